@@ -0,0 +1,411 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process"
+	"github.com/urfave/cli"
+)
+
+var (
+	runCommand = cli.Command{
+		Name:   "run",
+		Usage:  "Fetches the accounts with stake and indexes a new accounts snapshot. This is the default command",
+		Flags:  []cli.Flag{force, daemon},
+		Action: startAccountsManager,
+	}
+
+	inspectAccountCommand = cli.Command{
+		Name:      "inspect-account",
+		Usage:     "Explains how every stake field of an address is derived from its sources and compares it with the indexed snapshot",
+		ArgsUsage: "<address>",
+		Flags:     []cli.Flag{epoch, jsonOutput},
+		Action:    inspectAccount,
+	}
+
+	listSnapshotsCommand = cli.Command{
+		Name:   "list-snapshots",
+		Usage:  "Lists the accounts snapshots of every destination client",
+		Action: listSnapshots,
+	}
+
+	verifyCommand = cli.Command{
+		Name:      "verify",
+		Usage:     "Checks the accounts snapshot of an epoch on every destination client",
+		ArgsUsage: "<epoch>",
+		Action:    verify,
+	}
+
+	exportCommand = cli.Command{
+		Name:      "export",
+		Usage:     "Exports the accounts snapshot of an epoch in the configured file sinks",
+		ArgsUsage: "<epoch>",
+		Action:    export,
+	}
+
+	diffCommand = cli.Command{
+		Name:      "diff",
+		Usage:     "Compares the total balances with stake of the accounts snapshots of two epochs",
+		ArgsUsage: "<epoch before> <epoch after>",
+		Action:    diff,
+	}
+
+	pruneCommand = cli.Command{
+		Name:   "prune",
+		Usage:  "Deletes the old epoch indices and their values documents, keeping the aliased indices",
+		Flags:  []cli.Flag{dryRun, keepEpochs, keepDays},
+		Action: prune,
+	}
+
+	exportEligibilityCommand = cli.Command{
+		Name:      "export-eligibility",
+		Usage:     "Exports the eligible amounts, the merkle root and the merkle proofs of an epoch accounts index",
+		ArgsUsage: "<epoch>",
+		Action:    exportEligibility,
+	}
+
+	validateConfigCommand = cli.Command{
+		Name:   "validate-config",
+		Usage:  "Checks the configuration file and the indices config folder without connecting to any service",
+		Action: validateConfig,
+	}
+)
+
+func inspectAccount(ctx *cli.Context) error {
+	generalConfig, err := initializeCommand(ctx)
+	if err != nil {
+		return err
+	}
+
+	address := ctx.Args().First()
+	if address == "" {
+		return fmt.Errorf("the <address> argument is required")
+	}
+
+	index, err := getSnapshotIndex(ctx, generalConfig)
+	if err != nil {
+		return err
+	}
+
+	inspector, err := process.CreateAccountInspector(generalConfig)
+	if err != nil {
+		return err
+	}
+
+	inspection, err := inspector.Inspect(address, index)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool(jsonOutput.Name) {
+		return printJSON(inspection)
+	}
+
+	printInspection(inspection)
+
+	return nil
+}
+
+func printInspection(inspection *process.AccountInspection) {
+	fmt.Printf("Address: %s\nCurrent epoch: %d\nIndex: %s\n", inspection.Address, inspection.Epoch, inspection.Index)
+	if inspection.Document == nil {
+		fmt.Println("The address is not indexed in the snapshot")
+	}
+	fmt.Println()
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "FIELD\tSOURCE\tSOURCE VALUE\tMERGED\tINDEXED\tMATCH")
+	for _, field := range inspection.Fields {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%t\n",
+			field.Field, field.Source, field.SourceValue, field.Merged, field.Indexed, field.Matches)
+	}
+	_ = writer.Flush()
+
+	sources := inspection.Sources
+	if sources.LegacyDelegation != nil {
+		fmt.Printf("\nLegacy delegation: active entries %v, waiting entries %v\n",
+			sources.LegacyDelegation.ActiveEntries, sources.LegacyDelegation.WaitingEntries)
+	}
+	if sources.Validators != nil {
+		fmt.Printf("\nValidators: %s\n", sources.Validators)
+	}
+	if sources.Delegation != nil {
+		fmt.Printf("\nDelegation: %s\n", sources.Delegation)
+	}
+	if sources.LKMEX != nil {
+		fmt.Printf("\nLKMEX: raw value %s, value %s\n", sources.LKMEX.RawValue, sources.LKMEX.Value)
+	}
+	if sources.Energy != nil {
+		energy := sources.Energy
+		fmt.Printf("\nEnergy:\n  storage key: %s\n  raw value: %s\n", energy.StorageKey, energy.RawValue)
+		fmt.Printf("  amount: %s, total locked tokens: %s, last update epoch: %d\n",
+			energy.Details.Amount, energy.Details.TotalLockedTokens, energy.Details.LastUpdateEpoch)
+		fmt.Printf("  decay: (%d - %d) * %s = %s\n",
+			energy.CurrentEpoch, energy.Details.LastUpdateEpoch, energy.Details.TotalLockedTokens, energy.Decay)
+		fmt.Printf("  energy: %s - %s = %s\n", energy.Details.Amount, energy.Decay, energy.Energy)
+		if energy.Ignored {
+			fmt.Println("  the energy is negative and the account is ignored")
+		}
+	}
+}
+
+// getSnapshotIndex returns the index of the --epoch flag or the latest snapshot of the first destination client
+func getSnapshotIndex(ctx *cli.Context, generalConfig *config.Config) (string, error) {
+	if ctx.IsSet(epoch.Name) {
+		return process.ComputeAccountsIndexName(uint32(ctx.Uint64(epoch.Name))), nil
+	}
+
+	lister, err := process.CreateSnapshotsLister(generalConfig)
+	if err != nil {
+		return "", err
+	}
+
+	latestSnapshot, err := lister.GetLatestSnapshot()
+	if err != nil {
+		return "", err
+	}
+
+	return latestSnapshot.Index, nil
+}
+
+func listSnapshots(ctx *cli.Context) error {
+	generalConfig, err := initializeCommand(ctx)
+	if err != nil {
+		return err
+	}
+
+	lister, err := process.CreateSnapshotsLister(generalConfig)
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := lister.ListSnapshots()
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "CLIENT\tINDEX\tEPOCH\tCREATED\tDOCUMENTS\tALIASES")
+	for _, snapshot := range snapshots {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%d\t%s\t%d\t%s\n",
+			generalConfig.Destination.DestinationElasticSearchClients[snapshot.ClientIndex].Address,
+			snapshot.Index,
+			snapshot.Epoch,
+			snapshot.CreationDate.UTC().Format(time.RFC3339),
+			snapshot.NumDocuments,
+			strings.Join(snapshot.Aliases, ","),
+		)
+	}
+
+	return writer.Flush()
+}
+
+func verify(ctx *cli.Context) error {
+	generalConfig, err := initializeCommand(ctx)
+	if err != nil {
+		return err
+	}
+
+	epochValue, err := parseEpochArgument(ctx, 0)
+	if err != nil {
+		return err
+	}
+
+	verifier, err := process.CreateSnapshotVerifier(generalConfig)
+	if err != nil {
+		return err
+	}
+
+	results, err := verifier.Verify(process.ComputeAccountsIndexName(epochValue), epochValue)
+	if results != nil {
+		errPrint := printJSON(results)
+		if errPrint != nil {
+			return errPrint
+		}
+	}
+
+	return err
+}
+
+func export(ctx *cli.Context) error {
+	generalConfig, err := initializeCommand(ctx)
+	if err != nil {
+		return err
+	}
+
+	epochValue, err := parseEpochArgument(ctx, 0)
+	if err != nil {
+		return err
+	}
+
+	exporter, err := process.CreateSnapshotExporter(generalConfig)
+	if err != nil {
+		return err
+	}
+
+	err = exporter.Export(process.ComputeAccountsIndexName(epochValue), epochValue)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Done.")
+
+	return nil
+}
+
+func diff(ctx *cli.Context) error {
+	generalConfig, err := initializeCommand(ctx)
+	if err != nil {
+		return err
+	}
+
+	epochBefore, err := parseEpochArgument(ctx, 0)
+	if err != nil {
+		return err
+	}
+	epochAfter, err := parseEpochArgument(ctx, 1)
+	if err != nil {
+		return err
+	}
+
+	differ, err := process.CreateSnapshotsDiffer(generalConfig)
+	if err != nil {
+		return err
+	}
+
+	result, err := differ.Diff(process.ComputeAccountsIndexName(epochBefore), process.ComputeAccountsIndexName(epochAfter))
+	if err != nil {
+		return err
+	}
+
+	return printJSON(result)
+}
+
+func prune(ctx *cli.Context) error {
+	generalConfig, err := initializeCommand(ctx)
+	if err != nil {
+		return err
+	}
+
+	if ctx.IsSet(keepEpochs.Name) {
+		generalConfig.Retention.KeepEpochs = ctx.Int(keepEpochs.Name)
+	}
+	if ctx.IsSet(keepDays.Name) {
+		generalConfig.Retention.KeepDays = ctx.Int(keepDays.Name)
+	}
+
+	err = pruneSnapshots(generalConfig, ctx.Bool(dryRun.Name))
+	if err != nil {
+		return err
+	}
+
+	log.Info("Done.")
+
+	return nil
+}
+
+func pruneSnapshots(generalConfig *config.Config, isDryRun bool) error {
+	retentionManager, err := process.CreateRetentionManager(generalConfig, isDryRun)
+	if err != nil {
+		return err
+	}
+
+	_, err = retentionManager.Prune()
+
+	return err
+}
+
+func exportEligibility(ctx *cli.Context) error {
+	generalConfig, err := initializeCommand(ctx)
+	if err != nil {
+		return err
+	}
+
+	epochValue, err := parseEpochArgument(ctx, 0)
+	if err != nil {
+		return err
+	}
+
+	exporter, err := process.CreateEligibilityExporter(generalConfig)
+	if err != nil {
+		return err
+	}
+
+	err = exporter.ExportEligibility(process.ComputeAccountsIndexName(epochValue), epochValue)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Done.")
+
+	return nil
+}
+
+func validateConfig(ctx *cli.Context) error {
+	err := initializeLogger(ctx)
+	if err != nil {
+		return err
+	}
+
+	configurationFileName := ctx.GlobalString(configurationFile.Name)
+	generalConfig, err := config.LoadConfig(configurationFileName)
+	if err != nil {
+		return process.NewCategorizedError(process.ErrConfig, err)
+	}
+
+	result := process.ValidateConfig(generalConfig, ctx.GlobalString(indicesConfigPath.Name))
+	for _, warning := range result.Warnings {
+		fmt.Printf("WARNING %s\n", warning)
+	}
+	for _, validationError := range result.Errors {
+		fmt.Printf("ERROR   %s\n", validationError)
+	}
+
+	err = result.Err()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s is valid\n", configurationFileName)
+
+	return nil
+}
+
+// initializeCommand will initialize the logger and load the main configuration file of a command. The indices config
+// folder is not checked, as it is read only by the runs
+func initializeCommand(ctx *cli.Context) (*config.Config, error) {
+	err := initializeLogger(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return loadMainConfig(ctx.GlobalString(configurationFile.Name), "")
+}
+
+func parseEpochArgument(ctx *cli.Context, position int) (uint32, error) {
+	argument := ctx.Args().Get(position)
+	if argument == "" {
+		return 0, fmt.Errorf("the <epoch> argument number %d is required", position+1)
+	}
+
+	epochValue, err := strconv.ParseUint(argument, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid epoch %s: %w", argument, err)
+	}
+
+	return uint32(epochValue), nil
+}
+
+func printJSON(value interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(value)
+}
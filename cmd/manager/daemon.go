@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/metrics"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process"
+)
+
+type metricsServerHandler interface {
+	Start() error
+	Close() error
+	IsInterfaceNil() bool
+}
+
+// startMetrics returns the metrics handler of the runs and the started server exposing its metrics. The returned server
+// is nil if the metrics are disabled
+func startMetrics(cfg *config.Config) (process.MetricsHandler, metricsServerHandler, error) {
+	if !cfg.Metrics.Enabled {
+		return metrics.NewDisabledMetrics(), nil, nil
+	}
+
+	prometheusMetrics := metrics.NewPrometheusMetrics()
+	server, err := metrics.NewMetricsServer(metrics.ArgsMetricsServer{
+		ListenAddress: cfg.Metrics.ListenAddress,
+		Handler:       prometheusMetrics.Handler(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = server.Start()
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot start the metrics server: %w", err)
+	}
+
+	return prometheusMetrics, server, nil
+}
+
+func closeMetrics(server metricsServerHandler) {
+	if check.IfNil(server) {
+		return
+	}
+
+	err := server.Close()
+	if err != nil {
+		log.Warn("cannot close the metrics server", "error", err)
+	}
+}
+
+// runDaemon will start a run for every new epoch until the process is interrupted. A failed run is retried at the next
+// check of the epoch, while an interruption stops the daemon only after the current run
+func runDaemon(components *managerComponents) error {
+	pollInterval := time.Duration(components.config.Daemon.PollIntervalInSec) * time.Second
+	if pollInterval <= 0 {
+		return process.NewCategorizedError(process.ErrConfig,
+			fmt.Errorf("invalid Daemon.PollIntervalInSec value %d", components.config.Daemon.PollIntervalInSec))
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	log.Info("started in daemon mode", "poll interval", pollInterval)
+
+	processedEpoch := uint32(0)
+	hasProcessedEpoch := false
+	for {
+		epoch, err := components.dataProcessor.GetCurrentEpoch()
+		switch {
+		case err != nil:
+			log.Error("cannot get the current epoch, will retry at the next check", "error", err)
+		case hasProcessedEpoch && epoch == processedEpoch:
+			log.Debug("the current epoch was already processed", "epoch", epoch)
+		default:
+			err = runOnce(components)
+			if err != nil {
+				log.Error("run failed, will retry at the next check", "exit code", process.ExitCode(err), "error", err)
+				break
+			}
+
+			processedEpoch = components.dataProcessor.GetRunReport().Epoch
+			hasProcessedEpoch = true
+			log.Info("run done, waiting for the next epoch", "processed epoch", processedEpoch)
+		}
+
+		select {
+		case sig := <-interrupt:
+			log.Info("stopping the daemon", "signal", sig.String())
+			return nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
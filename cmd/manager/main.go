@@ -3,7 +3,6 @@ package main
 import (
 	"os"
 
-	"github.com/multiversx/mx-chain-core-go/core"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process"
@@ -25,6 +24,18 @@ var (
 		Value: "./config/indices",
 	}
 
+	// force defines a flag that continues the run even if the pre-flight checks of the destinations or the guardrails fail
+	force = cli.BoolFlag{
+		Name:  "force",
+		Usage: "Boolean option for continuing the run even if the mapping from the indices folder is incompatible with the previous snapshot indices or the merged accounts violate the guardrails",
+	}
+
+	// daemon defines a flag that keeps the manager running, starting a run for every new epoch
+	daemon = cli.BoolFlag{
+		Name:  "daemon",
+		Usage: "Boolean option for keeping the manager running and starting a run for every new epoch, the epoch being checked every Daemon.PollIntervalInSec seconds",
+	}
+
 	// logLevel defines the logger level
 	logLevel = cli.StringFlag{
 		Name: "log-level",
@@ -39,6 +50,36 @@ var (
 		Name:  "log-save",
 		Usage: "Boolean option for enabling log saving. If set, it will automatically save all the logs into a file.",
 	}
+
+	// epoch defines a flag for the epoch of the accounts index a command should work with
+	epoch = cli.Uint64Flag{
+		Name:  "epoch",
+		Usage: "The epoch of the accounts index",
+	}
+
+	// jsonOutput defines a flag that prints the output of a command in JSON format
+	jsonOutput = cli.BoolFlag{
+		Name:  "json",
+		Usage: "Boolean option for printing the output in JSON format",
+	}
+
+	// dryRun defines a flag that lists what the prune command would delete without deleting anything
+	dryRun = cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Boolean option for listing the indices and the documents that would be deleted, without deleting them",
+	}
+
+	// keepEpochs defines a flag that overrides the number of epoch indices kept by the retention
+	keepEpochs = cli.IntFlag{
+		Name:  "keep-epochs",
+		Usage: "The number of the most recent epoch indices to keep. Overrides the Retention.KeepEpochs config value",
+	}
+
+	// keepDays defines a flag that overrides the time window of the indices kept by the retention
+	keepDays = cli.IntFlag{
+		Name:  "keep-days",
+		Usage: "The number of days the epoch indices are kept for. Overrides the Retention.KeepDays config value",
+	}
 )
 
 func main() {
@@ -52,6 +93,8 @@ func main() {
 		logLevel,
 		logSaveFile,
 		indicesConfigPath,
+		force,
+		daemon,
 	}
 	app.Authors = []cli.Author{
 		{
@@ -61,14 +104,34 @@ func main() {
 	}
 
 	app.Action = startAccountsManager
+	app.Commands = []cli.Command{
+		runCommand,
+		inspectAccountCommand,
+		listSnapshotsCommand,
+		verifyCommand,
+		exportCommand,
+		diffCommand,
+		pruneCommand,
+		exportEligibilityCommand,
+		validateConfigCommand,
+	}
 
 	err := app.Run(os.Args)
 	if err != nil {
 		log.Error(err.Error())
-		os.Exit(1)
+		os.Exit(process.ExitCode(err))
 	}
 }
 
+// managerComponents holds the components created once and reused by every run of the daemon mode
+type managerComponents struct {
+	config         *config.Config
+	dataProcessor  process.DataProcessor
+	reportWriter   process.RunReportWriter
+	runNotifier    process.RunNotifier
+	metricsHandler process.MetricsHandler
+}
+
 func startAccountsManager(ctx *cli.Context) error {
 	err := initializeLogger(ctx)
 	if err != nil {
@@ -78,17 +141,50 @@ func startAccountsManager(ctx *cli.Context) error {
 	log.Info("Starting accounts-manager...")
 
 	configurationFileName := ctx.GlobalString(configurationFile.Name)
-	generalConfig, err := loadMainConfig(configurationFileName)
+	generalConfig, err := loadMainConfig(configurationFileName, ctx.GlobalString(indicesConfigPath.Name))
 	if err != nil {
 		return err
 	}
 
-	dataProc, err := process.CreateDataProcessor(generalConfig, ctx.GlobalString(indicesConfigPath.Name))
+	metricsHandler, metricsServer, err := startMetrics(generalConfig)
 	if err != nil {
-		return err
+		return process.NewCategorizedError(process.ErrConfig, err)
+	}
+	defer closeMetrics(metricsServer)
+
+	dataProc, err := process.CreateDataProcessor(process.ArgsDataProcessorFactory{
+		Config:            generalConfig,
+		IndicesConfigPath: ctx.GlobalString(indicesConfigPath.Name),
+		Force:             isBoolFlagSet(ctx, force),
+		MetricsHandler:    metricsHandler,
+	})
+	if err != nil {
+		return process.NewCategorizedError(process.ErrConfig, err)
+	}
+	defer closeDataProcessor(dataProc)
+
+	reportWriter, err := process.CreateRunReportWriter(generalConfig)
+	if err != nil {
+		return process.NewCategorizedError(process.ErrConfig, err)
+	}
+
+	runNotifier, err := process.CreateRunNotifier(generalConfig)
+	if err != nil {
+		return process.NewCategorizedError(process.ErrConfig, err)
+	}
+
+	components := &managerComponents{
+		config:         generalConfig,
+		dataProcessor:  dataProc,
+		reportWriter:   reportWriter,
+		runNotifier:    runNotifier,
+		metricsHandler: metricsHandler,
+	}
+	if isBoolFlagSet(ctx, daemon) {
+		return runDaemon(components)
 	}
 
-	err = dataProc.ProcessAccountsData()
+	err = runOnce(components)
 	if err != nil {
 		return err
 	}
@@ -98,12 +194,71 @@ func startAccountsManager(ctx *cli.Context) error {
 	return nil
 }
 
-func loadMainConfig(filepath string) (*config.Config, error) {
-	cfg := &config.Config{}
-	err := core.LoadTomlFile(cfg, filepath)
+// isBoolFlagSet returns true if the flag is set either before the command name or after it
+func isBoolFlagSet(ctx *cli.Context, flag cli.BoolFlag) bool {
+	return ctx.Bool(flag.Name) || ctx.GlobalBool(flag.Name)
+}
+
+func closeDataProcessor(dataProc process.DataProcessor) {
+	err := dataProc.Close()
+	if err != nil {
+		log.Warn("cannot close the data processor", "error", err)
+	}
+}
+
+// runOnce will process the accounts data, prune the old snapshots if enabled, notify the hooks and write the report of
+// the run, which also holds the anomalies found before notifying
+func runOnce(components *managerComponents) error {
+	err := processAccountsData(components.dataProcessor, components.config)
+
+	report := components.dataProcessor.GetRunReport()
+	report.Finish(err)
+	components.metricsHandler.ObserveRun(report.Epoch, report.EndTime.Sub(report.StartTime), err)
+
+	errNotify := components.runNotifier.Notify(report)
+	if errNotify != nil {
+		log.Warn("cannot notify all the hooks", "error", errNotify)
+	}
+
+	errWrite := components.reportWriter.Write(report)
+	if errWrite != nil {
+		log.Warn("cannot write the run report", "error", errWrite)
+	}
+
+	return err
+}
+
+func processAccountsData(dataProc process.DataProcessor, cfg *config.Config) error {
+	err := dataProc.ProcessAccountsData()
+	if err != nil {
+		return err
+	}
+
+	if !cfg.Retention.PruneAfterRun {
+		return nil
+	}
+
+	return process.NewCategorizedError(process.ErrElasticsearch, pruneSnapshots(cfg, false))
+}
+
+// loadMainConfig will load and validate the main configuration file. The indices config folder is checked as well if
+// its path is not empty
+func loadMainConfig(filepath string, indicesPath string) (*config.Config, error) {
+	cfg, err := config.LoadConfig(filepath)
+	if err != nil {
+		return nil, process.NewCategorizedError(process.ErrConfig, err)
+	}
+
+	result := process.ValidateConfig(cfg, indicesPath)
+	for _, warning := range result.Warnings {
+		log.Warn("config", "warning", warning)
+	}
+
+	err = result.Err()
 	if err != nil {
 		return nil, err
 	}
+
 	return cfg, nil
 }
 
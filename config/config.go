@@ -13,11 +13,28 @@ type Config struct {
 	}
 	Reindexer struct {
 		SourceElasticSearchClient data.EsClientConfig
+		NumSlices                 int
 	}
 	Destination struct {
+		Types                           []string
+		QueueSize                       int
+		BulkSizeInBytes                 int
+		MaxInFlightBulks                int
 		DestinationElasticSearchClients []data.EsClientConfig `toml:"DestinationElasticSearchClients"`
+		FileSinks                       []FileSinkConfig
+		DestinationSQLDatabases         []SQLSinkConfig
 	}
-	APIConfig APIConfig
+	APIConfig      APIConfig
+	AddressLabels  AddressLabelsConfig
+	AccountsFilter AccountsFilterConfig
+	Eligibility    EligibilityConfig
+	Retention      RetentionConfig
+	Verification   VerificationConfig
+	RunReport      RunReportConfig
+	Metrics        MetricsConfig
+	Daemon         DaemonConfig
+	Notifications  NotificationsConfig
+	Guardrails     GuardrailsConfig
 }
 
 // GeneralConfig will hold the general settings for an accounts manager
@@ -33,3 +50,123 @@ type APIConfig struct {
 	Username string
 	Password string
 }
+
+// AddressLabelsConfig holds the configuration for the address labels registry
+type AddressLabelsConfig struct {
+	Path string
+}
+
+// AccountsFilterConfig holds the rules used to exclude accounts from the snapshot
+type AccountsFilterConfig struct {
+	ExcludeSmartContracts bool
+	ExcludedAddresses     []string
+	IncludedAddresses     []string
+	Shards                []uint32
+	Thresholds            []ThresholdConfig
+	IndexFilteredAccounts bool
+}
+
+// ThresholdConfig holds a rule that a numeric field of an account has to satisfy in order to be kept in the snapshot
+type ThresholdConfig struct {
+	Field    string
+	Operator string
+	Value    float64
+}
+
+// EligibilityConfig holds the configuration used to export an eligibility snapshot
+type EligibilityConfig struct {
+	OutputDirectory string
+	MinAmount       string
+	Weights         map[string]string
+}
+
+// FileSinkConfig holds the configuration of a sink that writes the merged accounts in a local file
+type FileSinkConfig struct {
+	Format      string
+	Compression string
+	Directory   string
+	Columns     []string
+	OnFailure   string
+}
+
+// SQLSinkConfig holds the configuration of a sink that writes the snapshots in a SQL database
+type SQLSinkConfig struct {
+	Driver      string
+	DSN         string
+	TablePrefix string
+	OnFailure   string
+}
+
+// RetentionConfig holds the rules used to prune the old accounts snapshots and their values documents
+type RetentionConfig struct {
+	PruneAfterRun bool
+	KeepEpochs    int
+	KeepDays      int
+}
+
+// VerificationConfig holds the settings of the verification of the index written by a run
+type VerificationConfig struct {
+	Enabled    bool
+	SampleSize int
+}
+
+// RunReportConfig holds the settings of the report written after every run
+type RunReportConfig struct {
+	Path          string
+	IndexInValues bool
+}
+
+// MetricsConfig holds the settings of the HTTP listener that exposes the Prometheus metrics
+type MetricsConfig struct {
+	Enabled       bool
+	ListenAddress string
+}
+
+// DaemonConfig holds the settings of the daemon mode, in which a run is started for every new epoch
+type DaemonConfig struct {
+	PollIntervalInSec int
+}
+
+// NotificationsConfig holds the hooks notified after every run and the thresholds of the anomaly checks
+type NotificationsConfig struct {
+	Webhooks          []WebhookNotifierConfig
+	ExecHooks         []ExecNotifierConfig
+	AnomalyThresholds []AnomalyThresholdConfig
+}
+
+// WebhookNotifierConfig holds the configuration of a hook that posts the notification of a run to an HTTP endpoint
+type WebhookNotifierConfig struct {
+	URL          string
+	Headers      map[string]string
+	Events       []string
+	TimeoutInSec int
+}
+
+// ExecNotifierConfig holds the configuration of a hook that runs a local command for the notification of a run
+type ExecNotifierConfig struct {
+	Command      string
+	Args         []string
+	Events       []string
+	TimeoutInSec int
+}
+
+// AnomalyThresholdConfig holds the maximum change of a stake statistic from the previous run, in percents
+type AnomalyThresholdConfig struct {
+	Field              string
+	MaxDropPercent     float64
+	MaxIncreasePercent float64
+}
+
+// GuardrailsConfig holds the sanity rules checked on the merged accounts before anything is written
+type GuardrailsConfig struct {
+	Enabled              bool
+	RequireNonZeroTotals bool
+	Sources              []SourceGuardrailConfig
+}
+
+// SourceGuardrailConfig holds the sanity rules of a stake source. A zero value disables the rule
+type SourceGuardrailConfig struct {
+	Name                       string
+	MinAccounts                int
+	MaxTotalStakeChangePercent float64
+}
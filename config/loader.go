@@ -0,0 +1,27 @@
+package config
+
+import (
+	"os"
+
+	"github.com/pelletier/go-toml"
+)
+
+// LoadConfig will decode the provided toml file, rejecting the keys that do not match a field of the config, like a
+// misspelled section or setting name
+func LoadConfig(filePath string) (*Config, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	cfg := &Config{}
+	err = toml.NewDecoder(file).Strict(true).Decode(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
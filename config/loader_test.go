@@ -0,0 +1,48 @@
+package config
+
+import (
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	filePath := path.Join(t.TempDir(), "config.toml")
+	require.Nil(t, ioutil.WriteFile(filePath, []byte(content), 0644))
+
+	return filePath
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := LoadConfig("../cmd/manager/config/config.toml")
+	require.Nil(t, err)
+	require.Equal(t, "bech32", cfg.AddressPubkeyConverter.Type)
+	require.NotEmpty(t, cfg.Destination.DestinationElasticSearchClients)
+
+	_, err = LoadConfig("missing.toml")
+	require.NotNil(t, err)
+}
+
+func TestLoadConfigRejectsUnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadConfig(writeConfigFile(t, "[GeneralConfig]\n    EnergyContractAdress = \"erd1\"\n"))
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "EnergyContractAdress")
+
+	_, err = LoadConfig(writeConfigFile(t, "[Retentoin]\n    KeepEpochs = 3\n"))
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "Retentoin")
+
+	_, err = LoadConfig(writeConfigFile(t, "[Guardrails]\n    Sources = [{ Name = \"delegation\", MinAcounts = 1 }]\n"))
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "MinAcounts")
+
+	cfg, err := LoadConfig(writeConfigFile(t, "[Eligibility]\n    Weights = { anyKey = \"1\" }\n"))
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{"anyKey": "1"}, cfg.Eligibility.Weights)
+}
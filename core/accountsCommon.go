@@ -6,15 +6,22 @@ import (
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
 )
 
-// MergeElasticAndRestAccounts will merge additional data from the rest into the existing data from elastic search
+// MergeElasticAndRestAccounts will merge additional data from the rest and the address labels into the existing data
+// from elastic search
 func MergeElasticAndRestAccounts(
 	accountsES, accountsRest map[string]*data.AccountInfoWithStakeValues,
+	addressLabels map[string]*data.AddressLabel,
 ) map[string]*data.AccountInfoWithStakeValues {
 	accounts := make(map[string]*data.AccountInfoWithStakeValues)
 
 	for address, account := range accountsES {
 		accounts[address] = account
 
+		addressLabel, ok := addressLabels[address]
+		if ok {
+			accounts[address].AddressLabel = *addressLabel
+		}
+
 		accountRest, ok := accountsRest[address]
 		if !ok {
 			accounts[address].TotalBalanceWithStake = accounts[address].Balance
@@ -40,8 +40,17 @@ func TestAccountsProcessor_PrepareAccountsForReindexing(t *testing.T) {
 		addresses[1]: accR2,
 	}
 
-	mReturn := MergeElasticAndRestAccounts(mES, mR)
+	labels := map[string]*data.AddressLabel{
+		addresses[1]: {
+			Label: "exchange wallet",
+			Tags:  []string{"exchange"},
+		},
+	}
+
+	mReturn := MergeElasticAndRestAccounts(mES, mR, labels)
 	require.Len(t, mReturn, 2)
+	require.Equal(t, data.AddressLabel{}, mReturn[addresses[0]].AddressLabel)
+	require.Equal(t, *labels[addresses[1]], mReturn[addresses[1]].AddressLabel)
 
 	for _, addr := range addresses {
 		require.Equal(t, mES[addr].Balance, mReturn[addr].Balance)
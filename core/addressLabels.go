@@ -0,0 +1,94 @@
+package core
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+const (
+	csvTagsSeparator     = "|"
+	csvAddressHeaderName = "address"
+)
+
+// LoadAddressLabels will load the address labels registry from the provided JSON or CSV file. An empty path will
+// return an empty registry.
+//
+// The JSON file has to contain an object: { "erd1...": { "label": "...", "tags": ["..."] } }
+// The CSV file has to contain the columns: address,label,tags where the tags are separated by "|"
+func LoadAddressLabels(path string) (map[string]*data.AddressLabel, error) {
+	if path == "" {
+		return make(map[string]*data.AddressLabel), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open address labels file: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return readAddressLabelsJSON(file)
+	case ".csv":
+		return readAddressLabelsCSV(file)
+	default:
+		return nil, fmt.Errorf("unsupported address labels file extension, path %s", path)
+	}
+}
+
+func readAddressLabelsJSON(reader io.Reader) (map[string]*data.AddressLabel, error) {
+	labels := make(map[string]*data.AddressLabel)
+	err := json.NewDecoder(reader).Decode(&labels)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode address labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+func readAddressLabelsCSV(reader io.Reader) (map[string]*data.AddressLabel, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read address labels: %w", err)
+	}
+
+	labels := make(map[string]*data.AddressLabel)
+	for idx, record := range records {
+		if idx == 0 && strings.EqualFold(record[0], csvAddressHeaderName) {
+			continue
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("invalid address labels record on line %d", idx+1)
+		}
+
+		addressLabel := &data.AddressLabel{
+			Label: record[1],
+			Tags:  make([]string, 0),
+		}
+		if len(record) > 2 {
+			for _, tag := range strings.Split(record[2], csvTagsSeparator) {
+				tag = strings.TrimSpace(tag)
+				if tag != "" {
+					addressLabel.Tags = append(addressLabel.Tags, tag)
+				}
+			}
+		}
+
+		labels[record[0]] = addressLabel
+	}
+
+	return labels, nil
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAddressLabels(t *testing.T) {
+	t.Parallel()
+
+	expectedLabels := map[string]*data.AddressLabel{
+		"erd1first": {
+			Label: "Exchange hot wallet",
+			Tags:  []string{"exchange", "hot-wallet"},
+		},
+		"erd1second": {
+			Label: "Burn address",
+			Tags:  []string{"burn"},
+		},
+	}
+
+	t.Run("empty path should return an empty registry", func(t *testing.T) {
+		t.Parallel()
+
+		labels, err := LoadAddressLabels("")
+		require.Nil(t, err)
+		require.Len(t, labels, 0)
+	})
+
+	t.Run("json file", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "labels.json")
+		content := `{
+			"erd1first": { "label": "Exchange hot wallet", "tags": ["exchange", "hot-wallet"] },
+			"erd1second": { "label": "Burn address", "tags": ["burn"] }
+		}`
+		require.Nil(t, os.WriteFile(path, []byte(content), 0644))
+
+		labels, err := LoadAddressLabels(path)
+		require.Nil(t, err)
+		require.Equal(t, expectedLabels, labels)
+	})
+
+	t.Run("csv file", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "labels.csv")
+		content := "address,label,tags\n" +
+			"erd1first,Exchange hot wallet,exchange|hot-wallet\n" +
+			"erd1second,Burn address,burn\n"
+		require.Nil(t, os.WriteFile(path, []byte(content), 0644))
+
+		labels, err := LoadAddressLabels(path)
+		require.Nil(t, err)
+		require.Equal(t, expectedLabels, labels)
+	})
+
+	t.Run("unsupported extension should error", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "labels.txt")
+		require.Nil(t, os.WriteFile(path, []byte(""), 0644))
+
+		labels, err := LoadAddressLabels(path)
+		require.NotNil(t, err)
+		require.Nil(t, labels)
+	})
+}
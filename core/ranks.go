@@ -0,0 +1,45 @@
+package core
+
+import (
+	"math"
+	"sort"
+)
+
+const percentilePrecision = 10000
+
+// ComputeRanks will compute the global rank and percentile for every value. The rank 1 is assigned to the highest
+// value, equal values share the same rank and the percentile is the percentage of values ranked strictly below.
+// Values that are not positive are not ranked, so they will have rank 0 and percentile 0
+func ComputeRanks(values []float64) ([]uint64, []float64) {
+	ranks := make([]uint64, len(values))
+	percentiles := make([]float64, len(values))
+
+	rankedIndices := make([]int, 0, len(values))
+	for idx, value := range values {
+		if value > 0 {
+			rankedIndices = append(rankedIndices, idx)
+		}
+	}
+	sort.SliceStable(rankedIndices, func(i, j int) bool {
+		return values[rankedIndices[i]] > values[rankedIndices[j]]
+	})
+
+	numRanked := len(rankedIndices)
+	for start := 0; start < numRanked; {
+		end := start
+		for end < numRanked && values[rankedIndices[end]] == values[rankedIndices[start]] {
+			end++
+		}
+
+		numBelow := numRanked - end
+		percentile := math.Round(float64(numBelow)/float64(numRanked)*100*percentilePrecision) / percentilePrecision
+		for _, idx := range rankedIndices[start:end] {
+			ranks[idx] = uint64(start + 1)
+			percentiles[idx] = percentile
+		}
+
+		start = end
+	}
+
+	return ranks, percentiles
+}
@@ -0,0 +1,27 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeRanks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty values", func(t *testing.T) {
+		t.Parallel()
+
+		ranks, percentiles := ComputeRanks(nil)
+		require.Len(t, ranks, 0)
+		require.Len(t, percentiles, 0)
+	})
+
+	t.Run("ties share the same rank and not positive values are not ranked", func(t *testing.T) {
+		t.Parallel()
+
+		ranks, percentiles := ComputeRanks([]float64{5, 10, 0, 5, 1})
+		require.Equal(t, []uint64{2, 1, 0, 2, 4}, ranks)
+		require.Equal(t, []float64{25, 75, 0, 25, 0}, percentiles)
+	})
+}
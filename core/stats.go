@@ -0,0 +1,44 @@
+package core
+
+import (
+	"math"
+)
+
+// ComputeGiniCoefficient will compute the Gini coefficient of the provided values. The values have to be sorted ascending
+func ComputeGiniCoefficient(sortedValues []float64) float64 {
+	numValues := len(sortedValues)
+	if numValues == 0 {
+		return 0
+	}
+
+	sum := float64(0)
+	weightedSum := float64(0)
+	for idx, value := range sortedValues {
+		sum += value
+		weightedSum += float64(idx+1) * value
+	}
+	if sum == 0 {
+		return 0
+	}
+
+	n := float64(numValues)
+	gini := 2*weightedSum/(n*sum) - (n+1)/n
+
+	return math.Max(gini, 0)
+}
+
+// ComputeTopPercentThreshold will return the minimum value an entry needs in order to be in the top percent of the
+// provided values. The values have to be sorted ascending
+func ComputeTopPercentThreshold(sortedValues []float64, topPercent float64) float64 {
+	numValues := len(sortedValues)
+	if numValues == 0 || topPercent <= 0 {
+		return 0
+	}
+
+	numInTop := int(math.Ceil(float64(numValues) * topPercent / 100))
+	if numInTop > numValues {
+		numInTop = numValues
+	}
+
+	return sortedValues[numValues-numInTop]
+}
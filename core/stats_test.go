@@ -0,0 +1,33 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeGiniCoefficient(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, float64(0), ComputeGiniCoefficient(nil))
+	require.Equal(t, float64(0), ComputeGiniCoefficient([]float64{0, 0, 0}))
+	require.Equal(t, float64(0), ComputeGiniCoefficient([]float64{5, 5, 5, 5}))
+	require.InDelta(t, 0.75, ComputeGiniCoefficient([]float64{0, 0, 0, 10}), 1e-9)
+	require.InDelta(t, 0.25, ComputeGiniCoefficient([]float64{1, 2, 3, 4}), 1e-9)
+}
+
+func TestComputeTopPercentThreshold(t *testing.T) {
+	t.Parallel()
+
+	values := make([]float64, 200)
+	for idx := range values {
+		values[idx] = float64(idx + 1)
+	}
+
+	require.Equal(t, float64(0), ComputeTopPercentThreshold(nil, 1))
+	require.Equal(t, float64(0), ComputeTopPercentThreshold(values, 0))
+	require.Equal(t, float64(199), ComputeTopPercentThreshold(values, 1))
+	require.Equal(t, float64(181), ComputeTopPercentThreshold(values, 10))
+	require.Equal(t, float64(1), ComputeTopPercentThreshold(values, 100))
+	require.Equal(t, float64(7), ComputeTopPercentThreshold([]float64{7}, 1))
+}
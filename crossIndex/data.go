@@ -7,6 +7,9 @@ import (
 // AccountsPolicyName is the name of the policy for the accounts index
 const AccountsPolicyName = "accounts-manager-retention-policy"
 
+// StakeStatsIDFormat is the format of the ID of the stake stats document of an epoch, in the values index
+const StakeStatsIDFormat = "stake-stats-%d"
+
 // AllAccountsResponse is a structure that matches the response format for an all accounts request
 type AllAccountsResponse struct {
 	ScrollID string `json:"_scroll_id"`
@@ -0,0 +1,250 @@
+package destinationWriter
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+const (
+	// FailurePolicyAbort will stop the whole run when the destination fails
+	FailurePolicyAbort = "abort"
+	// FailurePolicyMarkFailed will only mark the destination as failed and skip all its next operations
+	FailurePolicyMarkFailed = "markFailed"
+)
+
+var log = logger.GetOrCreate("crossIndex/destinationWriter")
+
+// ArgsDestinationWriter holds the arguments needed to create a new destination writer
+type ArgsDestinationWriter struct {
+	Destination   crossIndex.Destination
+	Name          string
+	QueueSize     int
+	FailurePolicy string
+	Metrics       crossIndex.MetricsHandler
+}
+
+type task struct {
+	name    string
+	handler func() error
+	done    chan error
+}
+
+type destinationWriter struct {
+	destination   crossIndex.Destination
+	name          string
+	failurePolicy string
+	queue         chan *task
+	metrics       crossIndex.MetricsHandler
+	closeOnce     sync.Once
+
+	mut         sync.RWMutex
+	errRun      error
+	numAccounts uint64
+	numBatches  uint64
+}
+
+// NewDestinationWriter will create a new instance of destinationWriter. All the operations of the wrapped destination
+// are executed in order on a single long-lived goroutine. The batches are queued in a bounded queue without waiting for
+// them to be written, so a slow destination does not stall the others, while all the other operations wait for the
+// queue to be drained
+func NewDestinationWriter(args ArgsDestinationWriter) (*destinationWriter, error) {
+	if check.IfNil(args.Destination) {
+		return nil, crossIndex.ErrNilDestination
+	}
+	if check.IfNil(args.Metrics) {
+		return nil, crossIndex.ErrNilMetricsHandler
+	}
+	if args.QueueSize < 1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidQueueSize, args.QueueSize)
+	}
+
+	failurePolicy := args.FailurePolicy
+	if failurePolicy == "" {
+		failurePolicy = FailurePolicyAbort
+	}
+	if failurePolicy != FailurePolicyAbort && failurePolicy != FailurePolicyMarkFailed {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFailurePolicy, failurePolicy)
+	}
+
+	dw := &destinationWriter{
+		destination:   args.Destination,
+		name:          args.Name,
+		failurePolicy: failurePolicy,
+		queue:         make(chan *task, args.QueueSize),
+		metrics:       args.Metrics,
+	}
+
+	go dw.processQueue()
+
+	return dw, nil
+}
+
+func (dw *destinationWriter) processQueue() {
+	for t := range dw.queue {
+		err := dw.getError()
+		if err == nil {
+			err = t.handler()
+			if err != nil {
+				dw.setError(t.name, err)
+			}
+		}
+
+		if t.done != nil {
+			t.done <- err
+		}
+	}
+}
+
+func (dw *destinationWriter) setError(operation string, err error) {
+	dw.mut.Lock()
+	dw.errRun = fmt.Errorf("destination %s, %s: %w", dw.name, operation, err)
+	dw.mut.Unlock()
+
+	if dw.failurePolicy == FailurePolicyMarkFailed {
+		log.Error("destination marked as failed, all its next operations will be skipped",
+			"destination", dw.name, "operation", operation, "error", err)
+	}
+}
+
+func (dw *destinationWriter) getError() error {
+	dw.mut.RLock()
+	defer dw.mut.RUnlock()
+
+	return dw.errRun
+}
+
+// returnedError hides the error of a destination marked as failed, so the run can continue with the other destinations
+func (dw *destinationWriter) returnedError(err error) error {
+	if dw.failurePolicy == FailurePolicyMarkFailed {
+		return nil
+	}
+
+	return err
+}
+
+func (dw *destinationWriter) execute(name string, handler func() error) error {
+	t := &task{
+		name:    name,
+		handler: handler,
+		done:    make(chan error, 1),
+	}
+	dw.queue <- t
+
+	return dw.returnedError(<-t.done)
+}
+
+// PrepareSnapshot will reset the state of a previous snapshot and prepare the wrapped destination for a new one
+func (dw *destinationWriter) PrepareSnapshot(index string, epoch uint32) error {
+	// wait for all the operations of a previous snapshot before resetting the error
+	_ = dw.execute("drain", func() error { return nil })
+
+	dw.mut.Lock()
+	dw.errRun = nil
+	dw.numAccounts = 0
+	dw.numBatches = 0
+	dw.mut.Unlock()
+
+	return dw.execute("PrepareSnapshot", func() error {
+		return dw.destination.PrepareSnapshot(index, epoch)
+	})
+}
+
+// WriteBatch will queue the provided accounts for writing, without copying them. It blocks only if the queue is full and
+// returns the error of a previous failed operation, if any
+func (dw *destinationWriter) WriteBatch(accounts map[string]*data.AccountInfoWithStakeValues) error {
+	err := dw.getError()
+	if err != nil {
+		return dw.returnedError(err)
+	}
+
+	dw.queue <- &task{
+		name: "WriteBatch",
+		handler: func() error {
+			errWrite := dw.destination.WriteBatch(accounts)
+			dw.metrics.AddDestinationBatch(dw.name, errWrite)
+			if errWrite != nil {
+				return errWrite
+			}
+
+			dw.mut.Lock()
+			dw.numAccounts += uint64(len(accounts))
+			dw.numBatches++
+			dw.mut.Unlock()
+
+			return nil
+		},
+	}
+
+	return nil
+}
+
+// WriteRanks will write the provided ranks after all the queued batches were written
+func (dw *destinationWriter) WriteRanks(ranks map[string]*data.AccountRanks) error {
+	return dw.execute("WriteRanks", func() error {
+		return dw.destination.WriteRanks(ranks)
+	})
+}
+
+// WriteMetadata will write the provided document after all the queued batches were written
+func (dw *destinationWriter) WriteMetadata(documentID string, document []byte) error {
+	return dw.execute("WriteMetadata", func() error {
+		return dw.destination.WriteMetadata(documentID, document)
+	})
+}
+
+// Finalize will finalize the snapshot after all the queued batches were written
+func (dw *destinationWriter) Finalize() error {
+	err := dw.execute("Finalize", func() error {
+		return dw.destination.Finalize()
+	})
+
+	errRun := dw.getError()
+	if errRun != nil && dw.failurePolicy == FailurePolicyMarkFailed {
+		log.Error("the snapshot of the failed destination is incomplete", "destination", dw.name, "error", errRun)
+	}
+
+	return err
+}
+
+// Close will stop the writing goroutine after all the queued operations and will close the wrapped destination. The
+// writer cannot be used after it was closed
+func (dw *destinationWriter) Close() error {
+	var err error
+	dw.closeOnce.Do(func() {
+		_ = dw.execute("drain", func() error { return nil })
+		close(dw.queue)
+
+		err = dw.destination.Close()
+	})
+
+	return err
+}
+
+// GetStats returns the number of accounts and batches written for the current snapshot and the error of the destination,
+// if it failed
+func (dw *destinationWriter) GetStats() *data.DestinationStats {
+	dw.mut.RLock()
+	defer dw.mut.RUnlock()
+
+	stats := &data.DestinationStats{
+		Name:        dw.name,
+		NumAccounts: dw.numAccounts,
+		NumBatches:  dw.numBatches,
+		Failed:      dw.errRun != nil,
+	}
+	if dw.errRun != nil {
+		stats.Error = dw.errRun.Error()
+	}
+
+	return stats
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (dw *destinationWriter) IsInterfaceNil() bool {
+	return dw == nil
+}
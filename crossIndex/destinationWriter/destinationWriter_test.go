@@ -0,0 +1,211 @@
+package destinationWriter
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func createArgs(destination crossIndex.Destination) ArgsDestinationWriter {
+	return ArgsDestinationWriter{
+		Destination: destination,
+		Name:        "test",
+		QueueSize:   2,
+		Metrics:     &mocks.MetricsHandlerStub{},
+	}
+}
+
+func TestNewDestinationWriter(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewDestinationWriter(createArgs(nil))
+	require.Equal(t, crossIndex.ErrNilDestination, err)
+
+	args := createArgs(&mocks.DestinationStub{})
+	args.Metrics = nil
+	_, err = NewDestinationWriter(args)
+	require.Equal(t, crossIndex.ErrNilMetricsHandler, err)
+
+	args = createArgs(&mocks.DestinationStub{})
+	args.QueueSize = 0
+	_, err = NewDestinationWriter(args)
+	require.True(t, errors.Is(err, ErrInvalidQueueSize))
+
+	args = createArgs(&mocks.DestinationStub{})
+	args.FailurePolicy = "retry"
+	_, err = NewDestinationWriter(args)
+	require.True(t, errors.Is(err, ErrInvalidFailurePolicy))
+
+	dw, err := NewDestinationWriter(createArgs(&mocks.DestinationStub{}))
+	require.Nil(t, err)
+	require.False(t, dw.IsInterfaceNil())
+	require.Equal(t, FailurePolicyAbort, dw.failurePolicy)
+}
+
+func TestDestinationWriter_OperationsAreExecutedInOrder(t *testing.T) {
+	t.Parallel()
+
+	mut := sync.Mutex{}
+	operations := make([]string, 0)
+	addOperation := func(operation string) {
+		mut.Lock()
+		operations = append(operations, operation)
+		mut.Unlock()
+	}
+
+	destination := &mocks.DestinationStub{
+		PrepareSnapshotCalled: func(_ string, _ uint32) error {
+			addOperation("prepare")
+			return nil
+		},
+		WriteBatchCalled: func(accounts map[string]*data.AccountInfoWithStakeValues) error {
+			// a slow destination should not block the caller until the queue is full
+			time.Sleep(10 * time.Millisecond)
+			for address := range accounts {
+				addOperation(address)
+			}
+			return nil
+		},
+		WriteRanksCalled: func(_ map[string]*data.AccountRanks) error {
+			addOperation("ranks")
+			return nil
+		},
+		WriteMetadataCalled: func(documentID string, _ []byte) error {
+			addOperation(documentID)
+			return nil
+		},
+		FinalizeCalled: func() error {
+			addOperation("finalize")
+			return nil
+		},
+	}
+	dw, _ := NewDestinationWriter(createArgs(destination))
+
+	require.Nil(t, dw.PrepareSnapshot("index", 1))
+	require.Nil(t, dw.WriteBatch(map[string]*data.AccountInfoWithStakeValues{"erd1a": {}}))
+	require.Nil(t, dw.WriteBatch(map[string]*data.AccountInfoWithStakeValues{"erd1b": {}}))
+	require.Nil(t, dw.WriteRanks(nil))
+	require.Nil(t, dw.WriteMetadata("doc", nil))
+	require.Nil(t, dw.Finalize())
+
+	require.Equal(t, []string{"prepare", "erd1a", "erd1b", "ranks", "doc", "finalize"}, operations)
+}
+
+func TestDestinationWriter_FailurePolicyAbort(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	numWrites := 0
+	destination := &mocks.DestinationStub{
+		WriteBatchCalled: func(_ map[string]*data.AccountInfoWithStakeValues) error {
+			numWrites++
+			return expectedErr
+		},
+	}
+	failedBatches := make(map[string]int)
+	args := createArgs(destination)
+	args.Metrics = &mocks.MetricsHandlerStub{
+		AddDestinationBatchCalled: func(name string, err error) {
+			if err != nil {
+				failedBatches[name]++
+			}
+		},
+	}
+	dw, _ := NewDestinationWriter(args)
+
+	require.Nil(t, dw.PrepareSnapshot("index", 1))
+	require.Nil(t, dw.WriteBatch(map[string]*data.AccountInfoWithStakeValues{}))
+
+	err := dw.WriteRanks(nil)
+	require.True(t, errors.Is(err, expectedErr))
+	require.True(t, errors.Is(dw.WriteBatch(map[string]*data.AccountInfoWithStakeValues{}), expectedErr))
+	require.True(t, errors.Is(dw.Finalize(), expectedErr))
+	require.Equal(t, 1, numWrites)
+	require.Equal(t, map[string]int{"test": 1}, failedBatches)
+
+	// a new snapshot resets the failure
+	require.Nil(t, dw.PrepareSnapshot("index", 2))
+}
+
+func TestDestinationWriter_FailurePolicyMarkFailed(t *testing.T) {
+	t.Parallel()
+
+	finalizeCalled := false
+	destination := &mocks.DestinationStub{
+		WriteBatchCalled: func(_ map[string]*data.AccountInfoWithStakeValues) error {
+			return errors.New("expected error")
+		},
+		FinalizeCalled: func() error {
+			finalizeCalled = true
+			return nil
+		},
+	}
+	args := createArgs(destination)
+	args.FailurePolicy = FailurePolicyMarkFailed
+	dw, _ := NewDestinationWriter(args)
+
+	require.Nil(t, dw.PrepareSnapshot("index", 1))
+	require.Nil(t, dw.WriteBatch(map[string]*data.AccountInfoWithStakeValues{}))
+	require.Nil(t, dw.WriteMetadata("doc", nil))
+	require.Nil(t, dw.WriteBatch(map[string]*data.AccountInfoWithStakeValues{}))
+	require.Nil(t, dw.Finalize())
+	require.False(t, finalizeCalled)
+
+	stats := dw.GetStats()
+	require.True(t, stats.Failed)
+	require.Equal(t, uint64(0), stats.NumBatches)
+	require.Contains(t, stats.Error, "expected error")
+}
+
+func TestDestinationWriter_GetStats(t *testing.T) {
+	t.Parallel()
+
+	dw, _ := NewDestinationWriter(createArgs(&mocks.DestinationStub{}))
+	accounts := map[string]*data.AccountInfoWithStakeValues{"addr1": {}, "addr2": {}}
+
+	require.Nil(t, dw.PrepareSnapshot("index", 1))
+	require.Nil(t, dw.WriteBatch(accounts))
+	require.Nil(t, dw.WriteBatch(accounts))
+	require.Nil(t, dw.Finalize())
+	require.Equal(t, &data.DestinationStats{Name: "test", NumAccounts: 4, NumBatches: 2}, dw.GetStats())
+
+	require.Nil(t, dw.PrepareSnapshot("index", 2))
+	require.Equal(t, &data.DestinationStats{Name: "test"}, dw.GetStats())
+}
+
+func TestDestinationWriter_CloseWaitsForTheQueuedBatches(t *testing.T) {
+	t.Parallel()
+
+	mut := sync.Mutex{}
+	operations := make([]string, 0)
+	addOperation := func(operation string) {
+		mut.Lock()
+		operations = append(operations, operation)
+		mut.Unlock()
+	}
+
+	destination := &mocks.DestinationStub{
+		WriteBatchCalled: func(accounts map[string]*data.AccountInfoWithStakeValues) error {
+			time.Sleep(10 * time.Millisecond)
+			addOperation("batch")
+			return nil
+		},
+		CloseCalled: func() error {
+			addOperation("close")
+			return nil
+		},
+	}
+	dw, _ := NewDestinationWriter(createArgs(destination))
+
+	require.Nil(t, dw.WriteBatch(map[string]*data.AccountInfoWithStakeValues{"erd1a": {}}))
+	require.Nil(t, dw.Close())
+	require.Nil(t, dw.Close())
+
+	require.Equal(t, []string{"batch", "close"}, operations)
+}
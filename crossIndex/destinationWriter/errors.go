@@ -0,0 +1,9 @@
+package destinationWriter
+
+import "errors"
+
+// ErrInvalidFailurePolicy signals that an unknown failure policy has been provided
+var ErrInvalidFailurePolicy = errors.New("invalid failure policy")
+
+// ErrInvalidQueueSize signals that an invalid queue size has been provided
+var ErrInvalidQueueSize = errors.New("invalid queue size")
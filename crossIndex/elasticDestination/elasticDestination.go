@@ -0,0 +1,159 @@
+package elasticDestination
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/accountsIndexer"
+)
+
+var log = logger.GetOrCreate("crossIndex/elasticDestination")
+
+// ArgsElasticDestination holds the arguments needed to create a new elastic destination
+type ArgsElasticDestination struct {
+	Client              crossIndex.ElasticClientHandler
+	PathToIndicesConfig string
+	IndexPrefix         string
+	BulkSizeInBytes     int
+	MaxInFlightBulks    int
+	ForceMappingChanges bool
+}
+
+type elasticDestination struct {
+	client              crossIndex.ElasticClientHandler
+	accountsIndexer     crossIndex.AccountsIndexerHandler
+	pathToIndicesConfig string
+	indexPrefix         string
+	currentIndex        string
+	forceMappingChanges bool
+}
+
+// NewElasticDestination will create a new instance of elasticDestination. The IndexPrefix is prepended to the name of
+// the snapshot index, so different destinations can share the same cluster
+func NewElasticDestination(args ArgsElasticDestination) (*elasticDestination, error) {
+	if check.IfNil(args.Client) {
+		return nil, crossIndex.ErrNilElasticClient
+	}
+	if args.PathToIndicesConfig == "" {
+		return nil, ErrEmptyPathToIndicesConfig
+	}
+
+	acIndexer, err := accountsIndexer.NewAccountsIndexer(accountsIndexer.ArgsAccountsIndexer{
+		ElasticClient:    args.Client,
+		BulkSizeInBytes:  args.BulkSizeInBytes,
+		MaxInFlightBulks: args.MaxInFlightBulks,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &elasticDestination{
+		client:              args.Client,
+		accountsIndexer:     acIndexer,
+		pathToIndicesConfig: args.PathToIndicesConfig,
+		indexPrefix:         args.IndexPrefix,
+		forceMappingChanges: args.ForceMappingChanges,
+	}, nil
+}
+
+// PrepareSnapshot will check the mapping against the previous snapshots, put the accounts policy, install the index
+// templates, create the snapshot index and create the values index if it does not exist
+func (ed *elasticDestination) PrepareSnapshot(index string, _ uint32) error {
+	snapshotIndex := ed.indexPrefix + index
+	err := ed.checkMappingDrift(snapshotIndex)
+	if err != nil {
+		return err
+	}
+
+	policy, err := readPolicyForAccountsIndex(ed.pathToIndicesConfig)
+	if err != nil {
+		return err
+	}
+
+	// the policy is put first because OpenSearch attaches it to the index when the index is created
+	err = ed.client.PutPolicy(crossIndex.AccountsPolicyName, policy)
+	if err != nil {
+		return err
+	}
+
+	err = ed.installTemplates()
+	if err != nil {
+		return err
+	}
+
+	err = ed.client.CreateIndexWithMapping(snapshotIndex, createSnapshotIndexBody())
+	if err != nil {
+		return err
+	}
+
+	err = ed.checkAndCreateValuesIndex()
+	if err != nil {
+		return err
+	}
+
+	ed.currentIndex = snapshotIndex
+	log.Info("indexing accounts snapshot", "index", snapshotIndex)
+
+	return nil
+}
+
+func (ed *elasticDestination) checkAndCreateValuesIndex() error {
+	exists, err := ed.client.CheckIfIndexExists(valuesIndex)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	return ed.client.CreateIndexWithMapping(valuesIndex, bytes.NewBufferString("{}"))
+}
+
+// WriteBatch will index the provided accounts in the snapshot index
+func (ed *elasticDestination) WriteBatch(accounts map[string]*data.AccountInfoWithStakeValues) error {
+	if ed.currentIndex == "" {
+		return ErrSnapshotNotPrepared
+	}
+
+	return ed.accountsIndexer.IndexAccounts(accounts, ed.currentIndex)
+}
+
+// WriteRanks will update the ranks of the already indexed accounts
+func (ed *elasticDestination) WriteRanks(ranks map[string]*data.AccountRanks) error {
+	if ed.currentIndex == "" {
+		return ErrSnapshotNotPrepared
+	}
+
+	return ed.accountsIndexer.IndexAccountsRanks(ranks, ed.currentIndex)
+}
+
+// WriteMetadata will index the provided document in the values index
+func (ed *elasticDestination) WriteMetadata(documentID string, document []byte) error {
+	return ed.client.DoRequest(valuesIndex, documentID, bytes.NewBuffer(document))
+}
+
+// Finalize will mark the end of the current snapshot
+func (ed *elasticDestination) Finalize() error {
+	if ed.currentIndex == "" {
+		return ErrSnapshotNotPrepared
+	}
+
+	log.Info(fmt.Sprintf("indexed accounts snapshot in `%s` index", ed.currentIndex))
+	ed.currentIndex = ""
+
+	return nil
+}
+
+// Close does nothing because the elasticsearch client does not hold any resource that needs to be released
+func (ed *elasticDestination) Close() error {
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ed *elasticDestination) IsInterfaceNil() bool {
+	return ed == nil
+}
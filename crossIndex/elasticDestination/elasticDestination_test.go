@@ -0,0 +1,270 @@
+package elasticDestination
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+const pathToIndicesConfig = "../../cmd/manager/config/indices"
+
+func TestNewElasticDestination(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewElasticDestination(ArgsElasticDestination{PathToIndicesConfig: pathToIndicesConfig})
+	require.Equal(t, crossIndex.ErrNilElasticClient, err)
+
+	_, err = NewElasticDestination(ArgsElasticDestination{Client: &mocks.ElasticClientStub{}})
+	require.Equal(t, ErrEmptyPathToIndicesConfig, err)
+
+	destination, err := NewElasticDestination(ArgsElasticDestination{
+		Client:              &mocks.ElasticClientStub{},
+		PathToIndicesConfig: pathToIndicesConfig,
+	})
+	require.Nil(t, err)
+	require.False(t, destination.IsInterfaceNil())
+}
+
+func TestElasticDestination_WriteBeforePrepare(t *testing.T) {
+	t.Parallel()
+
+	destination, _ := NewElasticDestination(ArgsElasticDestination{
+		Client:              &mocks.ElasticClientStub{},
+		PathToIndicesConfig: pathToIndicesConfig,
+	})
+
+	require.Equal(t, ErrSnapshotNotPrepared, destination.WriteBatch(map[string]*data.AccountInfoWithStakeValues{}))
+	require.Equal(t, ErrSnapshotNotPrepared, destination.WriteRanks(map[string]*data.AccountRanks{}))
+	require.Equal(t, ErrSnapshotNotPrepared, destination.Finalize())
+}
+
+func TestElasticDestination_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	createdIndices := make([]string, 0)
+	bulkIndices := make([]string, 0)
+	requests := make(map[string]string)
+	client := &mocks.ElasticClientStub{
+		CreateIndexWithMappingCalled: func(index string, _ *bytes.Buffer) error {
+			createdIndices = append(createdIndices, index)
+			return nil
+		},
+		CheckIfIndexExistsCalled: func(index string) (bool, error) {
+			return false, nil
+		},
+		DoBulkRequestCalled: func(_ *bytes.Buffer, index string) error {
+			bulkIndices = append(bulkIndices, index)
+			return nil
+		},
+		DoRequestCalled: func(index, documentID string, buff *bytes.Buffer) error {
+			requests[index+"/"+documentID] = buff.String()
+			return nil
+		},
+	}
+	destination, _ := NewElasticDestination(ArgsElasticDestination{
+		Client:              client,
+		PathToIndicesConfig: pathToIndicesConfig,
+		IndexPrefix:         "filtered-",
+	})
+
+	require.Nil(t, destination.PrepareSnapshot("accounts-000001_5", 5))
+	require.Equal(t, []string{"filtered-accounts-000001_5", valuesIndex}, createdIndices)
+
+	accounts := map[string]*data.AccountInfoWithStakeValues{
+		"erd1a": {},
+	}
+	require.Nil(t, destination.WriteBatch(accounts))
+	require.Nil(t, destination.WriteRanks(map[string]*data.AccountRanks{"erd1a": {RankStake: 1}}))
+	require.Equal(t, []string{"filtered-accounts-000001_5", "filtered-accounts-000001_5"}, bulkIndices)
+
+	require.Nil(t, destination.WriteMetadata("stake-stats-5", []byte(`{"key":"stakeStats"}`)))
+	require.Equal(t, `{"key":"stakeStats"}`, requests[valuesIndex+"/stake-stats-5"])
+
+	require.Nil(t, destination.Finalize())
+}
+
+func TestElasticDestination_InstallTemplates(t *testing.T) {
+	t.Parallel()
+
+	installed := make(map[string][]byte)
+	putTemplate := func(name string, template *bytes.Buffer) error {
+		installed[name] = template.Bytes()
+		return nil
+	}
+	getTemplate := func(name string) ([]byte, error) {
+		return installed[name], nil
+	}
+	numPuts := 0
+	client := &mocks.ElasticClientStub{
+		PutIndexTemplateCalled: func(name string, template *bytes.Buffer) error {
+			numPuts++
+			return putTemplate(name, template)
+		},
+		PutComponentTemplateCalled: func(name string, template *bytes.Buffer) error {
+			numPuts++
+			return putTemplate(name, template)
+		},
+		GetIndexTemplateCalled:     getTemplate,
+		GetComponentTemplateCalled: getTemplate,
+	}
+	destination, _ := NewElasticDestination(ArgsElasticDestination{
+		Client:              client,
+		PathToIndicesConfig: pathToIndicesConfig,
+	})
+
+	require.Nil(t, destination.installTemplates())
+	require.Equal(t, 5, numPuts)
+	for _, name := range []string{
+		"accounts-manager-accounts",
+		"accounts-manager-values",
+		"accounts-manager-accounts-base",
+		"accounts-manager-accounts-stake",
+		"accounts-manager-accounts-ranks",
+	} {
+		require.Equal(t, "accounts-manager", gjson.GetBytes(installed[name], "_meta.managedBy").String())
+		require.Len(t, gjson.GetBytes(installed[name], "_meta.checksum").String(), 64)
+	}
+	require.Equal(t, "double", gjson.GetBytes(installed["accounts-manager-accounts-stake"], "template.mappings.properties.energyNum.type").String())
+	require.Equal(t, "double", gjson.GetBytes(installed["accounts-manager-accounts-stake"], "template.mappings.properties.lkMexStakeNum.type").String())
+
+	// unchanged templates are not installed again
+	require.Nil(t, destination.installTemplates())
+	require.Equal(t, 5, numPuts)
+
+	// a changed template file is upgraded
+	installed["accounts-manager-values"] = []byte(`{"_meta":{"checksum":"old"}}`)
+	require.Nil(t, destination.installTemplates())
+	require.Equal(t, 6, numPuts)
+}
+
+func TestElasticDestination_CheckMappingDrift(t *testing.T) {
+	t.Parallel()
+
+	liveMappings := map[string][]byte{
+		"accounts-000001_4": []byte(`{"properties":{"balanceNum":{"type":"double"},"energyNum":{"type":"keyword"},"energyDetails":{"properties":{"lastUpdateEpoch":{"type":"long"}}}}}`),
+		"accounts-000001_5": []byte(`{"properties":{"balanceNum":{"type":"keyword"}}}`),
+	}
+	requestedPattern := ""
+	client := &mocks.ElasticClientStub{
+		GetMappingCalled: func(index string) (map[string][]byte, error) {
+			requestedPattern = index
+			return liveMappings, nil
+		},
+	}
+
+	destination, _ := NewElasticDestination(ArgsElasticDestination{
+		Client:              client,
+		PathToIndicesConfig: pathToIndicesConfig,
+	})
+	err := destination.checkMappingDrift("accounts-000001_5")
+	require.True(t, errors.Is(err, ErrIncompatibleMapping))
+	require.Contains(t, err.Error(), "field energyNum is double in config but keyword in accounts-000001_4")
+	require.NotContains(t, err.Error(), "balanceNum")
+	require.Equal(t, "accounts-000001_*", requestedPattern)
+
+	destination, _ = NewElasticDestination(ArgsElasticDestination{
+		Client:              client,
+		PathToIndicesConfig: pathToIndicesConfig,
+		ForceMappingChanges: true,
+	})
+	require.Nil(t, destination.checkMappingDrift("accounts-000001_5"))
+
+	// additive changes are only reported
+	liveMappings["accounts-000001_4"] = []byte(`{"properties":{"balanceNum":{"type":"double"}}}`)
+	destination, _ = NewElasticDestination(ArgsElasticDestination{
+		Client:              client,
+		PathToIndicesConfig: pathToIndicesConfig,
+	})
+	require.Nil(t, destination.checkMappingDrift("accounts-000001_5"))
+}
+
+func TestElasticDestination_CheckMappingDriftAgainstPreviousReleaseSnapshots(t *testing.T) {
+	t.Parallel()
+
+	// mappings of the snapshots written before the templates were installed: the baseline release one, with the
+	// indexer legacy template and dynamically mapped stake fields, and one with dynamically mapped ranks
+	mappingsBytes, err := ioutil.ReadFile("testdata/previousSnapshotsMappings.json")
+	require.Nil(t, err)
+	liveMappings := make(map[string]json.RawMessage)
+	require.Nil(t, json.Unmarshal(mappingsBytes, &liveMappings))
+
+	client := &mocks.ElasticClientStub{
+		GetMappingCalled: func(_ string) (map[string][]byte, error) {
+			mappings := make(map[string][]byte, len(liveMappings))
+			for index, mapping := range liveMappings {
+				mappings[index] = mapping
+			}
+			return mappings, nil
+		},
+	}
+	destination, _ := NewElasticDestination(ArgsElasticDestination{
+		Client:              client,
+		PathToIndicesConfig: pathToIndicesConfig,
+	})
+
+	require.Nil(t, destination.checkMappingDrift("accounts-000001_5"))
+}
+
+func TestIsNumericWidening(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, isNumericWidening("float", "double"))
+	require.True(t, isNumericWidening("long", "integer"))
+	require.True(t, isNumericWidening("integer", "long"))
+	require.True(t, isNumericWidening("long", "double"))
+	require.False(t, isNumericWidening("double", "long"))
+	require.False(t, isNumericWidening("keyword", "double"))
+	require.False(t, isNumericWidening("long", "keyword"))
+}
+
+func TestReadAccountsMappingFields(t *testing.T) {
+	t.Parallel()
+
+	fields, err := readAccountsMappingFields(pathToIndicesConfig)
+	require.Nil(t, err)
+	require.Equal(t, "double", fields["lkMexStakeNum"])
+	require.Equal(t, "long", fields["rankStake"])
+	require.Equal(t, "object", fields["energyDetails"])
+	require.Equal(t, "long", fields["energyDetails.lastUpdateEpoch"])
+}
+
+func TestCheckIndicesConfig(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, ErrEmptyPathToIndicesConfig, CheckIndicesConfig(""))
+	require.Nil(t, CheckIndicesConfig(pathToIndicesConfig))
+
+	err := CheckIndicesConfig(t.TempDir())
+	require.True(t, errors.Is(err, ErrInvalidIndicesConfig))
+	require.Contains(t, err.Error(), accountsTemplateFileName)
+
+	invalidIndicesConfig := t.TempDir()
+	require.Nil(t, ioutil.WriteFile(path.Join(invalidIndicesConfig, accountsTemplateFileName), []byte(`{"composed_of": ["accounts-manager-missing"]}`), 0644))
+	require.Nil(t, ioutil.WriteFile(path.Join(invalidIndicesConfig, valuesTemplateFileName), []byte(`{}`), 0644))
+	require.Nil(t, ioutil.WriteFile(path.Join(invalidIndicesConfig, accountsPolicyFileName), []byte(`{"policy":`), 0644))
+	err = CheckIndicesConfig(invalidIndicesConfig)
+	require.True(t, errors.Is(err, ErrInvalidIndicesConfig))
+	require.Contains(t, err.Error(), "accounts-policy.json is not a valid JSON file")
+
+	require.Nil(t, ioutil.WriteFile(path.Join(invalidIndicesConfig, accountsPolicyFileName), []byte(`{}`), 0644))
+	err = CheckIndicesConfig(invalidIndicesConfig)
+	require.True(t, errors.Is(err, ErrInvalidIndicesConfig))
+	require.Contains(t, err.Error(), "missing.json")
+}
+
+func TestPreviousSnapshotsPattern(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "accounts-000001_*", previousSnapshotsPattern("accounts-000001_5"))
+	require.Equal(t, "filtered-accounts-000001_*", previousSnapshotsPattern("filtered-accounts-000001_1200"))
+	require.Equal(t, "accounts", previousSnapshotsPattern("accounts"))
+}
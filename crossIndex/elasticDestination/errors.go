@@ -0,0 +1,16 @@
+package elasticDestination
+
+import "errors"
+
+// ErrEmptyPathToIndicesConfig signals that an empty path to the indices config folder has been provided
+var ErrEmptyPathToIndicesConfig = errors.New("empty path to the indices config folder")
+
+// ErrSnapshotNotPrepared signals that the accounts are written before preparing the snapshot
+var ErrSnapshotNotPrepared = errors.New("snapshot not prepared")
+
+// ErrIncompatibleMapping signals that the mapping from the indices config changes the type of fields already indexed
+// in previous snapshots
+var ErrIncompatibleMapping = errors.New("incompatible mapping with previous snapshot indices")
+
+// ErrInvalidIndicesConfig signals that a file of the indices config folder is missing or is not a valid JSON
+var ErrInvalidIndicesConfig = errors.New("invalid indices config")
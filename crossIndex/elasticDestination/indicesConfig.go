@@ -0,0 +1,56 @@
+package elasticDestination
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// CheckIndicesConfig will check that the indices config folder holds the index templates, the policy of the accounts
+// index and the component templates the accounts index template is composed of, all of them valid JSON files
+func CheckIndicesConfig(pathToIndicesConfig string) error {
+	if len(pathToIndicesConfig) == 0 {
+		return ErrEmptyPathToIndicesConfig
+	}
+
+	filePaths := []string{
+		path.Join(pathToIndicesConfig, accountsTemplateFileName),
+		path.Join(pathToIndicesConfig, valuesTemplateFileName),
+		path.Join(pathToIndicesConfig, accountsPolicyFileName),
+	}
+	for _, filePath := range filePaths {
+		err := checkJSONFile(filePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	template, err := readFile(path.Join(pathToIndicesConfig, accountsTemplateFileName))
+	if err != nil {
+		return err
+	}
+	for _, component := range gjson.GetBytes(template.Bytes(), "composed_of").Array() {
+		componentFileName := strings.TrimPrefix(component.String(), templateNamePrefix) + ".json"
+		err = checkJSONFile(path.Join(pathToIndicesConfig, componentsFolderName, componentFileName))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkJSONFile(filePath string) error {
+	content, err := readFile(filePath)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidIndicesConfig, err.Error())
+	}
+	if !json.Valid(content.Bytes()) {
+		return fmt.Errorf("%w: %s is not a valid JSON file", ErrInvalidIndicesConfig, filePath)
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package elasticDestination
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+const objectFieldType = "object"
+
+var integerFieldTypes = map[string]struct{}{
+	"byte":    {},
+	"short":   {},
+	"integer": {},
+	"long":    {},
+}
+
+var floatingPointFieldTypes = map[string]struct{}{
+	"half_float": {},
+	"float":      {},
+	"double":     {},
+}
+
+type fieldDrift struct {
+	configType string
+	liveType   string
+	indices    []string
+}
+
+// checkMappingDrift will compare the mapping from the indices config folder with the live mappings of the previous
+// snapshot indices. Fields with a different type are incompatible and fail the check unless forced, while fields that
+// are missing from the previous indices and numeric fields that are widened, like float to double, are only reported
+func (ed *elasticDestination) checkMappingDrift(snapshotIndex string) error {
+	configFields, err := readAccountsMappingFields(ed.pathToIndicesConfig)
+	if err != nil {
+		return err
+	}
+
+	liveMappings, err := ed.client.GetMapping(previousSnapshotsPattern(snapshotIndex))
+	if err != nil {
+		return err
+	}
+
+	incompatible := make(map[string]*fieldDrift)
+	added := make(map[string]int)
+	widened := make(map[string]int)
+	numCheckedIndices := 0
+	for index, mapping := range liveMappings {
+		if index == snapshotIndex {
+			continue
+		}
+		numCheckedIndices++
+
+		liveFields := make(map[string]string)
+		flattenMappingProperties(gjson.GetBytes(mapping, "properties"), "", liveFields)
+		for field, configType := range configFields {
+			liveType, found := liveFields[field]
+			if !found {
+				added[field]++
+				continue
+			}
+			if liveType == configType {
+				continue
+			}
+			if isNumericWidening(liveType, configType) {
+				widened[fmt.Sprintf("%s (%s to %s)", field, liveType, configType)]++
+				continue
+			}
+
+			key := field + "/" + liveType
+			if _, ok := incompatible[key]; !ok {
+				incompatible[key] = &fieldDrift{configType: configType, liveType: liveType}
+			}
+			incompatible[key].indices = append(incompatible[key].indices, index)
+		}
+	}
+
+	if len(added) > 0 {
+		log.Info("mapping drift: fields missing from previous snapshot indices",
+			"fields", strings.Join(sortedKeys(added), ","), "num checked indices", numCheckedIndices)
+	}
+	if len(widened) > 0 {
+		log.Info("mapping drift: numeric fields widened since previous snapshot indices",
+			"fields", strings.Join(sortedKeys(widened), ","), "num checked indices", numCheckedIndices)
+	}
+	if len(incompatible) == 0 {
+		return nil
+	}
+
+	problems := make([]string, 0, len(incompatible))
+	for key, drift := range incompatible {
+		sort.Strings(drift.indices)
+		field := strings.Split(key, "/")[0]
+		problems = append(problems, fmt.Sprintf("field %s is %s in config but %s in %s",
+			field, drift.configType, drift.liveType, strings.Join(drift.indices, ",")))
+	}
+	sort.Strings(problems)
+
+	if ed.forceMappingChanges {
+		log.Warn("mapping drift: incompatible field types, continuing because the run is forced", "problems", strings.Join(problems, "; "))
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrIncompatibleMapping, strings.Join(problems, "; "))
+}
+
+// isNumericWidening returns true if the values of a numeric field of the previous snapshots fit in the type of the
+// config: an integer type replaced by another integer type or a numeric type replaced by a floating point type
+func isNumericWidening(liveType string, configType string) bool {
+	_, isLiveInteger := integerFieldTypes[liveType]
+	_, isLiveFloatingPoint := floatingPointFieldTypes[liveType]
+	_, isConfigInteger := integerFieldTypes[configType]
+	_, isConfigFloatingPoint := floatingPointFieldTypes[configType]
+
+	if isLiveInteger && isConfigInteger {
+		return true
+	}
+
+	return (isLiveInteger || isLiveFloatingPoint) && isConfigFloatingPoint
+}
+
+// readAccountsMappingFields returns the type of every field of the accounts index template, including the fields of
+// the component templates it is composed of
+func readAccountsMappingFields(pathToIndicesConfig string) (map[string]string, error) {
+	template, err := readFile(path.Join(pathToIndicesConfig, accountsTemplateFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]string)
+	for _, component := range gjson.GetBytes(template.Bytes(), "composed_of").Array() {
+		componentFileName := strings.TrimPrefix(component.String(), templateNamePrefix) + ".json"
+		componentTemplate, errRead := readFile(path.Join(pathToIndicesConfig, componentsFolderName, componentFileName))
+		if errRead != nil {
+			return nil, errRead
+		}
+
+		flattenMappingProperties(gjson.GetBytes(componentTemplate.Bytes(), "template.mappings.properties"), "", fields)
+	}
+	flattenMappingProperties(gjson.GetBytes(template.Bytes(), "template.mappings.properties"), "", fields)
+
+	return fields, nil
+}
+
+func flattenMappingProperties(properties gjson.Result, prefix string, fields map[string]string) {
+	properties.ForEach(func(key, value gjson.Result) bool {
+		field := prefix + key.String()
+		fieldType := value.Get("type").String()
+		subProperties := value.Get("properties")
+		if fieldType == "" && subProperties.Exists() {
+			fieldType = objectFieldType
+		}
+
+		fields[field] = fieldType
+		if subProperties.Exists() {
+			flattenMappingProperties(subProperties, field+".", fields)
+		}
+
+		return true
+	})
+}
+
+// previousSnapshotsPattern returns the pattern matching all the epochs of a snapshot index (accounts-000001_5 ->
+// accounts-000001_*)
+func previousSnapshotsPattern(snapshotIndex string) string {
+	separatorPosition := strings.LastIndex(snapshotIndex, "_")
+	if separatorPosition < 0 {
+		return snapshotIndex
+	}
+
+	return snapshotIndex[:separatorPosition+1] + "*"
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
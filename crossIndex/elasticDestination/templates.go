@@ -0,0 +1,145 @@
+package elasticDestination
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	accountsTemplateFileName = "accounts.json"
+	accountsPolicyFileName   = "accounts-policy.json"
+	valuesTemplateFileName   = "values.json"
+	componentsFolderName     = "components"
+	templateNamePrefix       = "accounts-manager-"
+	managedByValue           = "accounts-manager"
+	valuesIndex              = "values"
+)
+
+type putTemplateHandler func(name string, template *bytes.Buffer) error
+type getTemplateHandler func(name string) ([]byte, error)
+
+// installTemplates will install the component templates and the index templates from the indices config folder. A
+// template is upgraded only if the content of its file has changed since it was installed. The templates are applied
+// only to the indices created after the installation, so the existing snapshots keep their mappings
+func (ed *elasticDestination) installTemplates() error {
+	componentFiles, err := filepath.Glob(path.Join(ed.pathToIndicesConfig, componentsFolderName, "*.json"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(componentFiles)
+
+	for _, componentFile := range componentFiles {
+		name := templateNamePrefix + strings.TrimSuffix(filepath.Base(componentFile), ".json")
+		err = installTemplate(name, componentFile, ed.client.GetComponentTemplate, ed.client.PutComponentTemplate)
+		if err != nil {
+			return err
+		}
+	}
+
+	indexTemplateFiles := []string{accountsTemplateFileName, valuesTemplateFileName}
+	for _, indexTemplateFile := range indexTemplateFiles {
+		name := templateNamePrefix + strings.TrimSuffix(indexTemplateFile, ".json")
+		templatePath := path.Join(ed.pathToIndicesConfig, indexTemplateFile)
+		err = installTemplate(name, templatePath, ed.client.GetIndexTemplate, ed.client.PutIndexTemplate)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func installTemplate(name string, templatePath string, getTemplate getTemplateHandler, putTemplate putTemplateHandler) error {
+	template, err := readFile(templatePath)
+	if err != nil {
+		return err
+	}
+
+	checksum := computeChecksum(template.Bytes())
+	existingTemplate, err := getTemplate(name)
+	if err != nil {
+		return err
+	}
+	if gjson.GetBytes(existingTemplate, "_meta.checksum").String() == checksum {
+		log.Debug("template is up to date", "name", name)
+		return nil
+	}
+
+	templateWithMeta, err := addTemplateMeta(template.Bytes(), checksum)
+	if err != nil {
+		return fmt.Errorf("%w, template %s", err, templatePath)
+	}
+
+	err = putTemplate(name, bytes.NewBuffer(templateWithMeta))
+	if err != nil {
+		return err
+	}
+
+	action := "installed"
+	if len(existingTemplate) > 0 {
+		action = "upgraded"
+	}
+	log.Info("template "+action, "name", name, "version", gjson.GetBytes(templateWithMeta, "version").Int())
+
+	return nil
+}
+
+func addTemplateMeta(template []byte, checksum string) ([]byte, error) {
+	body := make(map[string]interface{})
+	err := json.Unmarshal(template, &body)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, ok := body["_meta"].(map[string]interface{})
+	if !ok {
+		meta = make(map[string]interface{})
+	}
+	meta["managedBy"] = managedByValue
+	meta["checksum"] = checksum
+	body["_meta"] = meta
+
+	return json.Marshal(body)
+}
+
+func computeChecksum(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+// createSnapshotIndexBody returns the body used to create a snapshot index. The mappings and the settings come from
+// the index template, but the lifecycle setting is kept in the body because OpenSearch attaches the policy to the index
+// after it is created
+func createSnapshotIndexBody() *bytes.Buffer {
+	return bytes.NewBufferString(fmt.Sprintf(`{"settings":{"index.lifecycle.name":"%s"}}`, crossIndex.AccountsPolicyName))
+}
+
+func readPolicyForAccountsIndex(pathToIndicesConfig string) (*bytes.Buffer, error) {
+	return readFile(path.Join(pathToIndicesConfig, accountsPolicyFileName))
+}
+
+func readFile(path string) (*bytes.Buffer, error) {
+	fileBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("readFile: %w, path %s, error %s", err, path, err.Error())
+	}
+
+	buff := &bytes.Buffer{}
+	_, err = buff.Write(fileBytes)
+	if err != nil {
+		return nil, fmt.Errorf("readFile: %w, path %s, error %s", err, path, err.Error())
+	}
+
+	return buff, nil
+}
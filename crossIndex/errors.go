@@ -4,3 +4,18 @@ import "errors"
 
 // ErrNilElasticClient signals that a nil elastic client has been provided
 var ErrNilElasticClient = errors.New("nil elastic search client")
+
+// ErrNilAccountsFilter signals that a nil accounts filter has been provided
+var ErrNilAccountsFilter = errors.New("nil accounts filter")
+
+// ErrNilDestination signals that a nil destination has been provided
+var ErrNilDestination = errors.New("nil destination")
+
+// ErrNilMetricsHandler signals that a nil metrics handler has been provided
+var ErrNilMetricsHandler = errors.New("nil metrics handler")
+
+// ErrEmptyDestinations signals that no destination has been provided
+var ErrEmptyDestinations = errors.New("empty destinations")
+
+// ErrInvalidAddressLabels signals that the address labels file cannot be loaded
+var ErrInvalidAddressLabels = errors.New("invalid address labels")
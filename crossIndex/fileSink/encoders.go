@@ -0,0 +1,215 @@
+package fileSink
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/tidwall/gjson"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+const (
+	csvArraySeparator = "|"
+	parquetNumWorkers = 1
+)
+
+var defaultCSVColumns = []string{
+	"address",
+	"balance",
+	"totalBalanceWithStake",
+	"delegationLegacyWaiting",
+	"delegationLegacyActive",
+	"validatorsActive",
+	"validatorsTopUp",
+	"delegation",
+	"totalStake",
+	"lkMexStake",
+	"energy",
+	"label",
+	"tags",
+	"rankTotalBalanceWithStake",
+	"percentileTotalBalanceWithStake",
+	"rankStake",
+	"percentileStake",
+	"rankEnergy",
+	"percentileEnergy",
+}
+
+type accountsEncoder interface {
+	encode(account *data.AccountInfoWithStakeValues) error
+	close() error
+}
+
+type ndjsonEncoder struct {
+	encoder *json.Encoder
+}
+
+func newNDJSONEncoder(w io.Writer) *ndjsonEncoder {
+	return &ndjsonEncoder{
+		encoder: json.NewEncoder(w),
+	}
+}
+
+func (ne *ndjsonEncoder) encode(account *data.AccountInfoWithStakeValues) error {
+	return ne.encoder.Encode(account)
+}
+
+func (ne *ndjsonEncoder) close() error {
+	return nil
+}
+
+type csvEncoder struct {
+	writer  *csv.Writer
+	columns []string
+}
+
+func newCSVEncoder(w io.Writer, columns []string) (*csvEncoder, error) {
+	if len(columns) == 0 {
+		columns = defaultCSVColumns
+	}
+
+	csvWriter := csv.NewWriter(w)
+	err := csvWriter.Write(columns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &csvEncoder{
+		writer:  csvWriter,
+		columns: columns,
+	}, nil
+}
+
+func (ce *csvEncoder) encode(account *data.AccountInfoWithStakeValues) error {
+	accountBytes, err := json.Marshal(account)
+	if err != nil {
+		return err
+	}
+
+	record := make([]string, len(ce.columns))
+	for idx, result := range gjson.GetManyBytes(accountBytes, ce.columns...) {
+		record[idx] = csvValue(result)
+	}
+
+	return ce.writer.Write(record)
+}
+
+func csvValue(result gjson.Result) string {
+	if !result.IsArray() {
+		return result.String()
+	}
+
+	values := make([]string, 0)
+	for _, value := range result.Array() {
+		values = append(values, value.String())
+	}
+
+	return strings.Join(values, csvArraySeparator)
+}
+
+func (ce *csvEncoder) close() error {
+	ce.writer.Flush()
+	return ce.writer.Error()
+}
+
+type parquetAccount struct {
+	Address                    string  `parquet:"name=address, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ShardID                    int64   `parquet:"name=shardID, type=INT64"`
+	Balance                    string  `parquet:"name=balance, type=BYTE_ARRAY, convertedtype=UTF8"`
+	BalanceNum                 float64 `parquet:"name=balanceNum, type=DOUBLE"`
+	TotalBalanceWithStake      string  `parquet:"name=totalBalanceWithStake, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TotalBalanceWithStakeNum   float64 `parquet:"name=totalBalanceWithStakeNum, type=DOUBLE"`
+	DelegationLegacyWaiting    string  `parquet:"name=delegationLegacyWaiting, type=BYTE_ARRAY, convertedtype=UTF8"`
+	DelegationLegacyWaitingNum float64 `parquet:"name=delegationLegacyWaitingNum, type=DOUBLE"`
+	DelegationLegacyActive     string  `parquet:"name=delegationLegacyActive, type=BYTE_ARRAY, convertedtype=UTF8"`
+	DelegationLegacyActiveNum  float64 `parquet:"name=delegationLegacyActiveNum, type=DOUBLE"`
+	ValidatorsActive           string  `parquet:"name=validatorsActive, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ValidatorsActiveNum        float64 `parquet:"name=validatorsActiveNum, type=DOUBLE"`
+	ValidatorTopUp             string  `parquet:"name=validatorsTopUp, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ValidatorTopUpNum          float64 `parquet:"name=validatorsTopUpNum, type=DOUBLE"`
+	Delegation                 string  `parquet:"name=delegation, type=BYTE_ARRAY, convertedtype=UTF8"`
+	DelegationNum              float64 `parquet:"name=delegationNum, type=DOUBLE"`
+	TotalStake                 string  `parquet:"name=totalStake, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TotalStakeNum              float64 `parquet:"name=totalStakeNum, type=DOUBLE"`
+	LKMEXStake                 string  `parquet:"name=lkMexStake, type=BYTE_ARRAY, convertedtype=UTF8"`
+	LKMEXStakeNum              float64 `parquet:"name=lkMexStakeNum, type=DOUBLE"`
+	Energy                     string  `parquet:"name=energy, type=BYTE_ARRAY, convertedtype=UTF8"`
+	EnergyNum                  float64 `parquet:"name=energyNum, type=DOUBLE"`
+	Label                      string  `parquet:"name=label, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Tags                       string  `parquet:"name=tags, type=BYTE_ARRAY, convertedtype=UTF8"`
+
+	RankTotalBalanceWithStake       int64   `parquet:"name=rankTotalBalanceWithStake, type=INT64"`
+	PercentileTotalBalanceWithStake float64 `parquet:"name=percentileTotalBalanceWithStake, type=DOUBLE"`
+	RankStake                       int64   `parquet:"name=rankStake, type=INT64"`
+	PercentileStake                 float64 `parquet:"name=percentileStake, type=DOUBLE"`
+	RankEnergy                      int64   `parquet:"name=rankEnergy, type=INT64"`
+	PercentileEnergy                float64 `parquet:"name=percentileEnergy, type=DOUBLE"`
+}
+
+type parquetEncoder struct {
+	writer *writer.ParquetWriter
+}
+
+func newParquetEncoder(w io.Writer, compression string) (*parquetEncoder, error) {
+	parquetWriter, err := writer.NewParquetWriterFromWriter(w, new(parquetAccount), parquetNumWorkers)
+	if err != nil {
+		return nil, err
+	}
+
+	switch compression {
+	case compressionGzip:
+		parquetWriter.CompressionType = parquet.CompressionCodec_GZIP
+	case compressionZstd:
+		parquetWriter.CompressionType = parquet.CompressionCodec_ZSTD
+	default:
+		parquetWriter.CompressionType = parquet.CompressionCodec_UNCOMPRESSED
+	}
+
+	return &parquetEncoder{
+		writer: parquetWriter,
+	}, nil
+}
+
+func (pe *parquetEncoder) encode(account *data.AccountInfoWithStakeValues) error {
+	return pe.writer.Write(parquetAccount{
+		Address:                    account.Address,
+		ShardID:                    int64(account.ShardID),
+		Balance:                    account.Balance,
+		BalanceNum:                 account.BalanceNum,
+		TotalBalanceWithStake:      account.TotalBalanceWithStake,
+		TotalBalanceWithStakeNum:   account.TotalBalanceWithStakeNum,
+		DelegationLegacyWaiting:    account.DelegationLegacyWaiting,
+		DelegationLegacyWaitingNum: account.DelegationLegacyWaitingNum,
+		DelegationLegacyActive:     account.DelegationLegacyActive,
+		DelegationLegacyActiveNum:  account.DelegationLegacyActiveNum,
+		ValidatorsActive:           account.ValidatorsActive,
+		ValidatorsActiveNum:        account.ValidatorsActiveNum,
+		ValidatorTopUp:             account.ValidatorTopUp,
+		ValidatorTopUpNum:          account.ValidatorTopUpNum,
+		Delegation:                 account.Delegation,
+		DelegationNum:              account.DelegationNum,
+		TotalStake:                 account.TotalStake,
+		TotalStakeNum:              account.TotalStakeNum,
+		LKMEXStake:                 account.LKMEXStake,
+		LKMEXStakeNum:              account.LKMEXStakeNum,
+		Energy:                     account.Energy,
+		EnergyNum:                  account.EnergyNum,
+		Label:                      account.Label,
+		Tags:                       strings.Join(account.Tags, csvArraySeparator),
+
+		RankTotalBalanceWithStake:       int64(account.RankTotalBalanceWithStake),
+		PercentileTotalBalanceWithStake: account.PercentileTotalBalanceWithStake,
+		RankStake:                       int64(account.RankStake),
+		PercentileStake:                 account.PercentileStake,
+		RankEnergy:                      int64(account.RankEnergy),
+		PercentileEnergy:                account.PercentileEnergy,
+	})
+}
+
+func (pe *parquetEncoder) close() error {
+	return pe.writer.WriteStop()
+}
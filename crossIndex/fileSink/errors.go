@@ -0,0 +1,15 @@
+package fileSink
+
+import "errors"
+
+// ErrEmptyDirectory signals that an empty output directory has been provided
+var ErrEmptyDirectory = errors.New("empty output directory")
+
+// ErrInvalidFormat signals that an unsupported file format has been provided
+var ErrInvalidFormat = errors.New("invalid file format")
+
+// ErrInvalidCompression signals that an unsupported compression has been provided
+var ErrInvalidCompression = errors.New("invalid compression")
+
+// ErrSnapshotNotPrepared signals that the accounts are written before preparing the snapshot
+var ErrSnapshotNotPrepared = errors.New("snapshot not prepared")
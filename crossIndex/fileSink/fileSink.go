@@ -0,0 +1,379 @@
+package fileSink
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+const (
+	formatNDJSON  = "ndjson"
+	formatCSV     = "csv"
+	formatParquet = "parquet"
+
+	compressionNone = "none"
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+
+	manifestFileFormat = "%s.%s.manifest.json"
+	spoolFileSuffix    = ".spool"
+	maxSpoolLineSize   = 16 * 1024 * 1024
+)
+
+var log = logger.GetOrCreate("crossIndex/fileSink")
+
+var compressionExtensions = map[string]string{
+	compressionNone: "",
+	compressionGzip: ".gz",
+	compressionZstd: ".zst",
+}
+
+type manifest struct {
+	Index       string                     `json:"index"`
+	Epoch       uint32                     `json:"epoch"`
+	Format      string                     `json:"format"`
+	Compression string                     `json:"compression"`
+	File        string                     `json:"file"`
+	RowCount    uint64                     `json:"rowCount"`
+	SHA256      string                     `json:"sha256"`
+	CreatedAt   time.Time                  `json:"createdAt"`
+	Values      map[string]json.RawMessage `json:"values,omitempty"`
+}
+
+type fileSink struct {
+	directory   string
+	format      string
+	compression string
+	columns     []string
+
+	currentManifest *manifest
+	spool           *os.File
+	spoolWriter     *bufio.Writer
+	spoolEncoder    *json.Encoder
+	ranks           map[string]*data.AccountRanks
+
+	file           *os.File
+	checksum       hash.Hash
+	compressor     io.WriteCloser
+	bufferedWriter *bufio.Writer
+	encoder        accountsEncoder
+}
+
+// NewFileSink will create a new instance of fileSink
+func NewFileSink(cfg config.FileSinkConfig) (*fileSink, error) {
+	if cfg.Directory == "" {
+		return nil, ErrEmptyDirectory
+	}
+
+	switch cfg.Format {
+	case formatNDJSON, formatCSV, formatParquet:
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFormat, cfg.Format)
+	}
+
+	compression := cfg.Compression
+	if compression == "" {
+		compression = compressionNone
+	}
+	_, ok := compressionExtensions[compression]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCompression, cfg.Compression)
+	}
+
+	return &fileSink{
+		directory:   cfg.Directory,
+		format:      cfg.Format,
+		compression: compression,
+		columns:     cfg.Columns,
+	}, nil
+}
+
+// PrepareSnapshot will discard the unfinished snapshot, if any, and will create the spool file of the provided snapshot.
+// The accounts are spooled until Finalize, when they are written in the snapshot file together with their ranks
+func (fs *fileSink) PrepareSnapshot(index string, epoch uint32) error {
+	if fs.spool != nil {
+		log.Warn("discarding the unfinished snapshot file", "index", fs.currentManifest.Index)
+		err := fs.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	err := os.MkdirAll(fs.directory, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	fileName := fs.computeFileName(index)
+	fs.spool, err = os.Create(filepath.Join(fs.directory, fileName+spoolFileSuffix))
+	if err != nil {
+		return err
+	}
+	fs.spoolWriter = bufio.NewWriter(fs.spool)
+	fs.spoolEncoder = json.NewEncoder(fs.spoolWriter)
+	fs.ranks = nil
+
+	fs.currentManifest = &manifest{
+		Index:       index,
+		Epoch:       epoch,
+		Format:      fs.format,
+		Compression: fs.compression,
+		File:        fileName,
+		Values:      make(map[string]json.RawMessage),
+	}
+
+	log.Info("spooling accounts snapshot file", "path", fs.spool.Name())
+
+	return nil
+}
+
+func (fs *fileSink) computeFileName(index string) string {
+	if fs.format == formatParquet {
+		return fmt.Sprintf("%s.%s", index, fs.format)
+	}
+
+	return fmt.Sprintf("%s.%s%s", index, fs.format, compressionExtensions[fs.compression])
+}
+
+func (fs *fileSink) createEncoder(fileWriter io.Writer) (accountsEncoder, error) {
+	if fs.format == formatParquet {
+		return newParquetEncoder(fileWriter, fs.compression)
+	}
+
+	var err error
+	switch fs.compression {
+	case compressionGzip:
+		fs.compressor = gzip.NewWriter(fileWriter)
+	case compressionZstd:
+		fs.compressor, err = zstd.NewWriter(fileWriter)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		fs.compressor = nopWriteCloser{Writer: fileWriter}
+	}
+	fs.bufferedWriter = bufio.NewWriter(fs.compressor)
+
+	if fs.format == formatCSV {
+		return newCSVEncoder(fs.bufferedWriter, fs.columns)
+	}
+
+	return newNDJSONEncoder(fs.bufferedWriter), nil
+}
+
+// WriteBatch will append the provided accounts to the spool file, sorted by address
+func (fs *fileSink) WriteBatch(accounts map[string]*data.AccountInfoWithStakeValues) error {
+	if fs.spool == nil {
+		return ErrSnapshotNotPrepared
+	}
+
+	addresses := make([]string, 0, len(accounts))
+	for address := range accounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	for _, address := range addresses {
+		account := *accounts[address]
+		account.Address = address
+
+		err := fs.spoolEncoder.Encode(&account)
+		if err != nil {
+			return err
+		}
+
+		fs.currentManifest.RowCount++
+	}
+
+	return nil
+}
+
+// WriteRanks will keep the provided ranks, which are set on the spooled accounts when the snapshot file is written
+func (fs *fileSink) WriteRanks(ranks map[string]*data.AccountRanks) error {
+	if fs.spool == nil {
+		return ErrSnapshotNotPrepared
+	}
+
+	fs.ranks = ranks
+
+	return nil
+}
+
+// WriteMetadata will add the provided document in the values of the snapshot manifest
+func (fs *fileSink) WriteMetadata(documentID string, document []byte) error {
+	if fs.spool == nil {
+		return ErrSnapshotNotPrepared
+	}
+
+	documentCopy := make([]byte, len(document))
+	copy(documentCopy, document)
+	fs.currentManifest.Values[documentID] = documentCopy
+
+	return nil
+}
+
+// Finalize will write the spooled accounts, with their ranks, in the snapshot file and will write its manifest
+func (fs *fileSink) Finalize() error {
+	if fs.spool == nil {
+		return ErrSnapshotNotPrepared
+	}
+
+	err := fs.writeSnapshotFile()
+	if err != nil {
+		return err
+	}
+
+	err = fs.removeSpool()
+	if err != nil {
+		return err
+	}
+
+	fs.currentManifest.SHA256 = hex.EncodeToString(fs.checksum.Sum(nil))
+	fs.currentManifest.CreatedAt = time.Now().UTC()
+	manifestBytes, err := json.MarshalIndent(fs.currentManifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	manifestPath := filepath.Join(fs.directory, fmt.Sprintf(manifestFileFormat, fs.currentManifest.Index, fs.format))
+	err = os.WriteFile(manifestPath, manifestBytes, 0644)
+	if err != nil {
+		return err
+	}
+
+	log.Info("wrote accounts snapshot file",
+		"path", fs.file.Name(),
+		"rows", fs.currentManifest.RowCount,
+		"sha256", fs.currentManifest.SHA256,
+	)
+
+	return nil
+}
+
+func (fs *fileSink) writeSnapshotFile() error {
+	err := fs.spoolWriter.Flush()
+	if err != nil {
+		return err
+	}
+	_, err = fs.spool.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	fs.file, err = os.Create(filepath.Join(fs.directory, fs.currentManifest.File))
+	if err != nil {
+		return err
+	}
+
+	fs.checksum = sha256.New()
+	fs.encoder, err = fs.createEncoder(io.MultiWriter(fs.file, fs.checksum))
+	if err != nil {
+		_ = fs.file.Close()
+		return err
+	}
+
+	err = fs.encodeSpooledAccounts()
+	if err != nil {
+		_ = fs.file.Close()
+		return err
+	}
+
+	return fs.closeFile()
+}
+
+func (fs *fileSink) encodeSpooledAccounts() error {
+	scanner := bufio.NewScanner(fs.spool)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxSpoolLineSize)
+	for scanner.Scan() {
+		account := &data.AccountInfoWithStakeValues{}
+		err := json.Unmarshal(scanner.Bytes(), account)
+		if err != nil {
+			return err
+		}
+
+		accountRanks, ok := fs.ranks[account.Address]
+		if ok {
+			account.AccountRanks = *accountRanks
+		}
+
+		err = fs.encoder.encode(account)
+		if err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func (fs *fileSink) removeSpool() error {
+	spool := fs.spool
+	fs.spool = nil
+	fs.ranks = nil
+
+	err := spool.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(spool.Name())
+}
+
+func (fs *fileSink) closeFile() error {
+	err := fs.encoder.close()
+	if err != nil {
+		return err
+	}
+
+	if fs.bufferedWriter != nil {
+		err = fs.bufferedWriter.Flush()
+		if err != nil {
+			return err
+		}
+	}
+
+	if fs.compressor != nil {
+		err = fs.compressor.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return fs.file.Close()
+}
+
+// Close will discard the unfinished snapshot, if any
+func (fs *fileSink) Close() error {
+	if fs.spool == nil {
+		return nil
+	}
+
+	return fs.removeSpool()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (fs *fileSink) IsInterfaceNil() bool {
+	return fs == nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+// Close does nothing
+func (nwc nopWriteCloser) Close() error {
+	return nil
+}
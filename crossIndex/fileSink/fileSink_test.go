@@ -0,0 +1,236 @@
+package fileSink
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIndex = "accounts-000001_5"
+	testEpoch = 5
+)
+
+func createAccounts(addresses ...string) map[string]*data.AccountInfoWithStakeValues {
+	accounts := make(map[string]*data.AccountInfoWithStakeValues)
+	for idx, address := range addresses {
+		account := &data.AccountInfoWithStakeValues{}
+		account.Balance = fmt.Sprintf("%d", idx+1)
+		account.Delegation = fmt.Sprintf("%d", (idx+1)*10)
+		account.Tags = []string{"tag1", "tag2"}
+
+		accounts[address] = account
+	}
+
+	return accounts
+}
+
+func writeSnapshot(t *testing.T, cfg config.FileSinkConfig) *manifest {
+	sink, err := NewFileSink(cfg)
+	require.Nil(t, err)
+
+	require.Nil(t, sink.PrepareSnapshot(testIndex, testEpoch))
+	require.Nil(t, sink.WriteBatch(createAccounts("erd1c", "erd1a")))
+	require.Nil(t, sink.WriteBatch(createAccounts("erd1b")))
+	require.Nil(t, sink.WriteMetadata("energy-snapshot-5", []byte(`{"key":"blockHash","value":"abcd"}`)))
+	require.Nil(t, sink.Finalize())
+
+	manifestBytes, err := os.ReadFile(filepath.Join(cfg.Directory, fmt.Sprintf(manifestFileFormat, testIndex, cfg.Format)))
+	require.Nil(t, err)
+
+	m := &manifest{}
+	require.Nil(t, json.Unmarshal(manifestBytes, m))
+	require.Equal(t, testIndex, m.Index)
+	require.Equal(t, uint32(testEpoch), m.Epoch)
+	require.Equal(t, uint64(3), m.RowCount)
+	require.JSONEq(t, `{"key":"blockHash","value":"abcd"}`, string(m.Values["energy-snapshot-5"]))
+
+	fileBytes, err := os.ReadFile(filepath.Join(cfg.Directory, m.File))
+	require.Nil(t, err)
+	checksum := sha256.Sum256(fileBytes)
+	require.Equal(t, hex.EncodeToString(checksum[:]), m.SHA256)
+
+	return m
+}
+
+func TestNewFileSink(t *testing.T) {
+	t.Parallel()
+
+	sink, err := NewFileSink(config.FileSinkConfig{Format: formatCSV})
+	require.Nil(t, sink)
+	require.Equal(t, ErrEmptyDirectory, err)
+
+	sink, err = NewFileSink(config.FileSinkConfig{Format: "xml", Directory: t.TempDir()})
+	require.Nil(t, sink)
+	require.True(t, errors.Is(err, ErrInvalidFormat))
+
+	sink, err = NewFileSink(config.FileSinkConfig{Format: formatCSV, Compression: "rar", Directory: t.TempDir()})
+	require.Nil(t, sink)
+	require.True(t, errors.Is(err, ErrInvalidCompression))
+
+	sink, err = NewFileSink(config.FileSinkConfig{Format: formatCSV, Directory: t.TempDir()})
+	require.Nil(t, err)
+	require.False(t, sink.IsInterfaceNil())
+	require.Equal(t, ErrSnapshotNotPrepared, sink.WriteBatch(createAccounts("erd1a")))
+}
+
+func TestFileSink_NDJSONGzip(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.FileSinkConfig{Format: formatNDJSON, Compression: compressionGzip, Directory: t.TempDir()}
+	m := writeSnapshot(t, cfg)
+	require.Equal(t, testIndex+".ndjson.gz", m.File)
+
+	file, err := os.Open(filepath.Join(cfg.Directory, m.File))
+	require.Nil(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+	gzipReader, err := gzip.NewReader(file)
+	require.Nil(t, err)
+
+	addresses := make([]string, 0)
+	scanner := bufio.NewScanner(gzipReader)
+	for scanner.Scan() {
+		account := &data.AccountInfoWithStakeValues{}
+		require.Nil(t, json.Unmarshal(scanner.Bytes(), account))
+		addresses = append(addresses, account.Address)
+	}
+	require.Equal(t, []string{"erd1a", "erd1c", "erd1b"}, addresses)
+}
+
+func TestFileSink_CSVZstd(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.FileSinkConfig{
+		Format:      formatCSV,
+		Compression: compressionZstd,
+		Directory:   t.TempDir(),
+		Columns:     []string{"address", "balance", "delegation", "tags"},
+	}
+	m := writeSnapshot(t, cfg)
+	require.Equal(t, testIndex+".csv.zst", m.File)
+
+	fileBytes, err := os.ReadFile(filepath.Join(cfg.Directory, m.File))
+	require.Nil(t, err)
+	zstdReader, err := zstd.NewReader(bytes.NewReader(fileBytes))
+	require.Nil(t, err)
+	defer zstdReader.Close()
+
+	decompressed, err := io.ReadAll(zstdReader)
+	require.Nil(t, err)
+	records, err := csv.NewReader(bytes.NewReader(decompressed)).ReadAll()
+	require.Nil(t, err)
+	require.Equal(t, [][]string{
+		{"address", "balance", "delegation", "tags"},
+		{"erd1a", "2", "20", "tag1|tag2"},
+		{"erd1c", "1", "10", "tag1|tag2"},
+		{"erd1b", "1", "10", "tag1|tag2"},
+	}, records)
+}
+
+func TestFileSink_Parquet(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.FileSinkConfig{Format: formatParquet, Compression: compressionZstd, Directory: t.TempDir()}
+	m := writeSnapshot(t, cfg)
+	require.Equal(t, testIndex+".parquet", m.File)
+
+	fileBytes, err := os.ReadFile(filepath.Join(cfg.Directory, m.File))
+	require.Nil(t, err)
+	require.True(t, bytes.HasPrefix(fileBytes, []byte("PAR1")))
+	require.True(t, bytes.HasSuffix(fileBytes, []byte("PAR1")))
+}
+
+func TestFileSink_SnapshotCarriesTheRanks(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.FileSinkConfig{Format: formatNDJSON, Directory: t.TempDir()}
+	sink, err := NewFileSink(cfg)
+	require.Nil(t, err)
+
+	require.Nil(t, sink.PrepareSnapshot(testIndex, testEpoch))
+	require.Nil(t, sink.WriteBatch(createAccounts("erd1a")))
+	require.Nil(t, sink.WriteRanks(map[string]*data.AccountRanks{
+		"erd1a": {RankTotalBalanceWithStake: 1, PercentileTotalBalanceWithStake: 100, RankStake: 2, PercentileStake: 50},
+	}))
+	require.Nil(t, sink.Finalize())
+
+	fileBytes, err := os.ReadFile(filepath.Join(cfg.Directory, testIndex+".ndjson"))
+	require.Nil(t, err)
+
+	account := &data.AccountInfoWithStakeValues{}
+	require.Nil(t, json.Unmarshal(bytes.TrimSpace(fileBytes), account))
+	require.Equal(t, "erd1a", account.Address)
+	require.Equal(t, data.AccountRanks{RankTotalBalanceWithStake: 1, PercentileTotalBalanceWithStake: 100, RankStake: 2, PercentileStake: 50}, account.AccountRanks)
+
+	_, err = os.Stat(filepath.Join(cfg.Directory, testIndex+".ndjson"+spoolFileSuffix))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestFileSink_WriteRanksWithoutSnapshot(t *testing.T) {
+	t.Parallel()
+
+	sink, err := NewFileSink(config.FileSinkConfig{Format: formatNDJSON, Directory: t.TempDir()})
+	require.Nil(t, err)
+
+	err = sink.WriteRanks(map[string]*data.AccountRanks{})
+	require.True(t, errors.Is(err, ErrSnapshotNotPrepared))
+}
+
+func TestFileSink_PrepareSnapshotDiscardsTheUnfinishedSnapshot(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.FileSinkConfig{Format: formatNDJSON, Directory: t.TempDir()}
+	sink, err := NewFileSink(cfg)
+	require.Nil(t, err)
+
+	require.Nil(t, sink.PrepareSnapshot("accounts-000001_4", testEpoch-1))
+	require.Nil(t, sink.WriteBatch(createAccounts("erd1a")))
+
+	require.Nil(t, sink.PrepareSnapshot(testIndex, testEpoch))
+	require.Nil(t, sink.WriteBatch(createAccounts("erd1b")))
+	require.Nil(t, sink.Finalize())
+
+	entries, err := os.ReadDir(cfg.Directory)
+	require.Nil(t, err)
+	fileNames := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		fileNames = append(fileNames, entry.Name())
+	}
+	require.Equal(t, []string{testIndex + ".ndjson", testIndex + ".ndjson.manifest.json"}, fileNames)
+}
+
+func TestFileSink_CloseUnfinishedSnapshot(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.FileSinkConfig{Format: formatNDJSON, Directory: t.TempDir()}
+	sink, err := NewFileSink(cfg)
+	require.Nil(t, err)
+	require.Nil(t, sink.Close())
+
+	require.Nil(t, sink.PrepareSnapshot(testIndex, testEpoch))
+	require.Nil(t, sink.WriteBatch(createAccounts("erd1a")))
+	require.Nil(t, sink.Close())
+	require.Nil(t, sink.Close())
+
+	entries, err := os.ReadDir(cfg.Directory)
+	require.Nil(t, err)
+	require.Empty(t, entries)
+	require.True(t, errors.Is(sink.Finalize(), ErrSnapshotNotPrepared))
+}
@@ -11,9 +11,19 @@ type ElasticClientHandler interface {
 	PutPolicy(policyName string, policy *bytes.Buffer) error
 	PutMapping(targetIndex string, body *bytes.Buffer) error
 	CreateIndexWithMapping(index string, mapping *bytes.Buffer) error
+	PutIndexTemplate(name string, template *bytes.Buffer) error
+	PutComponentTemplate(name string, template *bytes.Buffer) error
+	GetIndexTemplate(name string) ([]byte, error)
+	GetComponentTemplate(name string) ([]byte, error)
+	GetMapping(index string) (map[string][]byte, error)
+	GetIndices(index string) ([]*data.IndexInfo, error)
+	DeleteIndices(indices []string) error
+	RefreshIndex(index string) error
+	CountDocuments(index string) (uint64, error)
 	CheckIfIndexExists(index string) (bool, error)
 	DoRequest(index, documentID string, buff *bytes.Buffer) error
 	DoBulkRequest(buff *bytes.Buffer, index string) error
+	GetBulkStats() *data.BulkStats
 	DoMultiGet(ids []string, index string) ([]byte, error)
 	DoScrollRequestAllDocuments(index string, body []byte, handlerFunc func(responseBytes []byte) error) error
 	IsInterfaceNil() bool
@@ -23,6 +33,33 @@ type ElasticClientHandler interface {
 type AccountsIndexerHandler interface {
 	GetAccounts(addresses []string, index string) (map[string]*data.AccountInfoWithStakeValues, error)
 	IndexAccounts(accounts map[string]*data.AccountInfoWithStakeValues, index string) error
+	IndexAccountsRanks(ranks map[string]*data.AccountRanks, index string) error
+}
+
+// Destination defines what a destination of the accounts snapshots should be able to do. A batch passed to WriteBatch
+// can be written after the call returns and is shared by all the destinations, so the caller must not reuse or mutate
+// the map or its accounts afterwards, while the destinations must treat them as read only
+type Destination interface {
+	PrepareSnapshot(index string, epoch uint32) error
+	WriteBatch(accounts map[string]*data.AccountInfoWithStakeValues) error
+	WriteRanks(ranks map[string]*data.AccountRanks) error
+	WriteMetadata(documentID string, document []byte) error
+	Finalize() error
+	Close() error
+	IsInterfaceNil() bool
+}
+
+// MetricsHandler defines what the collector of the reindexing metrics should be able to do
+type MetricsHandler interface {
+	AddScrollPage()
+	AddDestinationBatch(destination string, err error)
+	IsInterfaceNil() bool
+}
+
+// AccountsFilterHandler defines what an accounts' filter should be able to do
+type AccountsFilterHandler interface {
+	Filter(accounts map[string]*data.AccountInfoWithStakeValues) (map[string]*data.AccountInfoWithStakeValues, map[string]*data.AccountInfoWithStakeValues)
+	IsInterfaceNil() bool
 }
 
 // AccountsProcessorHandler defines what an accounts' processor should be able to do
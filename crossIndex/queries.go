@@ -18,10 +18,23 @@ func EncodeQuery(query object) (bytes.Buffer, error) {
 }
 
 func GetAll() *bytes.Buffer {
+	return GetAllSlice(0, 1)
+}
+
+// GetAllSlice returns a query that matches all the documents of a slice, when read with a sliced scroll. The documents
+// are sorted by their index order, so every slice returns the same pages as long as the index is not changed
+func GetAllSlice(sliceID int, numSlices int) *bytes.Buffer {
 	obj := object{
 		"query": object{
 			"match_all": object{},
 		},
+		"sort": []interface{}{"_doc"},
+	}
+	if numSlices > 1 {
+		obj["slice"] = object{
+			"id":  sliceID,
+			"max": numSlices,
+		}
 	}
 
 	encoded, _ := EncodeQuery(obj)
@@ -0,0 +1,55 @@
+package reindexer
+
+import (
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/core"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+type indexedAccount struct {
+	address                  string
+	totalBalanceWithStakeNum float64
+	totalStakeNum            float64
+	energyNum                float64
+}
+
+func newIndexedAccount(address string, account *data.AccountInfoWithStakeValues) *indexedAccount {
+	return &indexedAccount{
+		address:                  address,
+		totalBalanceWithStakeNum: account.TotalBalanceWithStakeNum,
+		totalStakeNum:            account.TotalStakeNum,
+		energyNum:                account.EnergyNum,
+	}
+}
+
+func computeAccountsRanks(accounts []*indexedAccount) map[string]*data.AccountRanks {
+	totalBalances := make([]float64, len(accounts))
+	totalStakes := make([]float64, len(accounts))
+	energies := make([]float64, len(accounts))
+	for idx, account := range accounts {
+		totalBalances[idx] = account.totalBalanceWithStakeNum
+		totalStakes[idx] = account.totalStakeNum
+		energies[idx] = account.energyNum
+	}
+
+	rankBalances, percentileBalances := core.ComputeRanks(totalBalances)
+	rankStakes, percentileStakes := core.ComputeRanks(totalStakes)
+	rankEnergies, percentileEnergies := core.ComputeRanks(energies)
+
+	ranks := make(map[string]*data.AccountRanks, len(accounts))
+	for idx, account := range accounts {
+		if rankBalances[idx] == 0 && rankStakes[idx] == 0 && rankEnergies[idx] == 0 {
+			continue
+		}
+
+		ranks[account.address] = &data.AccountRanks{
+			RankTotalBalanceWithStake:       rankBalances[idx],
+			PercentileTotalBalanceWithStake: percentileBalances[idx],
+			RankStake:                       rankStakes[idx],
+			PercentileStake:                 percentileStakes[idx],
+			RankEnergy:                      rankEnergies[idx],
+			PercentileEnergy:                percentileEnergies[idx],
+		}
+	}
+
+	return ranks
+}
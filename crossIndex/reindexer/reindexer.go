@@ -1,174 +1,337 @@
 package reindexer
 
 import (
-	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/core"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
-	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/accountsIndexer"
 )
 
+type valuesDocument struct {
+	id   string
+	body []byte
+}
+
 type reindexer struct {
-	sourceIndexer       crossIndex.ElasticClientHandler
-	destinationClients  []crossIndex.ElasticClientHandler
-	count               int
-	pathToIndicesConfig string
+	sourceIndexer                crossIndex.ElasticClientHandler
+	destinations                 []crossIndex.Destination
+	filteredAccountsDestinations []crossIndex.Destination
+	accountsFilter               crossIndex.AccountsFilterHandler
+	count                        int
+	pathToAddressLabels          string
+	numSourceSlices              int
+	indexedAccounts              []*indexedAccount
+	numFilteredAccounts          uint64
+	numLabelledAccounts          uint64
+	labelledAccountsPerTag       map[string]uint64
+	numSourceDocuments           uint64
+	verificationSampleSize       int
+	sampler                      *accountsSampler
+	stakeStats                   *data.StakeStats
+	stakeTotals                  *stakeTotals
+	metrics                      crossIndex.MetricsHandler
+}
+
+// ArgsReindexer holds the arguments needed to create a new reindexer
+type ArgsReindexer struct {
+	SourceIndexer                crossIndex.ElasticClientHandler
+	Destinations                 []crossIndex.Destination
+	FilteredAccountsDestinations []crossIndex.Destination
+	AccountsFilter               crossIndex.AccountsFilterHandler
+	PathToAddressLabels          string
+	NumSourceSlices              int
+	// VerificationSampleSize is the number of written accounts kept for the post-run verification
+	VerificationSampleSize int
+	Metrics                crossIndex.MetricsHandler
 }
 
 var log = logger.GetOrCreate("reindexer")
 
 // New returns a new instance of reindexer
-func New(
-	sourceIndexer crossIndex.ElasticClientHandler,
-	destinationIndexer []crossIndex.ElasticClientHandler,
-	pathToIndicesConfig string) (*reindexer, error,
-) {
-	if check.IfNil(sourceIndexer) {
+func New(args ArgsReindexer) (*reindexer, error) {
+	if check.IfNil(args.SourceIndexer) {
 		return nil, fmt.Errorf("%w for sourceIndexer", crossIndex.ErrNilElasticClient)
 	}
-	if pathToIndicesConfig == "" {
-		return nil, errors.New("empty path to the indices config folder")
+	if check.IfNil(args.AccountsFilter) {
+		return nil, crossIndex.ErrNilAccountsFilter
+	}
+	if check.IfNil(args.Metrics) {
+		return nil, crossIndex.ErrNilMetricsHandler
+	}
+	if len(args.Destinations) == 0 {
+		return nil, crossIndex.ErrEmptyDestinations
 	}
-	for idx, dstClient := range destinationIndexer {
-		if check.IfNil(dstClient) {
-			return nil, fmt.Errorf("%w for destinationIndexer, index %d", crossIndex.ErrNilElasticClient, idx)
+	for idx, destination := range args.Destinations {
+		if check.IfNil(destination) {
+			return nil, fmt.Errorf("%w, index %d", crossIndex.ErrNilDestination, idx)
+		}
+	}
+	for idx, destination := range args.FilteredAccountsDestinations {
+		if check.IfNil(destination) {
+			return nil, fmt.Errorf("%w for filtered accounts, index %d", crossIndex.ErrNilDestination, idx)
 		}
 	}
 
+	numSourceSlices := args.NumSourceSlices
+	if numSourceSlices < 1 {
+		numSourceSlices = 1
+	}
+
 	return &reindexer{
-		sourceIndexer:       sourceIndexer,
-		destinationClients:  destinationIndexer,
-		pathToIndicesConfig: pathToIndicesConfig,
+		sourceIndexer:                args.SourceIndexer,
+		destinations:                 args.Destinations,
+		filteredAccountsDestinations: args.FilteredAccountsDestinations,
+		accountsFilter:               args.AccountsFilter,
+		pathToAddressLabels:          args.PathToAddressLabels,
+		numSourceSlices:              numSourceSlices,
+		verificationSampleSize:       args.VerificationSampleSize,
+		sampler:                      newTimeSeededAccountsSampler(0),
+		metrics:                      args.Metrics,
 	}, nil
 }
 
-// ReindexAccounts will reindex all accounts from source indexer to destination indexer
+// ReindexAccounts will reindex all accounts from source indexer to all the destinations
 func (r *reindexer) ReindexAccounts(sourceIndex string, destinationIndex string, restAccounts *data.AccountsData) error {
-	log.Info("Create a new index with mapping")
-
-	template, policy, err := readTemplateAndPolicyForAccountsIndex(r.pathToIndicesConfig)
+	addressLabels, err := core.LoadAddressLabels(r.pathToAddressLabels)
 	if err != nil {
-		return err
+		return fmt.Errorf("%w: %s", crossIndex.ErrInvalidAddressLabels, err.Error())
 	}
+	log.Info("loaded address labels", "num", len(addressLabels))
 
-	templateBytes := template.Bytes()
-	policyBytes := policy.Bytes()
-
-	for _, dstClient := range r.destinationClients {
-		err = dstClient.CreateIndexWithMapping(destinationIndex, bytes.NewBuffer(templateBytes))
-		if err != nil {
-			return err
-		}
+	log.Info("Prepare the destinations for a new snapshot")
 
-		err = dstClient.PutPolicy(crossIndex.AccountsPolicyName, bytes.NewBuffer(policyBytes))
+	allDestinations := append(append([]crossIndex.Destination{}, r.destinations...), r.filteredAccountsDestinations...)
+	for _, destination := range allDestinations {
+		err = destination.PrepareSnapshot(destinationIndex, restAccounts.Epoch)
 		if err != nil {
 			return err
 		}
 	}
 
-	saverFunc := func(responseBytes []byte) error {
-		r.count++
-		log.Info("indexing accounts", "bulk", r.count)
-
-		esAccounts, errG := getAllAccounts(responseBytes)
+	r.indexedAccounts = make([]*indexedAccount, 0)
+	r.numFilteredAccounts = 0
+	r.numLabelledAccounts = 0
+	r.labelledAccountsPerTag = make(map[string]uint64)
+	r.numSourceDocuments = 0
+	r.stakeStats = nil
+	r.stakeTotals = newStakeTotals()
+	r.sampler = newTimeSeededAccountsSampler(r.verificationSampleSize)
+	preparePage := func(responseBytes []byte) (*accountsPage, error) {
+		esAccounts, numHits, errG := getAllAccounts(responseBytes)
 		if errG != nil {
-			return errG
+			return nil, errG
 		}
 
-		mergedAccounts := core.MergeElasticAndRestAccounts(esAccounts, restAccounts.AccountsWithStake)
+		mergedAccounts := core.MergeElasticAndRestAccounts(esAccounts, restAccounts.AccountsWithStake, addressLabels)
+		keptAccounts, filteredAccounts := r.accountsFilter.Filter(mergedAccounts)
 
-		return r.indexAllAccounts(mergedAccounts, destinationIndex)
+		return &accountsPage{
+			numSourceDocuments: numHits,
+			keptAccounts:       keptAccounts,
+			filteredAccounts:   filteredAccounts,
+		}, nil
 	}
 
-	err = r.sourceIndexer.DoScrollRequestAllDocuments(sourceIndex, crossIndex.GetAll().Bytes(), saverFunc)
+	err = r.readSourceSlices(sourceIndex, preparePage, r.writePage)
 	if err != nil {
 		return err
 	}
 
-	err = r.checkAndCreateValuesIndex()
+	err = r.writeAccountsRanks()
 	if err != nil {
 		return err
 	}
 
-	return r.indexExtraInformation(restAccounts)
-}
+	err = r.writeExtraInformation(restAccounts)
+	if err != nil {
+		return err
+	}
 
-func (r *reindexer) indexAllAccounts(mapAllAccounts map[string]*data.AccountInfoWithStakeValues, destinationIndex string) error {
-	for _, dstClient := range r.destinationClients {
-		acIndexer, err := accountsIndexer.NewAccountsIndexer(dstClient)
+	for _, destination := range allDestinations {
+		err = destination.Finalize()
 		if err != nil {
 			return err
 		}
+	}
+
+	log.Info("filtered accounts", "num", r.numFilteredAccounts)
+	r.logLabelledAccounts()
+
+	return nil
+}
 
-		err = acIndexer.IndexAccounts(mapAllAccounts, destinationIndex)
+func (r *reindexer) writePage(page *accountsPage) error {
+	r.count++
+	log.Info("indexing accounts", "bulk", r.count)
+	r.metrics.AddScrollPage()
+
+	for address, account := range page.keptAccounts {
+		r.indexedAccounts = append(r.indexedAccounts, newIndexedAccount(address, account))
+		r.stakeTotals.add(account)
+		r.countLabelledAccount(account)
+
+		err := r.sampler.add(address, account)
 		if err != nil {
 			return err
 		}
 	}
-	return nil
-}
 
-func (r *reindexer) indexExtraInformation(accountsData *data.AccountsData) error {
-	for _, dstClient := range r.destinationClients {
-		err := indexEnergyBlockInfo(accountsData.EnergyBlockInfo, accountsData.Epoch, dstClient)
+	r.numSourceDocuments += uint64(page.numSourceDocuments)
+	r.numFilteredAccounts += uint64(len(page.filteredAccounts))
+	if len(page.filteredAccounts) > 0 {
+		err := writeBatch(r.filteredAccountsDestinations, page.filteredAccounts)
 		if err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return writeBatch(r.destinations, page.keptAccounts)
 }
 
-func indexEnergyBlockInfo(energyBlockInfo *data.BlockInfo, epoch uint32, esClient crossIndex.ElasticClientHandler) error {
-	log.Info(fmt.Sprintf("Indexing extra information in `%s` index...", valuesIndex))
+func (r *reindexer) countLabelledAccount(account *data.AccountInfoWithStakeValues) {
+	if account.Label == "" && len(account.Tags) == 0 {
+		return
+	}
 
-	id := fmt.Sprintf("energy-snapshot-%d", epoch)
-	keyValueObj := &data.KeyValueObj{
-		Key:   "blockHash",
-		Value: energyBlockInfo.Hash,
+	r.numLabelledAccounts++
+	for _, tag := range account.Tags {
+		r.labelledAccountsPerTag[tag]++
+	}
+}
+
+func (r *reindexer) logLabelledAccounts() {
+	tags := make([]string, 0, len(r.labelledAccountsPerTag))
+	for tag := range r.labelledAccountsPerTag {
+		tags = append(tags, tag)
 	}
+	sort.Strings(tags)
 
-	keyValueObjBytes, err := json.Marshal(keyValueObj)
-	if err != nil {
-		return err
+	log.Info("labelled accounts", "num", r.numLabelledAccounts)
+	for _, tag := range tags {
+		log.Info("labelled accounts", "tag", tag, "num", r.labelledAccountsPerTag[tag])
+	}
+}
+
+// Close will close all the destinations, the last error being returned if any destination cannot be closed
+func (r *reindexer) Close() error {
+	var lastErr error
+	allDestinations := append(append([]crossIndex.Destination{}, r.destinations...), r.filteredAccountsDestinations...)
+	for _, destination := range allDestinations {
+		err := destination.Close()
+		if err != nil {
+			log.Warn("cannot close destination", "error", err)
+			lastErr = err
+		}
 	}
 
-	return esClient.DoRequest(valuesIndex, id, bytes.NewBuffer(keyValueObjBytes))
+	return lastErr
 }
 
-func (r *reindexer) checkAndCreateValuesIndex() error {
-	template, err := readTemplateForIndex(r.pathToIndicesConfig, valuesIndex)
-	templateBytes := template.Bytes()
+// GetReindexSummary returns the numbers of the last reindexing, a random sample of the written accounts and the stake
+// statistics of the epoch, nil if the reindexing failed before computing them
+func (r *reindexer) GetReindexSummary() *data.ReindexSummary {
+	return &data.ReindexSummary{
+		NumSourceDocuments:  r.numSourceDocuments,
+		NumIndexedAccounts:  uint64(len(r.indexedAccounts)),
+		NumFilteredAccounts: r.numFilteredAccounts,
+		SampledAccounts:     r.sampler.sample(),
+		StakeStats:          r.stakeStats,
+	}
+}
 
-	for _, dstClient := range r.destinationClients {
-		exists, errC := dstClient.CheckIfIndexExists(valuesIndex)
-		if errC != nil {
-			return errC
+func writeBatch(destinations []crossIndex.Destination, accounts map[string]*data.AccountInfoWithStakeValues) error {
+	for _, destination := range destinations {
+		err := destination.WriteBatch(accounts)
+		if err != nil {
+			return err
 		}
-		if exists {
-			continue
+	}
+
+	return nil
+}
+
+func (r *reindexer) writeAccountsRanks() error {
+	log.Info("Writing accounts ranks", "num accounts", len(r.indexedAccounts))
+
+	ranks := computeAccountsRanks(r.indexedAccounts)
+	for _, destination := range r.destinations {
+		err := destination.WriteRanks(ranks)
+		if err != nil {
+			return err
 		}
+	}
+
+	return nil
+}
 
-		err = dstClient.CreateIndexWithMapping(valuesIndex, bytes.NewBuffer(templateBytes))
+func (r *reindexer) writeExtraInformation(accountsData *data.AccountsData) error {
+	stakeStats := computeStakeStats(accountsData, r.stakeTotals, r.indexedAccounts)
+	r.stakeStats = stakeStats
+	log.Info("stake statistics",
+		"epoch", stakeStats.Epoch,
+		"num accounts", stakeStats.NumAccounts,
+		"num stakers", stakeStats.NumStakers,
+		"total stake", stakeStats.TotalStakeNum,
+		"total energy", stakeStats.TotalEnergyNum,
+		"gini", stakeStats.GiniTotalBalanceWithStake,
+	)
+
+	documents := make([]*valuesDocument, 0)
+	if accountsData.EnergyBlockInfo != nil {
+		energyDocument, err := createEnergyBlockInfoDocument(accountsData.EnergyBlockInfo, accountsData.Epoch)
 		if err != nil {
 			return err
 		}
+		documents = append(documents, energyDocument)
+	}
+
+	stakeStatsDocument, err := createStakeStatsDocument(stakeStats)
+	if err != nil {
+		return err
+	}
+	documents = append(documents, stakeStatsDocument)
+
+	log.Info("Writing extra information...")
+	for _, document := range documents {
+		for _, destination := range r.destinations {
+			err = destination.WriteMetadata(document.id, document.body)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
-func getAllAccounts(responseBytes []byte) (map[string]*data.AccountInfoWithStakeValues, error) {
+func createEnergyBlockInfoDocument(energyBlockInfo *data.BlockInfo, epoch uint32) (*valuesDocument, error) {
+	keyValueObj := &data.KeyValueObj{
+		Key:   "blockHash",
+		Value: energyBlockInfo.Hash,
+	}
+
+	keyValueObjBytes, err := json.Marshal(keyValueObj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &valuesDocument{
+		id:   fmt.Sprintf("energy-snapshot-%d", epoch),
+		body: keyValueObjBytes,
+	}, nil
+}
+
+// getAllAccounts returns the accounts of a scroll page, keyed by their addresses, and the number of hits of the page
+func getAllAccounts(responseBytes []byte) (map[string]*data.AccountInfoWithStakeValues, int, error) {
 	accountsResponse := &crossIndex.AllAccountsResponse{}
 	err := json.Unmarshal(responseBytes, &accountsResponse)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	accts := make(map[string]*data.AccountInfoWithStakeValues)
@@ -178,7 +341,7 @@ func getAllAccounts(responseBytes []byte) (map[string]*data.AccountInfoWithStake
 		accts[acct.ID] = &acc
 	}
 
-	return accts, nil
+	return accts, len(accountsResponse.Hits.Hits), nil
 }
 
 // IsInterfaceNil returns true if the value under the interface is nil
@@ -0,0 +1,162 @@
+package reindexer
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+type accountsFilterStub struct {
+	filteredAddress string
+}
+
+func (afs *accountsFilterStub) Filter(accounts map[string]*data.AccountInfoWithStakeValues) (map[string]*data.AccountInfoWithStakeValues, map[string]*data.AccountInfoWithStakeValues) {
+	kept := make(map[string]*data.AccountInfoWithStakeValues)
+	filtered := make(map[string]*data.AccountInfoWithStakeValues)
+	for address, account := range accounts {
+		if address == afs.filteredAddress {
+			filtered[address] = account
+			continue
+		}
+		kept[address] = account
+	}
+
+	return kept, filtered
+}
+
+func (afs *accountsFilterStub) IsInterfaceNil() bool {
+	return afs == nil
+}
+
+func TestReindexer_ReindexAccountsCountsTheReadDocuments(t *testing.T) {
+	t.Parallel()
+
+	// the second page holds a document of an address already read, so the run reads more documents than it merges
+	pages := []string{
+		`{"hits":{"hits":[{"_id":"erd1a","_source":{"balance":"1"}},{"_id":"erd1b","_source":{"balance":"2"}}]}}`,
+		`{"hits":{"hits":[{"_id":"erd1c","_source":{"balance":"3"}},{"_id":"erd1c","_source":{"balance":"3"}}]}}`,
+	}
+	source := &mocks.ElasticClientStub{
+		DoScrollRequestAllDocumentsCalled: func(index string, body []byte, handlerFunc func(responseBytes []byte) error) error {
+			for _, page := range pages {
+				err := handlerFunc([]byte(page))
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	r, err := New(ArgsReindexer{
+		SourceIndexer:  source,
+		Destinations:   []crossIndex.Destination{&mocks.DestinationStub{}},
+		AccountsFilter: &accountsFilterStub{filteredAddress: "erd1b"},
+		Metrics:        &mocks.MetricsHandlerStub{},
+	})
+	require.Nil(t, err)
+
+	err = r.ReindexAccounts("accounts", "accounts-000001_5", &data.AccountsData{
+		AccountsWithStake: make(map[string]*data.AccountInfoWithStakeValues),
+		Epoch:             5,
+	})
+	require.Nil(t, err)
+
+	summary := r.GetReindexSummary()
+	require.Equal(t, uint64(4), summary.NumSourceDocuments)
+	require.Equal(t, uint64(2), summary.NumIndexedAccounts)
+	require.Equal(t, uint64(1), summary.NumFilteredAccounts)
+}
+
+func TestReindexer_ReindexAccountsComputesTheStakeStatsOfTheIndexedAccounts(t *testing.T) {
+	t.Parallel()
+
+	source := &mocks.ElasticClientStub{
+		DoScrollRequestAllDocumentsCalled: func(index string, body []byte, handlerFunc func(responseBytes []byte) error) error {
+			return handlerFunc([]byte(`{"hits":{"hits":[{"_id":"erd1a","_source":{"balance":"1"}},{"_id":"erd1b","_source":{"balance":"2"}}]}}`))
+		},
+	}
+
+	r, err := New(ArgsReindexer{
+		SourceIndexer:  source,
+		Destinations:   []crossIndex.Destination{&mocks.DestinationStub{}},
+		AccountsFilter: &accountsFilterStub{filteredAddress: "erd1b"},
+		Metrics:        &mocks.MetricsHandlerStub{},
+	})
+	require.Nil(t, err)
+
+	// erd1c has stake but no document in the source index, so it is not indexed either
+	err = r.ReindexAccounts("accounts", "accounts-000001_5", &data.AccountsData{
+		AccountsWithStake: map[string]*data.AccountInfoWithStakeValues{
+			"erd1a": {StakeInfo: data.StakeInfo{Delegation: "10", TotalStake: "10"}},
+			"erd1b": {StakeInfo: data.StakeInfo{Delegation: "20", TotalStake: "20"}},
+			"erd1c": {StakeInfo: data.StakeInfo{Delegation: "40", TotalStake: "40"}},
+		},
+		Epoch: 5,
+	})
+	require.Nil(t, err)
+
+	stakeStats := r.GetReindexSummary().StakeStats
+	require.Equal(t, uint64(1), stakeStats.NumAccounts)
+	require.Equal(t, "10", stakeStats.TotalStake)
+	require.Equal(t, uint64(1), stakeStats.NumStakers)
+	require.Equal(t, "10", stakeStats.Sources[data.SourceDelegation].TotalStake)
+	require.Equal(t, uint64(1), stakeStats.Sources[data.SourceDelegation].NumStakers)
+	require.Equal(t, "70", stakeStats.FetchedSources[data.SourceDelegation].TotalStake)
+	require.Equal(t, uint64(3), stakeStats.FetchedSources[data.SourceDelegation].NumStakers)
+}
+
+func TestReindexer_ReindexAccountsInvalidAddressLabels(t *testing.T) {
+	t.Parallel()
+
+	prepared := false
+	r, err := New(ArgsReindexer{
+		SourceIndexer: &mocks.ElasticClientStub{},
+		Destinations: []crossIndex.Destination{&mocks.DestinationStub{
+			PrepareSnapshotCalled: func(index string, epoch uint32) error {
+				prepared = true
+				return nil
+			},
+		}},
+		AccountsFilter:      &accountsFilterStub{},
+		PathToAddressLabels: filepath.Join(t.TempDir(), "missing.json"),
+		Metrics:             &mocks.MetricsHandlerStub{},
+	})
+	require.Nil(t, err)
+
+	err = r.ReindexAccounts("accounts", "accounts-000001_5", &data.AccountsData{Epoch: 5})
+	require.True(t, errors.Is(err, crossIndex.ErrInvalidAddressLabels))
+	require.False(t, prepared)
+}
+
+func TestReindexer_CloseClosesAllTheDestinations(t *testing.T) {
+	t.Parallel()
+
+	numClosed := 0
+	closeErr := errors.New("close error")
+	createDestination := func(err error) crossIndex.Destination {
+		return &mocks.DestinationStub{
+			CloseCalled: func() error {
+				numClosed++
+				return err
+			},
+		}
+	}
+
+	r, err := New(ArgsReindexer{
+		SourceIndexer:                &mocks.ElasticClientStub{},
+		Destinations:                 []crossIndex.Destination{createDestination(closeErr), createDestination(nil)},
+		FilteredAccountsDestinations: []crossIndex.Destination{createDestination(nil)},
+		AccountsFilter:               &accountsFilterStub{},
+		Metrics:                      &mocks.MetricsHandlerStub{},
+	})
+	require.Nil(t, err)
+
+	require.Equal(t, closeErr, r.Close())
+	require.Equal(t, 3, numClosed)
+}
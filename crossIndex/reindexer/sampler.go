@@ -0,0 +1,77 @@
+package reindexer
+
+import (
+	"encoding/json"
+	"math/rand"
+	"time"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+// accountsSampler keeps a uniform random sample of the written accounts, using reservoir sampling, so the sample can be
+// taken in a single pass without knowing the number of accounts in advance
+type accountsSampler struct {
+	size      int
+	numSeen   int64
+	addresses []string
+	accounts  map[string][]byte
+	random    *rand.Rand
+}
+
+func newAccountsSampler(size int, seed int64) *accountsSampler {
+	if size < 0 {
+		size = 0
+	}
+
+	return &accountsSampler{
+		size:      size,
+		addresses: make([]string, 0, size),
+		accounts:  make(map[string][]byte, size),
+		random:    rand.New(rand.NewSource(seed)),
+	}
+}
+
+func newTimeSeededAccountsSampler(size int) *accountsSampler {
+	return newAccountsSampler(size, time.Now().UnixNano())
+}
+
+// add will keep the n-th account with the probability size/n, replacing a random account of the sample. The account is
+// serialized the same way it is indexed
+func (as *accountsSampler) add(address string, account *data.AccountInfoWithStakeValues) error {
+	if as.size == 0 {
+		return nil
+	}
+
+	as.numSeen++
+	position := len(as.addresses)
+	if position >= as.size {
+		position = int(as.random.Int63n(as.numSeen))
+		if position >= as.size {
+			return nil
+		}
+	}
+
+	serializedAccount, err := json.Marshal(account)
+	if err != nil {
+		return err
+	}
+
+	if position == len(as.addresses) {
+		as.addresses = append(as.addresses, address)
+	} else {
+		delete(as.accounts, as.addresses[position])
+		as.addresses[position] = address
+	}
+	as.accounts[address] = serializedAccount
+
+	return nil
+}
+
+func (as *accountsSampler) sample() map[string][]byte {
+	sample := make(map[string][]byte, len(as.accounts))
+	for address, serializedAccount := range as.accounts {
+		sample[address] = serializedAccount
+	}
+
+	return sample
+}
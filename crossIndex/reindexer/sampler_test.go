@@ -0,0 +1,75 @@
+package reindexer
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	indexerData "github.com/multiversx/mx-chain-es-indexer-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountsSampler_Add(t *testing.T) {
+	t.Parallel()
+
+	sampler := newAccountsSampler(10, 1)
+	for idx := 0; idx < 5; idx++ {
+		require.Nil(t, sampler.add(fmt.Sprintf("addr%d", idx), &data.AccountInfoWithStakeValues{}))
+	}
+	require.Len(t, sampler.sample(), 5)
+
+	for idx := 5; idx < 1000; idx++ {
+		account := &data.AccountInfoWithStakeValues{
+			AccountInfo: indexerData.AccountInfo{Balance: fmt.Sprintf("%d", idx)},
+		}
+		require.Nil(t, sampler.add(fmt.Sprintf("addr%d", idx), account))
+	}
+
+	sample := sampler.sample()
+	require.Len(t, sample, 10)
+	require.Len(t, sampler.addresses, 10)
+	for _, address := range sampler.addresses {
+		serializedAccount, ok := sample[address]
+		require.True(t, ok)
+
+		account := &data.AccountInfoWithStakeValues{}
+		require.Nil(t, json.Unmarshal(serializedAccount, account))
+		if account.Balance != "" {
+			require.Equal(t, address, "addr"+account.Balance)
+		}
+	}
+}
+
+func TestAccountsSampler_IsUniform(t *testing.T) {
+	t.Parallel()
+
+	numAccounts := 100
+	numRuns := 2000
+	hits := make([]int, numAccounts)
+	for run := 0; run < numRuns; run++ {
+		sampler := newAccountsSampler(10, int64(run))
+		for idx := 0; idx < numAccounts; idx++ {
+			require.Nil(t, sampler.add(fmt.Sprintf("%d", idx), &data.AccountInfoWithStakeValues{}))
+		}
+		for idx := 0; idx < numAccounts; idx++ {
+			_, ok := sampler.accounts[fmt.Sprintf("%d", idx)]
+			if ok {
+				hits[idx]++
+			}
+		}
+	}
+
+	// every account is expected in 10% of the samples, 200 out of 2000
+	for idx, numHits := range hits {
+		require.InDelta(t, 200, numHits, 80, "account %d", idx)
+	}
+}
+
+func TestAccountsSampler_ZeroSize(t *testing.T) {
+	t.Parallel()
+
+	sampler := newAccountsSampler(0, 1)
+	require.Nil(t, sampler.add("addr", &data.AccountInfoWithStakeValues{}))
+	require.Empty(t, sampler.sample())
+}
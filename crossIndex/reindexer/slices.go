@@ -0,0 +1,111 @@
+package reindexer
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+var errSliceAborted = errors.New("slice reading aborted")
+
+type accountsPage struct {
+	numSourceDocuments int
+	keptAccounts       map[string]*data.AccountInfoWithStakeValues
+	filteredAccounts   map[string]*data.AccountInfoWithStakeValues
+}
+
+// readSourceSlices will read the slices of the source index in parallel, every slice with its own scroll. The pages
+// are prepared by the slice workers, while writePage is called from a single goroutine, in a round-robin order over the
+// slices, so the same source index is always written in the same order
+func (r *reindexer) readSourceSlices(
+	sourceIndex string,
+	preparePage func(responseBytes []byte) (*accountsPage, error),
+	writePage func(page *accountsPage) error,
+) error {
+	pages := make([]chan *accountsPage, r.numSourceSlices)
+	sliceErrors := make([]error, r.numSourceSlices)
+	done := make(chan struct{})
+	abortOnce := sync.Once{}
+	abort := func() {
+		abortOnce.Do(func() {
+			close(done)
+		})
+	}
+
+	wg := sync.WaitGroup{}
+	for sliceID := 0; sliceID < r.numSourceSlices; sliceID++ {
+		pages[sliceID] = make(chan *accountsPage, 1)
+
+		wg.Add(1)
+		go func(sliceID int) {
+			defer wg.Done()
+			defer close(pages[sliceID])
+
+			handlerFunc := func(responseBytes []byte) error {
+				page, err := preparePage(responseBytes)
+				if err != nil {
+					return err
+				}
+
+				select {
+				case pages[sliceID] <- page:
+					return nil
+				case <-done:
+					return errSliceAborted
+				}
+			}
+
+			query := crossIndex.GetAllSlice(sliceID, r.numSourceSlices)
+			err := r.sourceIndexer.DoScrollRequestAllDocuments(sourceIndex, query.Bytes(), handlerFunc)
+			if err != nil {
+				sliceErrors[sliceID] = err
+				abort()
+			}
+		}(sliceID)
+	}
+
+	errWrite := writePagesInOrder(pages, writePage)
+	if errWrite != nil {
+		abort()
+	}
+	wg.Wait()
+
+	if errWrite != nil {
+		return errWrite
+	}
+	for _, err := range sliceErrors {
+		if err != nil && !errors.Is(err, errSliceAborted) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writePagesInOrder(pages []chan *accountsPage, writePage func(page *accountsPage) error) error {
+	finished := make([]bool, len(pages))
+	numFinished := 0
+	for numFinished < len(pages) {
+		for sliceID, slicePages := range pages {
+			if finished[sliceID] {
+				continue
+			}
+
+			page, ok := <-slicePages
+			if !ok {
+				finished[sliceID] = true
+				numFinished++
+				continue
+			}
+
+			err := writePage(page)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package reindexer
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+// createSlicedSourceStub returns a source client whose slices have numPages pages, every page holding a single account
+// named after its slice and page
+func createSlicedSourceStub(numPages []int) *mocks.ElasticClientStub {
+	return &mocks.ElasticClientStub{
+		DoScrollRequestAllDocumentsCalled: func(index string, body []byte, handlerFunc func(responseBytes []byte) error) error {
+			sliceID := int(gjson.GetBytes(body, "slice.id").Int())
+			for page := 0; page < numPages[sliceID]; page++ {
+				time.Sleep(time.Duration(rand.Intn(3)) * time.Millisecond)
+				err := handlerFunc([]byte(fmt.Sprintf("%d-%d", sliceID, page)))
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func preparePageStub(responseBytes []byte) (*accountsPage, error) {
+	return &accountsPage{
+		keptAccounts: map[string]*data.AccountInfoWithStakeValues{
+			string(responseBytes): {},
+		},
+	}, nil
+}
+
+func readAllSlices(t *testing.T, r *reindexer) []string {
+	written := make([]string, 0)
+	err := r.readSourceSlices("accounts", preparePageStub, func(page *accountsPage) error {
+		for address := range page.keptAccounts {
+			written = append(written, address)
+		}
+		return nil
+	})
+	require.Nil(t, err)
+
+	return written
+}
+
+func TestReindexer_ReadSourceSlicesIsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	r := &reindexer{
+		sourceIndexer:   createSlicedSourceStub([]int{3, 1, 2}),
+		numSourceSlices: 3,
+	}
+
+	expected := []string{"0-0", "1-0", "2-0", "0-1", "2-1", "0-2"}
+	for run := 0; run < 10; run++ {
+		require.Equal(t, expected, readAllSlices(t, r))
+	}
+}
+
+func TestReindexer_ReadSourceSlicesSingleSlice(t *testing.T) {
+	t.Parallel()
+
+	r := &reindexer{
+		sourceIndexer: &mocks.ElasticClientStub{
+			DoScrollRequestAllDocumentsCalled: func(index string, body []byte, handlerFunc func(responseBytes []byte) error) error {
+				require.False(t, gjson.GetBytes(body, "slice").Exists())
+				return handlerFunc([]byte("0-0"))
+			},
+		},
+		numSourceSlices: 1,
+	}
+
+	require.Equal(t, []string{"0-0"}, readAllSlices(t, r))
+}
+
+func TestReindexer_ReadSourceSlicesErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("write error stops all the slices", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("write error")
+		r := &reindexer{
+			sourceIndexer:   createSlicedSourceStub([]int{100, 100}),
+			numSourceSlices: 2,
+		}
+
+		numWrites := 0
+		err := r.readSourceSlices("accounts", preparePageStub, func(page *accountsPage) error {
+			numWrites++
+			if numWrites == 3 {
+				return expectedErr
+			}
+			return nil
+		})
+		require.Equal(t, expectedErr, err)
+	})
+
+	t.Run("slice error is returned", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("scroll error")
+		r := &reindexer{
+			sourceIndexer: &mocks.ElasticClientStub{
+				DoScrollRequestAllDocumentsCalled: func(index string, body []byte, handlerFunc func(responseBytes []byte) error) error {
+					if gjson.GetBytes(body, "slice.id").Int() == 1 {
+						return expectedErr
+					}
+					for {
+						err := handlerFunc([]byte("0-0"))
+						if err != nil {
+							return err
+						}
+					}
+				},
+			},
+			numSourceSlices: 2,
+		}
+
+		err := r.readSourceSlices("accounts", preparePageStub, func(page *accountsPage) error {
+			return nil
+		})
+		require.Equal(t, expectedErr, err)
+	})
+}
@@ -0,0 +1,149 @@
+package reindexer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/core"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+const stakeStatsKey = "stakeStats"
+
+type sourceTotal struct {
+	total      *big.Int
+	numStakers uint64
+}
+
+func (st *sourceTotal) add(values ...string) {
+	total := big.NewInt(0)
+	for _, value := range values {
+		valueBig, ok := big.NewInt(0).SetString(value, 10)
+		if !ok {
+			continue
+		}
+		total.Add(total, valueBig)
+	}
+	if total.Sign() <= 0 {
+		return
+	}
+
+	st.total.Add(st.total, total)
+	st.numStakers++
+}
+
+func (st *sourceTotal) toSourceStakeStats() *data.SourceStakeStats {
+	return &data.SourceStakeStats{
+		TotalStake:    st.total.String(),
+		TotalStakeNum: core.ComputeBalanceAsFloat(st.total.String()),
+		NumStakers:    st.numStakers,
+	}
+}
+
+func newSourceTotal() *sourceTotal {
+	return &sourceTotal{
+		total: big.NewInt(0),
+	}
+}
+
+type stakeTotals struct {
+	sources    map[string]*sourceTotal
+	totalStake *sourceTotal
+}
+
+func newStakeTotals() *stakeTotals {
+	return &stakeTotals{
+		sources: map[string]*sourceTotal{
+			data.SourceLegacyDelegation: newSourceTotal(),
+			data.SourceValidators:       newSourceTotal(),
+			data.SourceDelegation:       newSourceTotal(),
+			data.SourceLKMEX:            newSourceTotal(),
+			data.SourceEnergy:           newSourceTotal(),
+		},
+		totalStake: newSourceTotal(),
+	}
+}
+
+func (st *stakeTotals) add(account *data.AccountInfoWithStakeValues) {
+	st.sources[data.SourceLegacyDelegation].add(account.DelegationLegacyActive, account.DelegationLegacyWaiting)
+	st.sources[data.SourceValidators].add(account.ValidatorsActive, account.ValidatorTopUp)
+	st.sources[data.SourceDelegation].add(account.Delegation)
+	st.sources[data.SourceLKMEX].add(account.LKMEXStake)
+	st.sources[data.SourceEnergy].add(account.Energy)
+	st.totalStake.add(account.TotalStake)
+}
+
+func (st *stakeTotals) toSourcesStakeStats() map[string]*data.SourceStakeStats {
+	stats := make(map[string]*data.SourceStakeStats, len(st.sources))
+	for name, source := range st.sources {
+		stats[name] = source.toSourceStakeStats()
+	}
+
+	return stats
+}
+
+// ComputeSourcesStakeStats will compute the total stake and the number of stakers of every source of the accounts with
+// stake fetched from the API, the total energy being returned under the energy source
+func ComputeSourcesStakeStats(accountsData *data.AccountsData) map[string]*data.SourceStakeStats {
+	totals := newStakeTotals()
+	for _, account := range accountsData.AccountsWithStake {
+		totals.add(account)
+	}
+
+	return totals.toSourcesStakeStats()
+}
+
+// computeStakeStats will compute the network level totals of the indexed accounts. The totals of all the accounts with
+// stake fetched from the API, before the merge and the filter, are kept apart as the fetched sources
+func computeStakeStats(accountsData *data.AccountsData, totals *stakeTotals, indexedAccounts []*indexedAccount) *data.StakeStats {
+	sources := make(map[string]*sourceTotal, len(totals.sources))
+	for name, source := range totals.sources {
+		sources[name] = source
+	}
+	totalStake := totals.totalStake
+	totalEnergy := sources[data.SourceEnergy]
+	delete(sources, data.SourceEnergy)
+
+	sortedBalances := make([]float64, len(indexedAccounts))
+	for idx, account := range indexedAccounts {
+		sortedBalances[idx] = account.totalBalanceWithStakeNum
+	}
+	sort.Float64s(sortedBalances)
+
+	stats := &data.StakeStats{
+		Key:                          stakeStatsKey,
+		Epoch:                        accountsData.Epoch,
+		NumAccounts:                  uint64(len(sortedBalances)),
+		TotalStake:                   totalStake.total.String(),
+		TotalStakeNum:                core.ComputeBalanceAsFloat(totalStake.total.String()),
+		NumStakers:                   totalStake.numStakers,
+		Sources:                      make(map[string]*data.SourceStakeStats, len(sources)),
+		TotalEnergy:                  totalEnergy.total.String(),
+		TotalEnergyNum:               core.ComputeBalanceAsFloat(totalEnergy.total.String()),
+		NumAccountsWithEnergy:        totalEnergy.numStakers,
+		GiniTotalBalanceWithStake:    core.ComputeGiniCoefficient(sortedBalances),
+		Top1PercentBalanceWithStake:  core.ComputeTopPercentThreshold(sortedBalances, 1),
+		Top10PercentBalanceWithStake: core.ComputeTopPercentThreshold(sortedBalances, 10),
+		FetchedSources:               ComputeSourcesStakeStats(accountsData),
+	}
+	for name, source := range sources {
+		stats.Sources[name] = source.toSourceStakeStats()
+	}
+
+	return stats
+}
+
+func createStakeStatsDocument(stakeStats *data.StakeStats) (*valuesDocument, error) {
+	stakeStatsBytes, err := json.Marshal(stakeStats)
+	if err != nil {
+		return nil, err
+	}
+
+	return &valuesDocument{
+		id:   fmt.Sprintf(crossIndex.StakeStatsIDFormat, stakeStats.Epoch),
+		body: stakeStatsBytes,
+	}, nil
+}
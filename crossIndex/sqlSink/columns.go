@@ -0,0 +1,139 @@
+package sqlSink
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+const (
+	typeText    = "TEXT"
+	typeDouble  = "DOUBLE PRECISION"
+	typeInteger = "BIGINT"
+
+	tagsSeparator = "|"
+)
+
+type column struct {
+	name     string
+	sqlType  string
+	getValue func(epoch uint32, address string, account *data.AccountInfoWithStakeValues) interface{}
+}
+
+var accountsColumns = []*column{
+	{"epoch", typeInteger, func(epoch uint32, _ string, _ *data.AccountInfoWithStakeValues) interface{} { return int64(epoch) }},
+	{"address", typeText, func(_ uint32, address string, _ *data.AccountInfoWithStakeValues) interface{} { return address }},
+	{"shard_id", typeInteger, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return int64(a.ShardID) }},
+	{"balance", typeText, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return a.Balance }},
+	{"balance_num", typeDouble, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return a.BalanceNum }},
+	{"total_balance_with_stake", typeText, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} {
+		return a.TotalBalanceWithStake
+	}},
+	{"total_balance_with_stake_num", typeDouble, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} {
+		return a.TotalBalanceWithStakeNum
+	}},
+	{"delegation_legacy_waiting", typeText, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} {
+		return a.DelegationLegacyWaiting
+	}},
+	{"delegation_legacy_waiting_num", typeDouble, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} {
+		return a.DelegationLegacyWaitingNum
+	}},
+	{"delegation_legacy_active", typeText, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} {
+		return a.DelegationLegacyActive
+	}},
+	{"delegation_legacy_active_num", typeDouble, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} {
+		return a.DelegationLegacyActiveNum
+	}},
+	{"validators_active", typeText, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return a.ValidatorsActive }},
+	{"validators_active_num", typeDouble, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return a.ValidatorsActiveNum }},
+	{"validators_top_up", typeText, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return a.ValidatorTopUp }},
+	{"validators_top_up_num", typeDouble, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return a.ValidatorTopUpNum }},
+	{"delegation", typeText, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return a.Delegation }},
+	{"delegation_num", typeDouble, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return a.DelegationNum }},
+	{"total_stake", typeText, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return a.TotalStake }},
+	{"total_stake_num", typeDouble, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return a.TotalStakeNum }},
+	{"lkmex_stake", typeText, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return a.LKMEXStake }},
+	{"lkmex_stake_num", typeDouble, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return a.LKMEXStakeNum }},
+	{"energy", typeText, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return a.Energy }},
+	{"energy_num", typeDouble, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return a.EnergyNum }},
+	{"label", typeText, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return a.Label }},
+	{"tags", typeText, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} {
+		return strings.Join(a.Tags, tagsSeparator)
+	}},
+	{"rank_total_balance_with_stake", typeInteger, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} {
+		return int64(a.RankTotalBalanceWithStake)
+	}},
+	{"percentile_total_balance_with_stake", typeDouble, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} {
+		return a.PercentileTotalBalanceWithStake
+	}},
+	{"rank_stake", typeInteger, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return int64(a.RankStake) }},
+	{"percentile_stake", typeDouble, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return a.PercentileStake }},
+	{"rank_energy", typeInteger, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return int64(a.RankEnergy) }},
+	{"percentile_energy", typeDouble, func(_ uint32, _ string, a *data.AccountInfoWithStakeValues) interface{} { return a.PercentileEnergy }},
+}
+
+type rankColumn struct {
+	name     string
+	sqlType  string
+	getValue func(ranks *data.AccountRanks) interface{}
+}
+
+// ranksColumns are the rank columns of accountsColumns, written with zero values together with the accounts and updated
+// once the ranks are known
+var ranksColumns = []*rankColumn{
+	{"rank_total_balance_with_stake", typeInteger, func(r *data.AccountRanks) interface{} { return int64(r.RankTotalBalanceWithStake) }},
+	{"percentile_total_balance_with_stake", typeDouble, func(r *data.AccountRanks) interface{} { return r.PercentileTotalBalanceWithStake }},
+	{"rank_stake", typeInteger, func(r *data.AccountRanks) interface{} { return int64(r.RankStake) }},
+	{"percentile_stake", typeDouble, func(r *data.AccountRanks) interface{} { return r.PercentileStake }},
+	{"rank_energy", typeInteger, func(r *data.AccountRanks) interface{} { return int64(r.RankEnergy) }},
+	{"percentile_energy", typeDouble, func(r *data.AccountRanks) interface{} { return r.PercentileEnergy }},
+}
+
+func columnNames() []string {
+	names := make([]string, len(accountsColumns))
+	for idx, c := range accountsColumns {
+		names[idx] = c.name
+	}
+
+	return names
+}
+
+func columnsDefinition() string {
+	definitions := make([]string, len(accountsColumns))
+	for idx, c := range accountsColumns {
+		definitions[idx] = fmt.Sprintf("%s %s NOT NULL", c.name, c.sqlType)
+	}
+
+	return strings.Join(definitions, ", ")
+}
+
+// updateRanksQuery returns the update of the ranks of an account, filtered by the whole primary key (epoch, address) so
+// every update is an index lookup
+func updateRanksQuery(table string) string {
+	assignments := make([]string, len(ranksColumns))
+	for idx, rc := range ranksColumns {
+		assignments[idx] = fmt.Sprintf("%s = $%d", rc.name, idx+1)
+	}
+
+	return fmt.Sprintf("UPDATE %s SET %s WHERE epoch = $%d AND address = $%d",
+		table, strings.Join(assignments, ", "), len(ranksColumns)+1, len(ranksColumns)+2)
+}
+
+func ranksRow(epoch uint32, address string, ranks *data.AccountRanks) []interface{} {
+	row := make([]interface{}, 0, len(ranksColumns)+2)
+	for _, rc := range ranksColumns {
+		row = append(row, rc.getValue(ranks))
+	}
+
+	return append(row, int64(epoch), address)
+}
+
+func accountRow(epoch uint32, address string, account *data.AccountInfoWithStakeValues) []interface{} {
+	row := make([]interface{}, len(accountsColumns))
+	for idx, c := range accountsColumns {
+		row[idx] = c.getValue(epoch, address, account)
+	}
+
+	return row
+}
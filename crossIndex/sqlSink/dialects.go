@@ -0,0 +1,118 @@
+package sqlSink
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+const (
+	driverPostgres = "postgres"
+	driverSQLite   = "sqlite3"
+)
+
+type dialect interface {
+	prepareSnapshotQueries(parentTable string, table string, epoch uint32) []string
+	valuesTableQuery(valuesTable string) string
+	insertRows(db *sql.DB, table string, rows [][]interface{}) error
+}
+
+// postgresDialect keeps all the snapshots in a table partitioned by epoch and uses COPY to insert the accounts
+type postgresDialect struct{}
+
+func (pd *postgresDialect) prepareSnapshotQueries(parentTable string, table string, epoch uint32) []string {
+	return []string{
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s, PRIMARY KEY (epoch, address)) PARTITION BY LIST (epoch)", parentTable, columnsDefinition()),
+		fmt.Sprintf("DROP TABLE IF EXISTS %s", table),
+		fmt.Sprintf("CREATE TABLE %s PARTITION OF %s FOR VALUES IN (%d)", table, parentTable, epoch),
+	}
+}
+
+func (pd *postgresDialect) valuesTableQuery(valuesTable string) string {
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id TEXT PRIMARY KEY, epoch BIGINT NOT NULL, document JSONB NOT NULL)", valuesTable)
+}
+
+func (pd *postgresDialect) insertRows(db *sql.DB, table string, rows [][]interface{}) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(pq.CopyIn(table, columnNames()...))
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	for _, row := range rows {
+		_, err = stmt.Exec(row...)
+		if err != nil {
+			_ = stmt.Close()
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	_, err = stmt.Exec()
+	if err != nil {
+		_ = stmt.Close()
+		_ = tx.Rollback()
+		return err
+	}
+
+	err = stmt.Close()
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// sqliteDialect keeps every snapshot in its own table and inserts the accounts in a transaction
+type sqliteDialect struct{}
+
+func (sd *sqliteDialect) prepareSnapshotQueries(_ string, table string, _ uint32) []string {
+	return []string{
+		fmt.Sprintf("DROP TABLE IF EXISTS %s", table),
+		fmt.Sprintf("CREATE TABLE %s (%s, PRIMARY KEY (epoch, address))", table, columnsDefinition()),
+	}
+}
+
+func (sd *sqliteDialect) valuesTableQuery(valuesTable string) string {
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id TEXT PRIMARY KEY, epoch BIGINT NOT NULL, document TEXT NOT NULL)", valuesTable)
+}
+
+func (sd *sqliteDialect) insertRows(db *sql.DB, table string, rows [][]interface{}) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(accountsColumns)), ", ")
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columnNames(), ", "), placeholders)
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	for _, row := range rows {
+		_, err = stmt.Exec(row...)
+		if err != nil {
+			_ = stmt.Close()
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	err = stmt.Close()
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,15 @@
+package sqlSink
+
+import "errors"
+
+// ErrInvalidDriver signals that an unsupported SQL driver has been provided
+var ErrInvalidDriver = errors.New("invalid SQL driver")
+
+// ErrEmptyDSN signals that an empty data source name has been provided
+var ErrEmptyDSN = errors.New("empty data source name")
+
+// ErrInvalidTablePrefix signals that the table prefix is not a valid SQL identifier
+var ErrInvalidTablePrefix = errors.New("invalid table prefix")
+
+// ErrSnapshotNotPrepared signals that the accounts are written before preparing the snapshot
+var ErrSnapshotNotPrepared = errors.New("snapshot not prepared")
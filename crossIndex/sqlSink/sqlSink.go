@@ -0,0 +1,224 @@
+package sqlSink
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+
+	// registers the SQLite driver
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const (
+	defaultTablePrefix = "accounts"
+	valuesTableSuffix  = "_values"
+	snapshotDocFormat  = "snapshot-%d"
+)
+
+var log = logger.GetOrCreate("crossIndex/sqlSink")
+
+var identifierRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+type snapshotInfo struct {
+	Index    string `json:"index"`
+	Table    string `json:"table"`
+	RowCount uint64 `json:"rowCount"`
+}
+
+type sqlSink struct {
+	db          *sql.DB
+	dialect     dialect
+	tablePrefix string
+
+	currentTable string
+	currentEpoch uint32
+	currentIndex string
+	rowCount     uint64
+}
+
+// NewSQLSink will create a new instance of sqlSink
+func NewSQLSink(cfg config.SQLSinkConfig) (*sqlSink, error) {
+	var sinkDialect dialect
+	switch cfg.Driver {
+	case driverPostgres:
+		sinkDialect = &postgresDialect{}
+	case driverSQLite:
+		sinkDialect = &sqliteDialect{}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDriver, cfg.Driver)
+	}
+	if cfg.DSN == "" {
+		return nil, ErrEmptyDSN
+	}
+
+	tablePrefix := cfg.TablePrefix
+	if tablePrefix == "" {
+		tablePrefix = defaultTablePrefix
+	}
+	if !identifierRegex.MatchString(tablePrefix) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTablePrefix, tablePrefix)
+	}
+
+	db, err := sql.Open(cfg.Driver, cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlSink{
+		db:          db,
+		dialect:     sinkDialect,
+		tablePrefix: tablePrefix,
+	}, nil
+}
+
+// PrepareSnapshot will (re)create the table of the provided epoch and the values table
+func (ss *sqlSink) PrepareSnapshot(index string, epoch uint32) error {
+	table := fmt.Sprintf("%s_%d", ss.tablePrefix, epoch)
+
+	queries := ss.dialect.prepareSnapshotQueries(ss.tablePrefix, table, epoch)
+	queries = append(queries, ss.dialect.valuesTableQuery(ss.valuesTable()))
+	for _, query := range queries {
+		_, err := ss.db.Exec(query)
+		if err != nil {
+			return fmt.Errorf("sqlSink.PrepareSnapshot: %w, query: %s", err, query)
+		}
+	}
+
+	ss.currentTable = table
+	ss.currentEpoch = epoch
+	ss.currentIndex = index
+	ss.rowCount = 0
+
+	log.Info("writing accounts snapshot in SQL table", "table", table)
+
+	return nil
+}
+
+// WriteBatch will insert the provided accounts in the table of the current snapshot
+func (ss *sqlSink) WriteBatch(accounts map[string]*data.AccountInfoWithStakeValues) error {
+	if ss.currentTable == "" {
+		return ErrSnapshotNotPrepared
+	}
+
+	addresses := make([]string, 0, len(accounts))
+	for address := range accounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	rows := make([][]interface{}, 0, len(accounts))
+	for _, address := range addresses {
+		rows = append(rows, accountRow(ss.currentEpoch, address, accounts[address]))
+	}
+
+	err := ss.dialect.insertRows(ss.db, ss.currentTable, rows)
+	if err != nil {
+		return err
+	}
+
+	ss.rowCount += uint64(len(rows))
+
+	return nil
+}
+
+// WriteRanks will update the ranks and the percentiles of the accounts of the current snapshot
+func (ss *sqlSink) WriteRanks(ranks map[string]*data.AccountRanks) error {
+	if ss.currentTable == "" {
+		return ErrSnapshotNotPrepared
+	}
+
+	addresses := make([]string, 0, len(ranks))
+	for address := range ranks {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	tx, err := ss.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(updateRanksQuery(ss.currentTable))
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	for _, address := range addresses {
+		_, err = stmt.Exec(ranksRow(ss.currentEpoch, address, ranks[address])...)
+		if err != nil {
+			_ = stmt.Close()
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	err = stmt.Close()
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// WriteMetadata will save the provided document in the values table
+func (ss *sqlSink) WriteMetadata(documentID string, document []byte) error {
+	if ss.currentTable == "" {
+		return ErrSnapshotNotPrepared
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (id, epoch, document) VALUES ($1, $2, $3) ON CONFLICT (id) DO UPDATE SET epoch = excluded.epoch, document = excluded.document",
+		ss.valuesTable(),
+	)
+	_, err := ss.db.Exec(query, documentID, int64(ss.currentEpoch), string(document))
+
+	return err
+}
+
+// Finalize will save the information about the current snapshot in the values table
+func (ss *sqlSink) Finalize() error {
+	if ss.currentTable == "" {
+		return ErrSnapshotNotPrepared
+	}
+
+	info, err := json.Marshal(&snapshotInfo{
+		Index:    ss.currentIndex,
+		Table:    ss.currentTable,
+		RowCount: ss.rowCount,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ss.WriteMetadata(fmt.Sprintf(snapshotDocFormat, ss.currentEpoch), info)
+	if err != nil {
+		return err
+	}
+
+	log.Info("wrote accounts snapshot in SQL table", "table", ss.currentTable, "rows", ss.rowCount)
+	ss.currentTable = ""
+
+	return nil
+}
+
+// Close will close the connections to the database
+func (ss *sqlSink) Close() error {
+	return ss.db.Close()
+}
+
+func (ss *sqlSink) valuesTable() string {
+	return ss.tablePrefix + valuesTableSuffix
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ss *sqlSink) IsInterfaceNil() bool {
+	return ss == nil
+}
@@ -0,0 +1,203 @@
+package sqlSink
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIndex = "accounts-000001_5"
+	testEpoch = 5
+)
+
+func createSQLiteSink(t *testing.T) (*sqlSink, *sql.DB) {
+	dsn := filepath.Join(t.TempDir(), "accounts.db")
+	sink, err := NewSQLSink(config.SQLSinkConfig{
+		Driver:      driverSQLite,
+		DSN:         dsn,
+		TablePrefix: "snapshots",
+	})
+	require.Nil(t, err)
+
+	db, err := sql.Open(driverSQLite, dsn)
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+		_ = sink.db.Close()
+	})
+
+	return sink, db
+}
+
+func createAccounts(addresses ...string) map[string]*data.AccountInfoWithStakeValues {
+	accounts := make(map[string]*data.AccountInfoWithStakeValues)
+	for idx, address := range addresses {
+		account := &data.AccountInfoWithStakeValues{}
+		account.Balance = "1000"
+		account.BalanceNum = 0.001
+		account.ShardID = uint32(idx)
+		account.TotalStake = "2000"
+		account.TotalStakeNum = 0.002
+		accounts[address] = account
+	}
+
+	return accounts
+}
+
+func TestNewSQLSink(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSQLSink(config.SQLSinkConfig{Driver: "mysql", DSN: "dsn"})
+	require.True(t, errors.Is(err, ErrInvalidDriver))
+
+	_, err = NewSQLSink(config.SQLSinkConfig{Driver: driverPostgres})
+	require.Equal(t, ErrEmptyDSN, err)
+
+	_, err = NewSQLSink(config.SQLSinkConfig{Driver: driverPostgres, DSN: "dsn", TablePrefix: "accounts; DROP TABLE x"})
+	require.True(t, errors.Is(err, ErrInvalidTablePrefix))
+
+	sink, err := NewSQLSink(config.SQLSinkConfig{Driver: driverPostgres, DSN: "postgres://localhost/db"})
+	require.Nil(t, err)
+	require.False(t, sink.IsInterfaceNil())
+	require.Equal(t, defaultTablePrefix, sink.tablePrefix)
+}
+
+func TestSQLSink_WriteBatchBeforePrepare(t *testing.T) {
+	t.Parallel()
+
+	sink, _ := createSQLiteSink(t)
+
+	err := sink.WriteBatch(createAccounts("erd1a"))
+	require.Equal(t, ErrSnapshotNotPrepared, err)
+	require.Equal(t, ErrSnapshotNotPrepared, sink.Finalize())
+}
+
+func TestSQLSink_SQLiteSnapshot(t *testing.T) {
+	t.Parallel()
+
+	sink, db := createSQLiteSink(t)
+
+	err := sink.PrepareSnapshot(testIndex, testEpoch)
+	require.Nil(t, err)
+
+	accounts := createAccounts("erd1b", "erd1a")
+	accounts["erd1a"].Label = "exchange"
+	accounts["erd1a"].Tags = []string{"cex", "hot"}
+	require.Nil(t, sink.WriteBatch(accounts))
+	require.Nil(t, sink.WriteBatch(createAccounts("erd1c")))
+	require.Nil(t, sink.WriteMetadata("stake-stats-5", []byte(`{"key":"stakeStats"}`)))
+	require.Nil(t, sink.Finalize())
+
+	var count int
+	require.Nil(t, db.QueryRow("SELECT COUNT(*) FROM snapshots_5").Scan(&count))
+	require.Equal(t, 3, count)
+
+	var label, tags, totalStake string
+	var epoch, shardID int64
+	var balanceNum float64
+	row := db.QueryRow("SELECT epoch, shard_id, balance_num, total_stake, label, tags FROM snapshots_5 WHERE address = ?", "erd1a")
+	require.Nil(t, row.Scan(&epoch, &shardID, &balanceNum, &totalStake, &label, &tags))
+	require.Equal(t, int64(testEpoch), epoch)
+	require.Equal(t, 0.001, balanceNum)
+	require.Equal(t, "2000", totalStake)
+	require.Equal(t, "exchange", label)
+	require.Equal(t, "cex|hot", tags)
+
+	var document string
+	require.Nil(t, db.QueryRow("SELECT document FROM snapshots_values WHERE id = ?", "stake-stats-5").Scan(&document))
+	require.Equal(t, `{"key":"stakeStats"}`, document)
+	require.Nil(t, db.QueryRow("SELECT document FROM snapshots_values WHERE id = ?", "snapshot-5").Scan(&document))
+	require.Equal(t, `{"index":"accounts-000001_5","table":"snapshots_5","rowCount":3}`, document)
+
+	// a second run of the same epoch replaces the previous snapshot
+	require.Nil(t, sink.PrepareSnapshot(testIndex, testEpoch))
+	require.Nil(t, sink.WriteBatch(createAccounts("erd1d")))
+	require.Nil(t, sink.WriteMetadata("stake-stats-5", []byte(`{"key":"stakeStats","numAccounts":1}`)))
+	require.Nil(t, sink.Finalize())
+
+	require.Nil(t, db.QueryRow("SELECT COUNT(*) FROM snapshots_5").Scan(&count))
+	require.Equal(t, 1, count)
+	require.Nil(t, db.QueryRow("SELECT document FROM snapshots_values WHERE id = ?", "stake-stats-5").Scan(&document))
+	require.Equal(t, `{"key":"stakeStats","numAccounts":1}`, document)
+}
+
+func TestSQLSink_WriteRanks(t *testing.T) {
+	t.Parallel()
+
+	sink, db := createSQLiteSink(t)
+
+	require.Equal(t, ErrSnapshotNotPrepared, sink.WriteRanks(map[string]*data.AccountRanks{}))
+
+	require.Nil(t, sink.PrepareSnapshot(testIndex, testEpoch))
+	require.Nil(t, sink.WriteBatch(createAccounts("erd1a", "erd1b")))
+	require.Nil(t, sink.WriteRanks(map[string]*data.AccountRanks{
+		"erd1a": {
+			RankTotalBalanceWithStake:       2,
+			PercentileTotalBalanceWithStake: 50,
+			RankStake:                       1,
+			PercentileStake:                 100,
+		},
+	}))
+	require.Nil(t, sink.Finalize())
+
+	var rankTotal, rankStake, rankEnergy int64
+	var percentileTotal, percentileStake float64
+	row := db.QueryRow("SELECT rank_total_balance_with_stake, percentile_total_balance_with_stake, rank_stake, percentile_stake, rank_energy FROM snapshots_5 WHERE address = ?", "erd1a")
+	require.Nil(t, row.Scan(&rankTotal, &percentileTotal, &rankStake, &percentileStake, &rankEnergy))
+	require.Equal(t, int64(2), rankTotal)
+	require.Equal(t, float64(50), percentileTotal)
+	require.Equal(t, int64(1), rankStake)
+	require.Equal(t, float64(100), percentileStake)
+	require.Equal(t, int64(0), rankEnergy)
+
+	row = db.QueryRow("SELECT rank_stake FROM snapshots_5 WHERE address = ?", "erd1b")
+	require.Nil(t, row.Scan(&rankStake))
+	require.Equal(t, int64(0), rankStake)
+}
+
+func TestSQLSink_Close(t *testing.T) {
+	t.Parallel()
+
+	sink, _ := createSQLiteSink(t)
+	require.Nil(t, sink.db.Ping())
+
+	require.Nil(t, sink.Close())
+	require.NotNil(t, sink.db.Ping())
+}
+
+func TestUpdateRanksQuery(t *testing.T) {
+	t.Parallel()
+
+	query := updateRanksQuery("accounts_5")
+	require.True(t, strings.HasSuffix(query, " WHERE epoch = $7 AND address = $8"), query)
+
+	row := ranksRow(testEpoch, "erd1a", &data.AccountRanks{RankStake: 3})
+	require.Len(t, row, 8)
+	require.Equal(t, int64(3), row[2])
+	require.Equal(t, int64(testEpoch), row[6])
+	require.Equal(t, "erd1a", row[7])
+
+	for _, rc := range ranksColumns {
+		require.Contains(t, columnNames(), rc.name)
+	}
+}
+
+func TestSQLSink_WriteRanksUsesThePrimaryKey(t *testing.T) {
+	t.Parallel()
+
+	sink, db := createSQLiteSink(t)
+	require.Nil(t, sink.PrepareSnapshot(testIndex, testEpoch))
+
+	var id, parent, notUsed int
+	var detail string
+	row := db.QueryRow("EXPLAIN QUERY PLAN "+strings.ReplaceAll(updateRanksQuery("snapshots_5"), "$", "?"), 1, 0, 1, 0, 0, 0, testEpoch, "erd1a")
+	require.Nil(t, row.Scan(&id, &parent, &notUsed, &detail))
+	require.Contains(t, detail, "USING INDEX")
+}
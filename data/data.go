@@ -2,6 +2,7 @@ package data
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/data/vm"
 	"github.com/multiversx/mx-chain-es-indexer-go/data"
@@ -77,22 +78,51 @@ type AccountsResponseES struct {
 
 // BulkRequestResponse defines the structure of a bulk request response
 type BulkRequestResponse struct {
-	Errors bool `json:"errors"`
-	Items  []struct {
-		Index struct {
-			Status int `json:"status"`
-			Error  struct {
-				Type   string `json:"type"`
-				Reason string `json:"reason"`
-			} `json:"error"`
-		} `json:"index"`
-	} `json:"items"`
+	Errors bool                       `json:"errors"`
+	Items  []*BulkRequestResponseItem `json:"items"`
+}
+
+// BulkRequestResponseItem defines the structure of the response of a single bulk action. Only the field of the action
+// from the request is set
+type BulkRequestResponseItem struct {
+	Index  *BulkActionResponse `json:"index,omitempty"`
+	Create *BulkActionResponse `json:"create,omitempty"`
+	Update *BulkActionResponse `json:"update,omitempty"`
+	Delete *BulkActionResponse `json:"delete,omitempty"`
+}
+
+// Result returns the response of the action of the item
+func (item *BulkRequestResponseItem) Result() *BulkActionResponse {
+	switch {
+	case item.Index != nil:
+		return item.Index
+	case item.Create != nil:
+		return item.Create
+	case item.Update != nil:
+		return item.Update
+	case item.Delete != nil:
+		return item.Delete
+	default:
+		return &BulkActionResponse{}
+	}
+}
+
+// BulkActionResponse defines the structure of the response of a bulk action
+type BulkActionResponse struct {
+	ID     string `json:"_id"`
+	Status int    `json:"status"`
+	Error  struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error"`
 }
 
 // AccountInfoWithStakeValues extends the structure data.AccountInfo with stake values
 type AccountInfoWithStakeValues struct {
 	data.AccountInfo
 	StakeInfo
+	AccountRanks
+	AddressLabel
 }
 
 type AccountsData struct {
@@ -100,6 +130,14 @@ type AccountsData struct {
 	Addresses         []string
 	EnergyBlockInfo   *BlockInfo
 	Epoch             uint32
+	SourcesStats      []*SourceFetchStats
+}
+
+// SourceFetchStats holds the number of accounts fetched from a stake source and the duration of the fetch
+type SourceFetchStats struct {
+	Name        string `json:"name"`
+	NumAccounts int    `json:"numAccounts"`
+	DurationMs  int64  `json:"durationMs"`
 }
 
 // StakeInfo is the structure that contains all information about stake for an account
@@ -124,6 +162,22 @@ type StakeInfo struct {
 	EnergyDetails *EnergyDetails `json:"energyDetails,omitempty"`
 }
 
+// AccountRanks is the structure that contains the global rank and percentile of an account
+type AccountRanks struct {
+	RankTotalBalanceWithStake       uint64  `json:"rankTotalBalanceWithStake,omitempty"`
+	PercentileTotalBalanceWithStake float64 `json:"percentileTotalBalanceWithStake,omitempty"`
+	RankStake                       uint64  `json:"rankStake,omitempty"`
+	PercentileStake                 float64 `json:"percentileStake,omitempty"`
+	RankEnergy                      uint64  `json:"rankEnergy,omitempty"`
+	PercentileEnergy                float64 `json:"percentileEnergy,omitempty"`
+}
+
+// AddressLabel is the structure that contains the label and the tags of a known address
+type AddressLabel struct {
+	Label string   `json:"label,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
 // EnergyDetails is the structure that contains details about the user's energy
 type EnergyDetails struct {
 	LastUpdateEpoch   uint32 `json:"lastUpdateEpoch"`
@@ -137,11 +191,112 @@ type KeyValueObj struct {
 	Value string `json:"value"`
 }
 
+const (
+	// SourceLegacyDelegation is the name of the legacy delegation stake source
+	SourceLegacyDelegation = "legacyDelegation"
+	// SourceValidators is the name of the validators (direct staking) stake source
+	SourceValidators = "validators"
+	// SourceDelegation is the name of the delegation manager stake source
+	SourceDelegation = "delegation"
+	// SourceLKMEX is the name of the LKMEX staking source
+	SourceLKMEX = "lkMex"
+	// SourceEnergy is the name of the energy source
+	SourceEnergy = "energy"
+)
+
+// SourceStakeStats holds the aggregated values of a single stake source
+type SourceStakeStats struct {
+	TotalStake    string  `json:"totalStake"`
+	TotalStakeNum float64 `json:"totalStakeNum"`
+	NumStakers    uint64  `json:"numStakers"`
+}
+
+// StakeStats is the dto for the network level totals of an epoch, stored in values index
+type StakeStats struct {
+	Key                          string                       `json:"key"`
+	Epoch                        uint32                       `json:"epoch"`
+	NumAccounts                  uint64                       `json:"numAccounts"`
+	TotalStake                   string                       `json:"totalStake"`
+	TotalStakeNum                float64                      `json:"totalStakeNum"`
+	NumStakers                   uint64                       `json:"numStakers"`
+	Sources                      map[string]*SourceStakeStats `json:"sources"`
+	TotalEnergy                  string                       `json:"totalEnergy"`
+	TotalEnergyNum               float64                      `json:"totalEnergyNum"`
+	NumAccountsWithEnergy        uint64                       `json:"numAccountsWithEnergy"`
+	GiniTotalBalanceWithStake    float64                      `json:"giniTotalBalanceWithStake"`
+	Top1PercentBalanceWithStake  float64                      `json:"top1PercentTotalBalanceWithStakeNum"`
+	Top10PercentBalanceWithStake float64                      `json:"top10PercentTotalBalanceWithStakeNum"`
+	// FetchedSources holds the totals of all the accounts with stake fetched from the API, before the merge and the
+	// filter, the total energy being kept under the energy source
+	FetchedSources map[string]*SourceStakeStats `json:"fetchedSources,omitempty"`
+}
+
+// ReindexSummary holds the numbers of the last reindexing and a random sample of the written accounts, serialized as they
+// were indexed, used by the post-run verification
+type ReindexSummary struct {
+	NumSourceDocuments  uint64
+	NumIndexedAccounts  uint64
+	NumFilteredAccounts uint64
+	SampledAccounts     map[string][]byte
+	StakeStats          *StakeStats
+}
+
+// DestinationStats holds the numbers of the current snapshot written by a destination
+type DestinationStats struct {
+	Name        string `json:"name"`
+	NumAccounts uint64 `json:"numAccounts"`
+	NumBatches  uint64 `json:"numBatches"`
+	Failed      bool   `json:"failed"`
+	Error       string `json:"error,omitempty"`
+}
+
+// BulkStats holds the counters of the bulk requests sent by an elastic client
+type BulkStats struct {
+	NumRequests          uint64 `json:"numRequests"`
+	NumRetriedItems      uint64 `json:"numRetriedItems"`
+	NumDeadLetteredItems uint64 `json:"numDeadLetteredItems"`
+}
+
+// IndexInfo holds the details of an index needed by the retention
+type IndexInfo struct {
+	Name         string
+	CreationDate time.Time
+	Aliases      []string
+}
+
 // EsClientConfig is a wrapper over the internally used field from elasticsearch.Config struct
 type EsClientConfig struct {
 	Address  string
 	Username string
 	Password string
+	// Addresses are extra nodes of the same cluster, used together with Address
+	Addresses []string
+	// CloudID is the endpoint of an Elastic Cloud deployment, used instead of the addresses
+	CloudID string
+	// APIKey is the base64 encoded API key. If set, it overrides the username and the password
+	APIKey string
+	// CACertFile is the PEM file with the certificate authorities trusted for the TLS connections
+	CACertFile string
+	// ClientCertFile and ClientKeyFile are the PEM files of the client certificate, used for mutual TLS
+	ClientCertFile string
+	ClientKeyFile  string
+	// InsecureSkipVerify disables the verification of the server certificate and should be used only for testing
+	InsecureSkipVerify bool
+	// DiscoverNodesOnStart enables sniffing the nodes of the cluster when the client is created
+	DiscoverNodesOnStart bool
+	// DiscoverNodesIntervalInSec enables sniffing the nodes of the cluster periodically, disabled if 0
+	DiscoverNodesIntervalInSec int
+	// OnFailure is the failure policy of a destination client: abort or markFailed
+	OnFailure string
+	// ScrollPageSize is the number of documents fetched by every scroll request
+	ScrollPageSize int
+	// BulkMaxRetries is the number of times the bulk items rejected with 429 or 503 are retried
+	BulkMaxRetries int
+	// BulkRetryBackoffInMs is the delay before the first retry, doubled for every next retry
+	BulkRetryBackoffInMs int
+	// DeadLetterFile is the NDJSON file where the documents that cannot be indexed are written. If empty, such documents
+	// fail the bulk request
+	DeadLetterFile string
 }
 
 // RestApiAuthenticationData holds the data to be used when authorizing API requests
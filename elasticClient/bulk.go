@@ -0,0 +1,265 @@
+package elasticClient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	actionDelete = "delete"
+
+	defaultBulkMaxRetries       = 3
+	defaultBulkRetryBackoffInMs = 500
+	maxBulkRetryBackoff         = 30 * time.Second
+)
+
+type bulkItem struct {
+	action string
+	id     string
+	meta   []byte
+	source []byte
+}
+
+type failedBulkItem struct {
+	item      *bulkItem
+	status    int
+	errorType string
+	reason    string
+}
+
+// parseBulkItems will split the body of a bulk request in actions. Every action has a meta line, followed by a source
+// line for all the actions except delete
+func parseBulkItems(body []byte) ([]*bulkItem, error) {
+	lines := bytes.Split(bytes.TrimRight(body, "\n"), []byte("\n"))
+	items := make([]*bulkItem, 0, len(lines)/2)
+	for idx := 0; idx < len(lines); idx++ {
+		meta := lines[idx]
+		if len(bytes.TrimSpace(meta)) == 0 {
+			continue
+		}
+
+		action := ""
+		gjson.ParseBytes(meta).ForEach(func(key, _ gjson.Result) bool {
+			action = key.String()
+			return false
+		})
+		if action == "" {
+			return nil, fmt.Errorf("%w: %s", errInvalidBulkBody, meta)
+		}
+
+		item := &bulkItem{
+			action: action,
+			id:     gjson.GetBytes(meta, action+"._id").String(),
+			meta:   meta,
+		}
+		if action != actionDelete {
+			idx++
+			if idx == len(lines) {
+				return nil, fmt.Errorf("%w: missing source for %s", errInvalidBulkBody, meta)
+			}
+			item.source = lines[idx]
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func serializeBulkItems(items []*bulkItem) *bytes.Buffer {
+	buff := &bytes.Buffer{}
+	for _, item := range items {
+		buff.Write(item.meta)
+		buff.WriteByte('\n')
+		if item.source != nil {
+			buff.Write(item.source)
+			buff.WriteByte('\n')
+		}
+	}
+
+	return buff
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
+
+// DoBulkRequest will do a bulk of request to elastic server. The items rejected with 429 or 503 are retried with an
+// exponential backoff, while the items that cannot be indexed are written in the dead-letter file, if configured
+func (ec *esClient) DoBulkRequest(buff *bytes.Buffer, index string) error {
+	items, err := parseBulkItems(buff.Bytes())
+	if err != nil {
+		return err
+	}
+	atomic.AddUint64(&ec.numBulkRequests, 1)
+
+	numItems := len(items)
+	numRetried := 0
+	failedItems := make([]*failedBulkItem, 0)
+	backoff := ec.bulkRetryBackoff
+	for attempt := 0; len(items) > 0; attempt++ {
+		retryItems, failed, errSend := ec.sendBulk(items, index)
+		if errSend != nil {
+			return errSend
+		}
+		failedItems = append(failedItems, failed...)
+
+		if len(retryItems) == 0 {
+			break
+		}
+		if attempt == ec.bulkMaxRetries {
+			for _, retryItem := range retryItems {
+				retryItem.reason = fmt.Sprintf("retries exhausted after %d attempts: %s", attempt+1, retryItem.reason)
+			}
+			failedItems = append(failedItems, retryItems...)
+			break
+		}
+
+		numRetried += len(retryItems)
+		log.Debug("retrying rejected bulk items", "index", index, "num items", len(retryItems), "backoff", backoff)
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxBulkRetryBackoff {
+			backoff = maxBulkRetryBackoff
+		}
+
+		items = make([]*bulkItem, 0, len(retryItems))
+		for _, retryItem := range retryItems {
+			items = append(items, retryItem.item)
+		}
+	}
+
+	atomic.AddUint64(&ec.numBulkRetriedItems, uint64(numRetried))
+	numFailed := len(failedItems)
+	if numRetried == 0 && numFailed == 0 {
+		return nil
+	}
+
+	if ec.deadLetter == nil && numFailed > 0 {
+		return failedItemsError(failedItems)
+	}
+
+	if numFailed > 0 {
+		err = ec.deadLetter.write(index, failedItems)
+		if err != nil {
+			return err
+		}
+		atomic.AddUint64(&ec.numBulkDeadLetteredItems, uint64(numFailed))
+	}
+
+	log.Warn("bulk request with failed items",
+		"index", index,
+		"num items", numItems,
+		"num indexed", numItems-numFailed,
+		"num retried", numRetried,
+		"num dead-lettered", numFailed,
+	)
+
+	return nil
+}
+
+// sendBulk will send the provided items and will return the items that should be retried and the items that cannot
+// be indexed
+func (ec *esClient) sendBulk(items []*bulkItem, index string) ([]*failedBulkItem, []*failedBulkItem, error) {
+	res, err := ec.client.Bulk(
+		bytes.NewReader(serializeBulkItems(items).Bytes()),
+		ec.client.Bulk.WithIndex(index),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeBody(res)
+
+	if isRetryableStatus(res.StatusCode) {
+		retryItems := make([]*failedBulkItem, 0, len(items))
+		for _, item := range items {
+			retryItems = append(retryItems, &failedBulkItem{
+				item:   item,
+				status: res.StatusCode,
+				reason: "bulk request rejected",
+			})
+		}
+
+		return retryItems, nil, nil
+	}
+	if res.IsError() {
+		return nil, nil, fmt.Errorf("error DoBulkRequest: %s", res.String())
+	}
+
+	bodyBytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bulkResponse := &data.BulkRequestResponse{}
+	err = json.Unmarshal(bodyBytes, bulkResponse)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !bulkResponse.Errors {
+		return nil, nil, nil
+	}
+	if len(bulkResponse.Items) != len(items) {
+		return nil, nil, fmt.Errorf("%w: sent %d items, received %d", errBulkResponseMismatch, len(items), len(bulkResponse.Items))
+	}
+
+	retryItems := make([]*failedBulkItem, 0)
+	failedItems := make([]*failedBulkItem, 0)
+	for idx, responseItem := range bulkResponse.Items {
+		result := responseItem.Result()
+		if result.Status < http.StatusMultipleChoices {
+			continue
+		}
+		if result.Status == http.StatusNotFound && items[idx].action == actionDelete {
+			continue
+		}
+
+		failedItem := &failedBulkItem{
+			item:      items[idx],
+			status:    result.Status,
+			errorType: result.Error.Type,
+			reason:    result.Error.Reason,
+		}
+		if isRetryableStatus(result.Status) {
+			retryItems = append(retryItems, failedItem)
+			continue
+		}
+
+		failedItems = append(failedItems, failedItem)
+	}
+
+	return retryItems, failedItems, nil
+}
+
+// GetBulkStats returns the number of bulk requests sent by the client, the number of retried items and the number of
+// items written in the dead-letter file
+func (ec *esClient) GetBulkStats() *data.BulkStats {
+	return &data.BulkStats{
+		NumRequests:          atomic.LoadUint64(&ec.numBulkRequests),
+		NumRetriedItems:      atomic.LoadUint64(&ec.numBulkRetriedItems),
+		NumDeadLetteredItems: atomic.LoadUint64(&ec.numBulkDeadLetteredItems),
+	}
+}
+
+func failedItemsError(failedItems []*failedBulkItem) error {
+	errorsString := ""
+	for idx, failedItem := range failedItems {
+		if idx == numOfErrorsToExtractBulkResponse {
+			break
+		}
+
+		errorsString += fmt.Sprintf("{ id: %s, status code: %d, error type: %s, reason: %s }\n",
+			failedItem.item.id, failedItem.status, failedItem.errorType, failedItem.reason)
+	}
+
+	log.Warn("DoBulkRequest", "num failed items", len(failedItems), "error", errorsString)
+	return fmt.Errorf("%w: %d items, %s", errBulkItemsFailed, len(failedItems), errorsString)
+}
@@ -0,0 +1,195 @@
+package elasticClient
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+const bulkBody = `{ "index" : { "_id" : "erd1a" } }
+{"balance":"1"}
+{ "update" : { "_id" : "erd1b" } }
+{ "doc" : {"rankStake":1} }
+{ "create" : { "_id" : "erd1c" } }
+{"balance":"3"}
+`
+
+// createBulkServer returns a server that answers the bulk requests with the statuses returned by statusHandler for
+// every item, based on the number of times the item was received
+func createBulkServer(t *testing.T, statusHandler func(id string, attempt int) int) (*httptest.Server, map[string]int) {
+	mut := sync.Mutex{}
+	attempts := make(map[string]int)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.True(t, strings.HasSuffix(r.URL.Path, "/_bulk"))
+		body, err := ioutil.ReadAll(r.Body)
+		require.Nil(t, err)
+
+		items, err := parseBulkItems(body)
+		require.Nil(t, err)
+
+		response := &data.BulkRequestResponse{}
+		mut.Lock()
+		for _, item := range items {
+			status := statusHandler(item.id, attempts[item.id])
+			attempts[item.id]++
+
+			result := &data.BulkActionResponse{ID: item.id, Status: status}
+			if status >= http.StatusMultipleChoices {
+				response.Errors = true
+				result.Error.Type = "error_type"
+				result.Error.Reason = fmt.Sprintf("status %d", status)
+			}
+
+			responseItem := &data.BulkRequestResponseItem{}
+			switch item.action {
+			case "index":
+				responseItem.Index = result
+			case "update":
+				responseItem.Update = result
+			case "create":
+				responseItem.Create = result
+			}
+			response.Items = append(response.Items, responseItem)
+		}
+		mut.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, attempts
+}
+
+func createClient(t *testing.T, address string, deadLetterFile string) *esClient {
+	client, err := NewElasticClient(data.EsClientConfig{
+		Address:              address,
+		BulkMaxRetries:       2,
+		BulkRetryBackoffInMs: 1,
+		DeadLetterFile:       deadLetterFile,
+	})
+	require.Nil(t, err)
+
+	return client
+}
+
+func readDeadLetterFile(t *testing.T, path string) []*deadLetterEntry {
+	file, err := os.Open(path)
+	require.Nil(t, err)
+	defer func() {
+		_ = file.Close()
+	}()
+
+	entries := make([]*deadLetterEntry, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry := &deadLetterEntry{}
+		require.Nil(t, json.Unmarshal(scanner.Bytes(), entry))
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func TestParseBulkItems(t *testing.T) {
+	t.Parallel()
+
+	items, err := parseBulkItems([]byte(bulkBody + `{ "delete" : { "_id" : "erd1d" } }` + "\n"))
+	require.Nil(t, err)
+	require.Len(t, items, 4)
+	require.Equal(t, "update", items[1].action)
+	require.Equal(t, "erd1b", items[1].id)
+	require.Equal(t, `{ "doc" : {"rankStake":1} }`, string(items[1].source))
+	require.Equal(t, "delete", items[3].action)
+	require.Nil(t, items[3].source)
+
+	require.Equal(t, bulkBody, serializeBulkItems(items[:3]).String())
+
+	_, err = parseBulkItems([]byte(`{ "index" : { "_id" : "erd1a" } }`))
+	require.True(t, errors.Is(err, errInvalidBulkBody))
+}
+
+func TestEsClient_DoBulkRequestRetriesRejectedItems(t *testing.T) {
+	t.Parallel()
+
+	server, attempts := createBulkServer(t, func(id string, attempt int) int {
+		if id == "erd1b" && attempt < 2 {
+			return http.StatusTooManyRequests
+		}
+		return http.StatusOK
+	})
+	client := createClient(t, server.URL, "")
+
+	err := client.DoBulkRequest(bytes.NewBufferString(bulkBody), "accounts")
+	require.Nil(t, err)
+	require.Equal(t, map[string]int{"erd1a": 1, "erd1b": 3, "erd1c": 1}, attempts)
+	require.Equal(t, &data.BulkStats{NumRequests: 1, NumRetriedItems: 2}, client.GetBulkStats())
+}
+
+func TestEsClient_DoBulkRequestFailedItemsWithoutDeadLetter(t *testing.T) {
+	t.Parallel()
+
+	server, _ := createBulkServer(t, func(id string, _ int) int {
+		if id == "erd1c" {
+			return http.StatusBadRequest
+		}
+		return http.StatusCreated
+	})
+	client := createClient(t, server.URL, "")
+
+	err := client.DoBulkRequest(bytes.NewBufferString(bulkBody), "accounts")
+	require.True(t, errors.Is(err, errBulkItemsFailed))
+	require.True(t, strings.Contains(err.Error(), "erd1c"))
+}
+
+func TestEsClient_DoBulkRequestWritesDeadLetter(t *testing.T) {
+	t.Parallel()
+
+	server, attempts := createBulkServer(t, func(id string, _ int) int {
+		switch id {
+		case "erd1a":
+			return http.StatusBadRequest
+		case "erd1b":
+			return http.StatusServiceUnavailable
+		default:
+			return http.StatusCreated
+		}
+	})
+	deadLetterPath := filepath.Join(t.TempDir(), "dead-letter.ndjson")
+	client := createClient(t, server.URL, deadLetterPath)
+
+	err := client.DoBulkRequest(bytes.NewBufferString(bulkBody), "accounts")
+	require.Nil(t, err)
+	require.Equal(t, map[string]int{"erd1a": 1, "erd1b": 3, "erd1c": 1}, attempts)
+
+	require.Equal(t, &data.BulkStats{NumRequests: 1, NumRetriedItems: 2, NumDeadLetteredItems: 2}, client.GetBulkStats())
+
+	entries := readDeadLetterFile(t, deadLetterPath)
+	require.Len(t, entries, 2)
+	require.Equal(t, "erd1a", entries[0].ID)
+	require.Equal(t, "index", entries[0].Action)
+	require.Equal(t, http.StatusBadRequest, entries[0].Status)
+	require.Equal(t, `{"balance":"1"}`, string(entries[0].Document))
+	require.Equal(t, "accounts", entries[0].Index)
+
+	require.Equal(t, "erd1b", entries[1].ID)
+	require.Equal(t, "update", entries[1].Action)
+	require.Equal(t, http.StatusServiceUnavailable, entries[1].Status)
+	require.True(t, strings.HasPrefix(entries[1].Reason, "retries exhausted after 3 attempts"))
+	require.Equal(t, int64(1), gjson.GetBytes(entries[1].Document, "doc.rankStake").Int())
+}
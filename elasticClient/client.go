@@ -8,6 +8,8 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/elastic/go-elasticsearch/v7"
@@ -19,6 +21,7 @@ import (
 
 const (
 	numOfErrorsToExtractBulkResponse = 5
+	defaultScrollPageSize            = 9000
 
 	errPolicyAlreadyExists = "document already exists"
 )
@@ -26,56 +29,55 @@ const (
 var log = logger.GetOrCreate("elasticClient")
 
 type esClient struct {
-	client      *elasticsearch.Client
-	countScroll int
+	client           *elasticsearch.Client
+	countScroll      uint64
+	scrollPageSize   int
+	bulkMaxRetries   int
+	bulkRetryBackoff time.Duration
+	deadLetter       *deadLetterFile
+
+	numBulkRequests          uint64
+	numBulkRetriedItems      uint64
+	numBulkDeadLetteredItems uint64
+
+	mutClusterInfo sync.Mutex
+	clusterInfo    *ClusterInfo
 }
 
 // NewElasticClient will create a new instance of an esClient
 func NewElasticClient(cfg data.EsClientConfig) (*esClient, error) {
-	elasticClient, err := elasticsearch.NewClient(unWrapEsConfig(cfg))
+	esConfig, err := unWrapEsConfig(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	return &esClient{
-		client:      elasticClient,
-		countScroll: 0,
-	}, nil
-}
-
-// DoBulkRequest will do a bulk of request to elastic server
-func (ec *esClient) DoBulkRequest(buff *bytes.Buffer, index string) error {
-	reader := bytes.NewReader(buff.Bytes())
-
-	res, err := ec.client.Bulk(
-		reader,
-		ec.client.Bulk.WithIndex(index),
-	)
+	elasticClient, err := elasticsearch.NewClient(esConfig)
 	if err != nil {
-		return err
-	}
-	if res.IsError() {
-		return fmt.Errorf("error DoBulkRequest: %s", res.String())
+		return nil, err
 	}
 
-	defer closeBody(res)
-
-	bodyBytes, errRead := ioutil.ReadAll(res.Body)
-	if errRead != nil {
-		return errRead
+	scrollPageSize := cfg.ScrollPageSize
+	if scrollPageSize <= 0 {
+		scrollPageSize = defaultScrollPageSize
 	}
 
-	bulkResponse := &data.BulkRequestResponse{}
-	err = json.Unmarshal(bodyBytes, bulkResponse)
-	if err != nil {
-		return err
+	bulkMaxRetries := cfg.BulkMaxRetries
+	if bulkMaxRetries <= 0 {
+		bulkMaxRetries = defaultBulkMaxRetries
 	}
-
-	if bulkResponse.Errors {
-		return extractErrorFromBulkResponse(bulkResponse)
+	bulkRetryBackoffInMs := cfg.BulkRetryBackoffInMs
+	if bulkRetryBackoffInMs <= 0 {
+		bulkRetryBackoffInMs = defaultBulkRetryBackoffInMs
 	}
 
-	return nil
+	return &esClient{
+		client:           elasticClient,
+		countScroll:      0,
+		scrollPageSize:   scrollPageSize,
+		bulkMaxRetries:   bulkMaxRetries,
+		bulkRetryBackoff: time.Duration(bulkRetryBackoffInMs) * time.Millisecond,
+		deadLetter:       newDeadLetterFile(cfg.DeadLetterFile),
+	}, nil
 }
 
 // DoRequest will do a index request to Elasticsearch
@@ -115,6 +117,119 @@ func (ec *esClient) CheckIfIndexExists(index string) (bool, error) {
 	return exists(res), nil
 }
 
+// GetMapping returns the mappings of the indices matching the provided index pattern, keyed by the name of the index
+func (ec *esClient) GetMapping(index string) (map[string][]byte, error) {
+	res, err := ec.client.Indices.GetMapping(
+		ec.client.Indices.GetMapping.WithIndex(index),
+		ec.client.Indices.GetMapping.WithAllowNoIndices(true),
+		ec.client.Indices.GetMapping.WithIgnoreUnavailable(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	bodyBytes, err := getBytesFromResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings := make(map[string][]byte)
+	gjson.ParseBytes(bodyBytes).ForEach(func(key, value gjson.Result) bool {
+		mappings[key.String()] = []byte(value.Get("mappings").Raw)
+		return true
+	})
+
+	return mappings, nil
+}
+
+// GetIndices returns the name, the creation date and the aliases of the indices matching the provided index pattern
+func (ec *esClient) GetIndices(index string) ([]*data.IndexInfo, error) {
+	res, err := ec.client.Indices.Get(
+		[]string{index},
+		ec.client.Indices.Get.WithAllowNoIndices(true),
+		ec.client.Indices.Get.WithIgnoreUnavailable(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	bodyBytes, err := getBytesFromResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseIndicesInfo(bodyBytes), nil
+}
+
+func parseIndicesInfo(body []byte) []*data.IndexInfo {
+	indices := make([]*data.IndexInfo, 0)
+	gjson.ParseBytes(body).ForEach(func(key, value gjson.Result) bool {
+		aliases := make([]string, 0)
+		value.Get("aliases").ForEach(func(alias, _ gjson.Result) bool {
+			aliases = append(aliases, alias.String())
+			return true
+		})
+
+		indices = append(indices, &data.IndexInfo{
+			Name:         key.String(),
+			CreationDate: time.UnixMilli(value.Get("settings.index.creation_date").Int()),
+			Aliases:      aliases,
+		})
+		return true
+	})
+
+	return indices
+}
+
+// DeleteIndices will delete the provided indices
+func (ec *esClient) DeleteIndices(indices []string) error {
+	if len(indices) == 0 {
+		return nil
+	}
+
+	res, err := ec.client.Indices.Delete(indices)
+	if err != nil {
+		return err
+	}
+	defer closeBody(res)
+
+	if res.IsError() {
+		return fmt.Errorf("error DeleteIndices: %s", res.String())
+	}
+
+	return nil
+}
+
+// RefreshIndex will make all the operations performed on the provided index visible to search
+func (ec *esClient) RefreshIndex(index string) error {
+	res, err := ec.client.Indices.Refresh(ec.client.Indices.Refresh.WithIndex(index))
+	if err != nil {
+		return err
+	}
+	defer closeBody(res)
+
+	if res.IsError() {
+		return fmt.Errorf("error RefreshIndex: %s", res.String())
+	}
+
+	return nil
+}
+
+// CountDocuments returns the number of documents of the provided index
+func (ec *esClient) CountDocuments(index string) (uint64, error) {
+	res, err := ec.client.Count(ec.client.Count.WithIndex(index))
+	if err != nil {
+		return 0, err
+	}
+
+	bodyBytes, err := getBytesFromResponse(res)
+	if err != nil {
+		return 0, err
+	}
+
+	return gjson.GetBytes(bodyBytes, "count").Uint(), nil
+}
+
 func exists(res *esapi.Response) bool {
 	defer func() {
 		if res != nil && res.Body != nil {
@@ -180,8 +295,34 @@ func (ec *esClient) PutMapping(targetIndex string, body *bytes.Buffer) error {
 	return nil
 }
 
-// CreateIndexWithMapping will init an index and put the template
+// CreateIndexWithMapping will init an index and put the template. On OpenSearch, the ILM policy from the settings is
+// replaced by the ISM policy with the same name, which should already exist
 func (ec *esClient) CreateIndexWithMapping(index string, mapping *bytes.Buffer) error {
+	info, err := ec.GetClusterInfo()
+	if err != nil {
+		return err
+	}
+	if !info.IsOpenSearch() {
+		return ec.createIndex(index, mapping)
+	}
+
+	newMapping, policyName, err := extractLifecycleName(mapping.Bytes())
+	if err != nil {
+		return err
+	}
+
+	err = ec.createIndex(index, bytes.NewBuffer(newMapping))
+	if err != nil {
+		return err
+	}
+	if policyName == "" {
+		return nil
+	}
+
+	return ec.addISMPolicy(index, policyName)
+}
+
+func (ec *esClient) createIndex(index string, mapping *bytes.Buffer) error {
 	res, err := ec.client.Indices.Create(
 		index,
 		ec.client.Indices.Create.WithBody(mapping),
@@ -200,8 +341,17 @@ func (ec *esClient) CreateIndexWithMapping(index string, mapping *bytes.Buffer)
 	return nil
 }
 
-// PutPolicy will put in Elasticsearch cluster the provided policy with the given name
+// PutPolicy will put in Elasticsearch cluster the provided ILM policy with the given name. On OpenSearch, the policy is
+// translated into an ISM policy
 func (ec *esClient) PutPolicy(policyName string, policy *bytes.Buffer) error {
+	info, err := ec.GetClusterInfo()
+	if err != nil {
+		return err
+	}
+	if info.IsOpenSearch() {
+		return ec.putISMPolicy(policyName, policy.Bytes())
+	}
+
 	res, err := ec.client.ILM.PutLifecycle(
 		policyName,
 		ec.client.ILM.PutLifecycle.WithBody(policy),
@@ -229,16 +379,17 @@ func (ec *esClient) PutPolicy(policyName string, policy *bytes.Buffer) error {
 	return nil
 }
 
-// DoScrollRequestAllDocuments will perform a documents request using scroll api
+// DoScrollRequestAllDocuments will perform a documents request using scroll api. It can be called concurrently, for
+// example to read the slices of a sliced scroll in parallel
 func (ec *esClient) DoScrollRequestAllDocuments(
 	index string,
 	body []byte,
 	handlerFunc func(responseBytes []byte) error,
 ) error {
-	ec.countScroll++
+	countScroll := atomic.AddUint64(&ec.countScroll, 1)
 	res, err := ec.client.Search(
-		ec.client.Search.WithSize(9000),
-		ec.client.Search.WithScroll(2*time.Hour+time.Duration(ec.countScroll)*time.Millisecond),
+		ec.client.Search.WithSize(ec.scrollPageSize),
+		ec.client.Search.WithScroll(2*time.Hour+time.Duration(countScroll)*time.Millisecond),
 		ec.client.Search.WithContext(context.Background()),
 		ec.client.Search.WithIndex(index),
 		ec.client.Search.WithBody(bytes.NewBuffer(body)),
@@ -297,10 +448,10 @@ func (ec *esClient) iterateScroll(
 }
 
 func (ec *esClient) getScrollResponse(scrollID string) ([]byte, error) {
-	ec.countScroll++
+	countScroll := atomic.AddUint64(&ec.countScroll, 1)
 	res, err := ec.client.Scroll(
 		ec.client.Scroll.WithScrollID(scrollID),
-		ec.client.Scroll.WithScroll(2*time.Minute+time.Duration(ec.countScroll)*time.Millisecond),
+		ec.client.Scroll.WithScroll(2*time.Minute+time.Duration(countScroll)*time.Millisecond),
 	)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,63 @@
+package elasticClient
+
+import (
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+const (
+	// FlavourElasticsearch is the flavour of an Elasticsearch cluster
+	FlavourElasticsearch = "elasticsearch"
+	// FlavourOpenSearch is the flavour of an OpenSearch cluster
+	FlavourOpenSearch = "opensearch"
+)
+
+// ClusterInfo holds the flavour and the version of the cluster behind a client
+type ClusterInfo struct {
+	Flavour string
+	Version string
+}
+
+// IsOpenSearch returns true if the cluster is an OpenSearch cluster
+func (ci *ClusterInfo) IsOpenSearch() bool {
+	return ci.Flavour == FlavourOpenSearch
+}
+
+func parseClusterInfo(infoResponse []byte) *ClusterInfo {
+	version := gjson.GetBytes(infoResponse, "version.number").String()
+	flavour := FlavourElasticsearch
+	if strings.EqualFold(gjson.GetBytes(infoResponse, "version.distribution").String(), FlavourOpenSearch) {
+		flavour = FlavourOpenSearch
+	}
+
+	return &ClusterInfo{
+		Flavour: flavour,
+		Version: version,
+	}
+}
+
+// GetClusterInfo returns the flavour and the version of the cluster. The information is fetched only once
+func (ec *esClient) GetClusterInfo() (*ClusterInfo, error) {
+	ec.mutClusterInfo.Lock()
+	defer ec.mutClusterInfo.Unlock()
+
+	if ec.clusterInfo != nil {
+		return ec.clusterInfo, nil
+	}
+
+	res, err := ec.client.Info()
+	if err != nil {
+		return nil, err
+	}
+
+	bodyBytes, err := getBytesFromResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	ec.clusterInfo = parseClusterInfo(bodyBytes)
+	log.Info("detected cluster", "flavour", ec.clusterInfo.Flavour, "version", ec.clusterInfo.Version)
+
+	return ec.clusterInfo, nil
+}
@@ -1,8 +1,12 @@
 package elasticClient
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v7"
 	"github.com/elastic/go-elasticsearch/v7/esapi"
@@ -15,12 +19,86 @@ type responseCreatePolicy struct {
 	Status int         `json:"status"`
 }
 
-func unWrapEsConfig(wrappedConfig data.EsClientConfig) elasticsearch.Config {
+func unWrapEsConfig(wrappedConfig data.EsClientConfig) (elasticsearch.Config, error) {
+	transport, err := createTransport(wrappedConfig)
+	if err != nil {
+		return elasticsearch.Config{}, err
+	}
+
 	return elasticsearch.Config{
-		Addresses: []string{wrappedConfig.Address},
-		Username:  wrappedConfig.Username,
-		Password:  wrappedConfig.Password,
+		Addresses:             getAddresses(wrappedConfig),
+		Username:              wrappedConfig.Username,
+		Password:              wrappedConfig.Password,
+		CloudID:               wrappedConfig.CloudID,
+		APIKey:                wrappedConfig.APIKey,
+		DiscoverNodesOnStart:  wrappedConfig.DiscoverNodesOnStart,
+		DiscoverNodesInterval: time.Duration(wrappedConfig.DiscoverNodesIntervalInSec) * time.Second,
+		Transport:             transport,
+	}, nil
+}
+
+// getAddresses returns the configured nodes, none if a cloud ID is set, since the cloud ID is used instead of the
+// addresses and the client rejects a config holding both
+func getAddresses(wrappedConfig data.EsClientConfig) []string {
+	if wrappedConfig.CloudID != "" {
+		return nil
+	}
+
+	addresses := make([]string, 0, len(wrappedConfig.Addresses)+1)
+	if wrappedConfig.Address != "" {
+		addresses = append(addresses, wrappedConfig.Address)
+	}
+	for _, address := range wrappedConfig.Addresses {
+		if address != "" && address != wrappedConfig.Address {
+			addresses = append(addresses, address)
+		}
 	}
+
+	return addresses
+}
+
+// createTransport returns a transport with the configured TLS options or nil, so the default transport is used, if
+// no TLS option is set
+func createTransport(wrappedConfig data.EsClientConfig) (http.RoundTripper, error) {
+	hasClientCert := wrappedConfig.ClientCertFile != "" || wrappedConfig.ClientKeyFile != ""
+	if wrappedConfig.CACertFile == "" && !hasClientCert && !wrappedConfig.InsecureSkipVerify {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: wrappedConfig.InsecureSkipVerify,
+	}
+
+	if wrappedConfig.CACertFile != "" {
+		caCert, err := ioutil.ReadFile(wrappedConfig.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the CA certificate file: %w", err)
+		}
+
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("%w in %s", errInvalidCACert, wrappedConfig.CACertFile)
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	if hasClientCert {
+		if wrappedConfig.ClientCertFile == "" || wrappedConfig.ClientKeyFile == "" {
+			return nil, errIncompleteClientCert
+		}
+
+		clientCert, err := tls.LoadX509KeyPair(wrappedConfig.ClientCertFile, wrappedConfig.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load the client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
 }
 
 func closeBody(res *esapi.Response) {
@@ -42,23 +120,3 @@ func getBytesFromResponse(res *esapi.Response) ([]byte, error) {
 
 	return bodyBytes, nil
 }
-
-func extractErrorFromBulkResponse(response *data.BulkRequestResponse) error {
-	count := 0
-	errorsString := ""
-	for _, item := range response.Items {
-		if item.Index.Status < 300 {
-			continue
-		}
-
-		count++
-		errorsString += fmt.Sprintf("{ status code: %d, error type: %s, reason: %s }\n", item.Index.Status, item.Index.Error.Type, item.Index.Error.Reason)
-
-		if count == numOfErrorsToExtractBulkResponse {
-			break
-		}
-	}
-
-	log.Warn("extractErrorFromBulkResponse", "error", errorsString)
-	return fmt.Errorf("%s", errorsString)
-}
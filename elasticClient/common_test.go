@@ -0,0 +1,142 @@
+package elasticClient
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func createAuthCheckServer(t *testing.T, tlsServer bool, expectedAuthorization string) *httptest.Server {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != expectedAuthorization {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	var server *httptest.Server
+	if tlsServer {
+		server = httptest.NewTLSServer(handler)
+	} else {
+		server = httptest.NewServer(handler)
+	}
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func writeServerCACert(t *testing.T, server *httptest.Server) string {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.Nil(t, os.WriteFile(path, certPEM, 0600))
+
+	return path
+}
+
+func TestUnWrapEsConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("basic auth with multiple addresses", func(t *testing.T) {
+		t.Parallel()
+
+		esConfig, err := unWrapEsConfig(data.EsClientConfig{
+			Address:   "http://127.0.0.1:9200",
+			Addresses: []string{"http://127.0.0.1:9200", "http://127.0.0.1:9201"},
+			Username:  "user",
+			Password:  "pass",
+		})
+		require.Nil(t, err)
+		require.Equal(t, []string{"http://127.0.0.1:9200", "http://127.0.0.1:9201"}, esConfig.Addresses)
+		require.Equal(t, "user", esConfig.Username)
+		require.Nil(t, esConfig.Transport)
+	})
+
+	t.Run("cloud ID replaces the addresses", func(t *testing.T) {
+		t.Parallel()
+
+		cloudID := "deployment:" + base64.StdEncoding.EncodeToString([]byte("cloud.example.com$es-uuid$kibana-uuid"))
+		esConfig, err := unWrapEsConfig(data.EsClientConfig{
+			Address:   "http://127.0.0.1:9200",
+			Addresses: []string{"http://127.0.0.1:9201"},
+			CloudID:   cloudID,
+		})
+		require.Nil(t, err)
+		require.Empty(t, esConfig.Addresses)
+		require.Equal(t, cloudID, esConfig.CloudID)
+
+		_, err = elasticsearch.NewClient(esConfig)
+		require.Nil(t, err)
+	})
+
+	t.Run("invalid CA certificate", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "ca.pem")
+		require.Nil(t, os.WriteFile(path, []byte("not a certificate"), 0600))
+
+		_, err := unWrapEsConfig(data.EsClientConfig{CACertFile: path})
+		require.True(t, errors.Is(err, errInvalidCACert))
+	})
+
+	t.Run("client certificate without key", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := unWrapEsConfig(data.EsClientConfig{ClientCertFile: "cert.pem"})
+		require.Equal(t, errIncompleteClientCert, err)
+	})
+}
+
+func TestNewElasticClient_Authentication(t *testing.T) {
+	t.Parallel()
+
+	t.Run("basic auth", func(t *testing.T) {
+		t.Parallel()
+
+		server := createAuthCheckServer(t, false, "Basic dXNlcjpwYXNz")
+		client, err := NewElasticClient(data.EsClientConfig{Address: server.URL, Username: "user", Password: "pass"})
+		require.Nil(t, err)
+
+		exists, err := client.CheckIfIndexExists("accounts")
+		require.Nil(t, err)
+		require.True(t, exists)
+	})
+
+	t.Run("api key over TLS with CA certificate", func(t *testing.T) {
+		t.Parallel()
+
+		server := createAuthCheckServer(t, true, "APIKey a2V5")
+		client, err := NewElasticClient(data.EsClientConfig{
+			Address:    server.URL,
+			Username:   "ignored",
+			Password:   "ignored",
+			APIKey:     "a2V5",
+			CACertFile: writeServerCACert(t, server),
+		})
+		require.Nil(t, err)
+
+		exists, err := client.CheckIfIndexExists("accounts")
+		require.Nil(t, err)
+		require.True(t, exists)
+	})
+
+	t.Run("TLS without the CA certificate fails", func(t *testing.T) {
+		t.Parallel()
+
+		server := createAuthCheckServer(t, true, "")
+		client, err := NewElasticClient(data.EsClientConfig{Address: server.URL})
+		require.Nil(t, err)
+
+		_, err = client.CheckIfIndexExists("accounts")
+		require.NotNil(t, err)
+	})
+}
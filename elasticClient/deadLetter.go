@@ -0,0 +1,72 @@
+package elasticClient
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+type deadLetterEntry struct {
+	Index     string          `json:"index"`
+	ID        string          `json:"id"`
+	Action    string          `json:"action"`
+	Status    int             `json:"status"`
+	ErrorType string          `json:"errorType,omitempty"`
+	Reason    string          `json:"reason,omitempty"`
+	Timestamp int64           `json:"timestamp"`
+	Document  json.RawMessage `json:"document,omitempty"`
+}
+
+// deadLetterFile appends the documents that cannot be indexed in a NDJSON file, one document per line
+type deadLetterFile struct {
+	mut  sync.Mutex
+	path string
+}
+
+func newDeadLetterFile(path string) *deadLetterFile {
+	if path == "" {
+		return nil
+	}
+
+	return &deadLetterFile{
+		path: path,
+	}
+}
+
+func (dlf *deadLetterFile) write(index string, failedItems []*failedBulkItem) error {
+	dlf.mut.Lock()
+	defer dlf.mut.Unlock()
+
+	file, err := os.OpenFile(dlf.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	timestamp := time.Now().Unix()
+	encoder := json.NewEncoder(file)
+	for _, failedItem := range failedItems {
+		entry := &deadLetterEntry{
+			Index:     index,
+			ID:        failedItem.item.id,
+			Action:    failedItem.item.action,
+			Status:    failedItem.status,
+			ErrorType: failedItem.errorType,
+			Reason:    failedItem.reason,
+			Timestamp: timestamp,
+		}
+		if json.Valid(failedItem.item.source) {
+			entry.Document = failedItem.item.source
+		}
+
+		err = encoder.Encode(entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	return file.Sync()
+}
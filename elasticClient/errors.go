@@ -0,0 +1,15 @@
+package elasticClient
+
+import "errors"
+
+var errInvalidBulkBody = errors.New("invalid bulk request body")
+
+var errBulkResponseMismatch = errors.New("the bulk response does not match the request")
+
+var errBulkItemsFailed = errors.New("bulk request with failed items")
+
+var errInvalidCACert = errors.New("no valid certificate found")
+
+var errIncompleteClientCert = errors.New("both the client certificate and the client key files should be provided")
+
+var errInvalidPolicy = errors.New("invalid lifecycle policy")
@@ -0,0 +1,299 @@
+package elasticClient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/tidwall/gjson"
+)
+
+const (
+	ismPoliciesPath  = "/_plugins/_ism/policies/"
+	ismAddPolicyPath = "/_plugins/_ism/add/"
+
+	lifecycleNameSetting = "index.lifecycle.name"
+)
+
+// ilmPhases are the ILM phases, in the order in which they are executed
+var ilmPhases = []string{"hot", "warm", "cold", "frozen", "delete"}
+
+// ilmActions are the ILM actions that have an ISM equivalent, in the order in which ILM executes them
+var ilmActions = []string{"set_priority", "rollover", "readonly", "allocate", "forcemerge", "delete"}
+
+type ismState struct {
+	Name        string                   `json:"name"`
+	Actions     []map[string]interface{} `json:"actions"`
+	Transitions []*ismTransition         `json:"transitions"`
+}
+
+type ismTransition struct {
+	StateName  string            `json:"state_name"`
+	Conditions map[string]string `json:"conditions,omitempty"`
+}
+
+type ismPolicy struct {
+	Description  string      `json:"description"`
+	DefaultState string      `json:"default_state"`
+	States       []*ismState `json:"states"`
+}
+
+// convertILMPolicyToISM will translate an ILM policy into an ISM policy. Every ILM phase becomes an ISM state and the
+// min_age of a phase becomes the condition of the transition from the previous state. The ILM actions without an ISM
+// equivalent are skipped
+func convertILMPolicyToISM(policyName string, ilmPolicy []byte) ([]byte, error) {
+	phases := gjson.GetBytes(ilmPolicy, "policy.phases")
+	if !phases.IsObject() {
+		return nil, fmt.Errorf("%w: missing policy.phases", errInvalidPolicy)
+	}
+
+	policy := &ismPolicy{
+		Description: fmt.Sprintf("%s, converted from ILM", policyName),
+		States:      make([]*ismState, 0),
+	}
+	for _, phaseName := range ilmPhases {
+		phase := phases.Get(phaseName)
+		if !phase.Exists() {
+			continue
+		}
+
+		state := &ismState{
+			Name:        phaseName,
+			Actions:     convertILMActions(phaseName, phase.Get("actions")),
+			Transitions: make([]*ismTransition, 0),
+		}
+
+		if len(policy.States) > 0 {
+			previousState := policy.States[len(policy.States)-1]
+			transition := &ismTransition{StateName: phaseName}
+			minAge := phase.Get("min_age").String()
+			if !isZeroAge(minAge) {
+				transition.Conditions = map[string]string{"min_index_age": minAge}
+			}
+			previousState.Transitions = append(previousState.Transitions, transition)
+		}
+
+		policy.States = append(policy.States, state)
+	}
+	if len(policy.States) == 0 {
+		return nil, fmt.Errorf("%w: no phase", errInvalidPolicy)
+	}
+	policy.DefaultState = policy.States[0].Name
+
+	return json.Marshal(map[string]interface{}{"policy": policy})
+}
+
+func convertILMActions(phaseName string, actions gjson.Result) []map[string]interface{} {
+	ismActions := make([]map[string]interface{}, 0)
+	actions.ForEach(func(key, _ gjson.Result) bool {
+		if !isKnownILMAction(key.String()) {
+			log.Warn("ILM action without ISM equivalent is skipped", "phase", phaseName, "action", key.String())
+		}
+		return true
+	})
+
+	for _, actionName := range ilmActions {
+		action := actions.Get(actionName)
+		if !action.Exists() {
+			continue
+		}
+
+		switch actionName {
+		case "set_priority":
+			ismActions = append(ismActions, ismAction("index_priority", map[string]interface{}{
+				"priority": action.Get("priority").Int(),
+			}))
+		case "rollover":
+			conditions := make(map[string]interface{})
+			copyCondition(action, "max_size", conditions, "min_size")
+			copyCondition(action, "max_primary_shard_size", conditions, "min_primary_shard_size")
+			copyCondition(action, "max_docs", conditions, "min_doc_count")
+			copyCondition(action, "max_age", conditions, "min_index_age")
+			ismActions = append(ismActions, ismAction("rollover", conditions))
+		case "readonly":
+			ismActions = append(ismActions, ismAction("read_only", map[string]interface{}{}))
+		case "allocate":
+			if action.Get("number_of_replicas").Exists() {
+				ismActions = append(ismActions, ismAction("replica_count", map[string]interface{}{
+					"number_of_replicas": action.Get("number_of_replicas").Int(),
+				}))
+			}
+		case "forcemerge":
+			ismActions = append(ismActions, ismAction("force_merge", map[string]interface{}{
+				"max_num_segments": action.Get("max_num_segments").Int(),
+			}))
+		case "delete":
+			ismActions = append(ismActions, ismAction("delete", map[string]interface{}{}))
+		}
+	}
+
+	return ismActions
+}
+
+func ismAction(name string, value map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{name: value}
+}
+
+func copyCondition(action gjson.Result, ilmName string, conditions map[string]interface{}, ismName string) {
+	value := action.Get(ilmName)
+	if value.Exists() {
+		conditions[ismName] = value.Value()
+	}
+}
+
+func isKnownILMAction(actionName string) bool {
+	for _, knownAction := range ilmActions {
+		if knownAction == actionName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isZeroAge(age string) bool {
+	switch age {
+	case "", "0", "0ms", "0s", "0m", "0h", "0d":
+		return true
+	default:
+		return false
+	}
+}
+
+// extractLifecycleName will remove the ILM policy from the settings of an index, because OpenSearch rejects the
+// unknown setting, and will return the name of the removed policy
+func extractLifecycleName(mapping []byte) ([]byte, string, error) {
+	if len(mapping) == 0 {
+		return mapping, "", nil
+	}
+
+	body := make(map[string]interface{})
+	err := json.Unmarshal(mapping, &body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	settings, ok := body["settings"].(map[string]interface{})
+	if !ok {
+		return mapping, "", nil
+	}
+
+	policyName := ""
+	if name, found := settings[lifecycleNameSetting]; found {
+		policyName = fmt.Sprintf("%v", name)
+		delete(settings, lifecycleNameSetting)
+	}
+	if index, isMap := settings["index"].(map[string]interface{}); isMap {
+		if lifecycle, isLifecycleMap := index["lifecycle"].(map[string]interface{}); isLifecycleMap {
+			if name, found := lifecycle["name"]; found {
+				policyName = fmt.Sprintf("%v", name)
+			}
+			delete(index, "lifecycle")
+		}
+	}
+	if policyName == "" {
+		return mapping, "", nil
+	}
+
+	newMapping, err := json.Marshal(body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return newMapping, policyName, nil
+}
+
+func (ec *esClient) performRequest(method string, path string, body []byte) (int, []byte, error) {
+	req, err := http.NewRequest(method, path, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := ec.client.Perform(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	responseBytes, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return res.StatusCode, responseBytes, nil
+}
+
+// putISMPolicy will create or update an ISM policy
+func (ec *esClient) putISMPolicy(policyName string, ilmPolicy []byte) error {
+	policy, err := convertILMPolicyToISM(policyName, ilmPolicy)
+	if err != nil {
+		return err
+	}
+
+	policyPath := ismPoliciesPath + url.PathEscape(policyName)
+	status, responseBytes, err := ec.performRequest(http.MethodPut, policyPath, policy)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusConflict {
+		return checkISMResponse("putISMPolicy", status, responseBytes)
+	}
+
+	// the policy already exists and can be updated only with its current sequence number and primary term
+	status, responseBytes, err = ec.performRequest(http.MethodGet, policyPath, nil)
+	if err != nil {
+		return err
+	}
+	err = checkISMResponse("putISMPolicy", status, responseBytes)
+	if err != nil {
+		return err
+	}
+
+	updatePath := fmt.Sprintf("%s?if_seq_no=%d&if_primary_term=%d",
+		policyPath,
+		gjson.GetBytes(responseBytes, "_seq_no").Int(),
+		gjson.GetBytes(responseBytes, "_primary_term").Int(),
+	)
+	status, responseBytes, err = ec.performRequest(http.MethodPut, updatePath, policy)
+	if err != nil {
+		return err
+	}
+
+	return checkISMResponse("putISMPolicy", status, responseBytes)
+}
+
+// addISMPolicy will attach an ISM policy to an existing index
+func (ec *esClient) addISMPolicy(index string, policyName string) error {
+	body, err := json.Marshal(map[string]string{"policy_id": policyName})
+	if err != nil {
+		return err
+	}
+
+	status, responseBytes, err := ec.performRequest(http.MethodPost, ismAddPolicyPath+url.PathEscape(index), body)
+	if err != nil {
+		return err
+	}
+	err = checkISMResponse("addISMPolicy", status, responseBytes)
+	if err != nil {
+		return err
+	}
+	if gjson.GetBytes(responseBytes, "failures").Bool() {
+		return fmt.Errorf("error addISMPolicy: %s", responseBytes)
+	}
+
+	return nil
+}
+
+func checkISMResponse(operation string, status int, responseBytes []byte) error {
+	if status >= http.StatusMultipleChoices {
+		return fmt.Errorf("error %s: status %d, %s", operation, status, responseBytes)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package elasticClient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestParseClusterInfo(t *testing.T) {
+	t.Parallel()
+
+	info := parseClusterInfo([]byte(`{"version":{"distribution":"opensearch","number":"2.11.0"}}`))
+	require.True(t, info.IsOpenSearch())
+	require.Equal(t, "2.11.0", info.Version)
+
+	info = parseClusterInfo([]byte(`{"version":{"number":"7.17.9","build_flavor":"default"}}`))
+	require.False(t, info.IsOpenSearch())
+	require.Equal(t, FlavourElasticsearch, info.Flavour)
+	require.Equal(t, "7.17.9", info.Version)
+}
+
+func TestConvertILMPolicyToISM(t *testing.T) {
+	t.Parallel()
+
+	ilmPolicy := `{"policy":{"phases":{
+		"delete":{"min_age":"90d","actions":{"delete":{"delete_searchable_snapshot":true}}},
+		"warm":{"min_age":"7d","actions":{"forcemerge":{"max_num_segments":1},"shrink":{"number_of_shards":1},"readonly":{}}},
+		"hot":{"min_age":"0ms","actions":{"rollover":{"max_size":"50gb","max_age":"1d"},"set_priority":{"priority":100}}}
+	}}}`
+
+	ismPolicy, err := convertILMPolicyToISM("policy", []byte(ilmPolicy))
+	require.Nil(t, err)
+
+	policy := gjson.GetBytes(ismPolicy, "policy")
+	require.Equal(t, "hot", policy.Get("default_state").String())
+	require.Equal(t, []interface{}{"hot", "warm", "delete"}, policy.Get("states.#.name").Value())
+
+	hot := policy.Get("states.0")
+	require.Equal(t, int64(100), hot.Get("actions.0.index_priority.priority").Int())
+	require.Equal(t, "50gb", hot.Get("actions.1.rollover.min_size").String())
+	require.Equal(t, "1d", hot.Get("actions.1.rollover.min_index_age").String())
+	require.Equal(t, "warm", hot.Get("transitions.0.state_name").String())
+	require.Equal(t, "7d", hot.Get("transitions.0.conditions.min_index_age").String())
+
+	warm := policy.Get("states.1")
+	require.Equal(t, int64(2), warm.Get("actions.#").Int())
+	require.True(t, warm.Get("actions.0.read_only").Exists())
+	require.Equal(t, int64(1), warm.Get("actions.1.force_merge.max_num_segments").Int())
+
+	deleteState := policy.Get("states.2")
+	require.True(t, deleteState.Get("actions.0.delete").Exists())
+	require.Equal(t, int64(0), deleteState.Get("transitions.#").Int())
+
+	_, err = convertILMPolicyToISM("policy", []byte(`{"policy":{}}`))
+	require.True(t, errors.Is(err, errInvalidPolicy))
+}
+
+func TestExtractLifecycleName(t *testing.T) {
+	t.Parallel()
+
+	mapping, policyName, err := extractLifecycleName([]byte(`{"settings":{"index.lifecycle.name":"policy","number_of_shards":1}}`))
+	require.Nil(t, err)
+	require.Equal(t, "policy", policyName)
+	require.JSONEq(t, `{"settings":{"number_of_shards":1}}`, string(mapping))
+
+	mapping, policyName, err = extractLifecycleName([]byte(`{"settings":{"index":{"lifecycle":{"name":"policy"}}}}`))
+	require.Nil(t, err)
+	require.Equal(t, "policy", policyName)
+	require.JSONEq(t, `{"settings":{"index":{}}}`, string(mapping))
+
+	original := []byte(`{"settings":{"number_of_shards":1}}`)
+	mapping, policyName, err = extractLifecycleName(original)
+	require.Nil(t, err)
+	require.Empty(t, policyName)
+	require.Equal(t, original, mapping)
+}
@@ -0,0 +1,119 @@
+package elasticClient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/tidwall/gjson"
+)
+
+// PutIndexTemplate will create or replace the composable index template with the given name. On OpenSearch, the ILM
+// policy is removed from the settings of the template
+func (ec *esClient) PutIndexTemplate(name string, template *bytes.Buffer) error {
+	info, err := ec.GetClusterInfo()
+	if err != nil {
+		return err
+	}
+
+	body := template.Bytes()
+	if info.IsOpenSearch() {
+		body, err = removeLifecycleFromTemplate(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	res, err := ec.client.Indices.PutIndexTemplate(name, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer closeBody(res)
+
+	if res.IsError() {
+		return fmt.Errorf("error PutIndexTemplate: %s", res.String())
+	}
+
+	return nil
+}
+
+// PutComponentTemplate will create or replace the component template with the given name
+func (ec *esClient) PutComponentTemplate(name string, template *bytes.Buffer) error {
+	res, err := ec.client.Cluster.PutComponentTemplate(name, bytes.NewReader(template.Bytes()))
+	if err != nil {
+		return err
+	}
+	defer closeBody(res)
+
+	if res.IsError() {
+		return fmt.Errorf("error PutComponentTemplate: %s", res.String())
+	}
+
+	return nil
+}
+
+// GetIndexTemplate returns the body of the composable index template with the given name or nil if the template does
+// not exist
+func (ec *esClient) GetIndexTemplate(name string) ([]byte, error) {
+	res, err := ec.client.Indices.GetIndexTemplate(ec.client.Indices.GetIndexTemplate.WithName(name))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode == http.StatusNotFound {
+		closeBody(res)
+		return nil, nil
+	}
+
+	bodyBytes, err := getBytesFromResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(gjson.GetBytes(bodyBytes, "index_templates.0.index_template").Raw), nil
+}
+
+// GetComponentTemplate returns the body of the component template with the given name or nil if the template does not
+// exist
+func (ec *esClient) GetComponentTemplate(name string) ([]byte, error) {
+	res, err := ec.client.Cluster.GetComponentTemplate(ec.client.Cluster.GetComponentTemplate.WithName(name))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode == http.StatusNotFound {
+		closeBody(res)
+		return nil, nil
+	}
+
+	bodyBytes, err := getBytesFromResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(gjson.GetBytes(bodyBytes, "component_templates.0.component_template").Raw), nil
+}
+
+func removeLifecycleFromTemplate(body []byte) ([]byte, error) {
+	templateBody := make(map[string]json.RawMessage)
+	err := json.Unmarshal(body, &templateBody)
+	if err != nil {
+		return nil, err
+	}
+
+	innerTemplate, ok := templateBody["template"]
+	if !ok {
+		return body, nil
+	}
+
+	newInnerTemplate, policyName, err := extractLifecycleName(innerTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if policyName == "" {
+		return body, nil
+	}
+
+	templateBody["template"] = newInnerTemplate
+
+	return json.Marshal(templateBody)
+}
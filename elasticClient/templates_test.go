@@ -0,0 +1,28 @@
+package elasticClient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveLifecycleFromTemplate(t *testing.T) {
+	t.Parallel()
+
+	template, err := removeLifecycleFromTemplate([]byte(`{"index_patterns":["a-*"],"template":{"settings":{"index.lifecycle.name":"policy","number_of_shards":1}}}`))
+	require.Nil(t, err)
+	require.JSONEq(t, `{"index_patterns":["a-*"],"template":{"settings":{"number_of_shards":1}}}`, string(template))
+
+	original := []byte(`{"index_patterns":["a-*"],"template":{"settings":{"number_of_shards":1}}}`)
+	template, err = removeLifecycleFromTemplate(original)
+	require.Nil(t, err)
+	require.Equal(t, original, template)
+
+	original = []byte(`{"index_patterns":["a-*"]}`)
+	template, err = removeLifecycleFromTemplate(original)
+	require.Nil(t, err)
+	require.Equal(t, original, template)
+
+	_, err = removeLifecycleFromTemplate([]byte(`{`))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import "time"
+
+type disabledMetrics struct {
+}
+
+// NewDisabledMetrics will create a metrics handler that records nothing, used when the metrics are not exposed
+func NewDisabledMetrics() *disabledMetrics {
+	return &disabledMetrics{}
+}
+
+// ObserveSourceFetch does nothing
+func (dm *disabledMetrics) ObserveSourceFetch(_ string, _ int, _ time.Duration) {
+}
+
+// AddScrollPage does nothing
+func (dm *disabledMetrics) AddScrollPage() {
+}
+
+// AddDestinationBatch does nothing
+func (dm *disabledMetrics) AddDestinationBatch(_ string, _ error) {
+}
+
+// ObserveRESTRequest does nothing
+func (dm *disabledMetrics) ObserveRESTRequest(_ string, _ time.Duration, _ error) {
+}
+
+// ObserveRun does nothing
+func (dm *disabledMetrics) ObserveRun(_ uint32, _ time.Duration, _ error) {
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (dm *disabledMetrics) IsInterfaceNil() bool {
+	return dm == nil
+}
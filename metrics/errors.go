@@ -0,0 +1,9 @@
+package metrics
+
+import "errors"
+
+// ErrEmptyListenAddress signals that the listen address of the metrics server is empty
+var ErrEmptyListenAddress = errors.New("empty listen address")
+
+// ErrNilHandler signals that a nil metrics handler has been provided
+var ErrNilHandler = errors.New("nil metrics handler")
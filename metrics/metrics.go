@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	namespace = "accounts_manager"
+
+	labelSource      = "source"
+	labelDestination = "destination"
+	labelEndpoint    = "endpoint"
+	labelStatus      = "status"
+
+	statusSuccess = "success"
+	statusFailed  = "failed"
+)
+
+type prometheusMetrics struct {
+	registry            *prometheus.Registry
+	sourceFetchDuration *prometheus.GaugeVec
+	sourceAccounts      *prometheus.GaugeVec
+	scrollPages         prometheus.Counter
+	destinationBatches  *prometheus.CounterVec
+	destinationFailures *prometheus.CounterVec
+	restRequestDuration *prometheus.HistogramVec
+	restRequestErrors   *prometheus.CounterVec
+	lastSuccessfulEpoch prometheus.Gauge
+	lastRunDuration     prometheus.Gauge
+	runs                *prometheus.CounterVec
+}
+
+// NewPrometheusMetrics will create a new instance of prometheusMetrics, holding its collectors in its own registry
+func NewPrometheusMetrics() *prometheusMetrics {
+	pm := &prometheusMetrics{
+		registry: prometheus.NewRegistry(),
+		sourceFetchDuration: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "source_fetch_duration_seconds",
+			Help:      "The duration of the last fetch of the accounts of a stake source",
+		}, []string{labelSource}),
+		sourceAccounts: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "source_accounts",
+			Help:      "The number of accounts returned by the last fetch of a stake source",
+		}, []string{labelSource}),
+		scrollPages: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "scroll_pages_read_total",
+			Help:      "The number of pages read from the source accounts index",
+		}),
+		destinationBatches: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "destination_batches_total",
+			Help:      "The number of batches of accounts sent to a destination",
+		}, []string{labelDestination}),
+		destinationFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "destination_batches_failed_total",
+			Help:      "The number of batches of accounts a destination failed to write",
+		}, []string{labelDestination}),
+		restRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "rest_request_duration_seconds",
+			Help:      "The latency of the requests sent to the API",
+			Buckets:   prometheus.ExponentialBuckets(0.05, 2, 10),
+		}, []string{labelEndpoint}),
+		restRequestErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rest_request_errors_total",
+			Help:      "The number of failed requests sent to the API",
+		}, []string{labelEndpoint}),
+		lastSuccessfulEpoch: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "last_successful_epoch",
+			Help:      "The epoch of the last successful run",
+		}),
+		lastRunDuration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "last_run_duration_seconds",
+			Help:      "The duration of the last run",
+		}),
+		runs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "runs_total",
+			Help:      "The number of runs, by status",
+		}, []string{labelStatus}),
+	}
+
+	pm.registry.MustRegister(
+		pm.sourceFetchDuration,
+		pm.sourceAccounts,
+		pm.scrollPages,
+		pm.destinationBatches,
+		pm.destinationFailures,
+		pm.restRequestDuration,
+		pm.restRequestErrors,
+		pm.lastSuccessfulEpoch,
+		pm.lastRunDuration,
+		pm.runs,
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+	)
+
+	return pm
+}
+
+// ObserveSourceFetch will record the number of accounts and the duration of the fetch of a stake source
+func (pm *prometheusMetrics) ObserveSourceFetch(source string, numAccounts int, duration time.Duration) {
+	pm.sourceFetchDuration.WithLabelValues(source).Set(duration.Seconds())
+	pm.sourceAccounts.WithLabelValues(source).Set(float64(numAccounts))
+}
+
+// AddScrollPage will count a page read from the source accounts index
+func (pm *prometheusMetrics) AddScrollPage() {
+	pm.scrollPages.Inc()
+}
+
+// AddDestinationBatch will count a batch sent to the provided destination, as failed if the error is not nil
+func (pm *prometheusMetrics) AddDestinationBatch(destination string, err error) {
+	pm.destinationBatches.WithLabelValues(destination).Inc()
+	if err != nil {
+		pm.destinationFailures.WithLabelValues(destination).Inc()
+	}
+}
+
+// ObserveRESTRequest will record the latency of a request sent to the provided endpoint, counting it as failed if the
+// error is not nil
+func (pm *prometheusMetrics) ObserveRESTRequest(endpoint string, duration time.Duration, err error) {
+	pm.restRequestDuration.WithLabelValues(endpoint).Observe(duration.Seconds())
+	if err != nil {
+		pm.restRequestErrors.WithLabelValues(endpoint).Inc()
+	}
+}
+
+// ObserveRun will record the duration and the status of a run. The epoch is recorded only for a successful run
+func (pm *prometheusMetrics) ObserveRun(epoch uint32, duration time.Duration, err error) {
+	pm.lastRunDuration.Set(duration.Seconds())
+	if err != nil {
+		pm.runs.WithLabelValues(statusFailed).Inc()
+		return
+	}
+
+	pm.runs.WithLabelValues(statusSuccess).Inc()
+	pm.lastSuccessfulEpoch.Set(float64(epoch))
+}
+
+// Handler returns the handler that exposes the metrics in the Prometheus text format
+func (pm *prometheusMetrics) Handler() http.Handler {
+	return promhttp.HandlerFor(pm.registry, promhttp.HandlerOpts{})
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (pm *prometheusMetrics) IsInterfaceNil() bool {
+	return pm == nil
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusMetrics_Observe(t *testing.T) {
+	t.Parallel()
+
+	pm := NewPrometheusMetrics()
+	require.False(t, pm.IsInterfaceNil())
+
+	pm.ObserveSourceFetch("validators", 10, 2*time.Second)
+	require.Equal(t, float64(10), testutil.ToFloat64(pm.sourceAccounts.WithLabelValues("validators")))
+	require.Equal(t, float64(2), testutil.ToFloat64(pm.sourceFetchDuration.WithLabelValues("validators")))
+
+	pm.AddScrollPage()
+	pm.AddScrollPage()
+	require.Equal(t, float64(2), testutil.ToFloat64(pm.scrollPages))
+
+	pm.AddDestinationBatch("file", nil)
+	pm.AddDestinationBatch("file", errors.New("disk full"))
+	require.Equal(t, float64(2), testutil.ToFloat64(pm.destinationBatches.WithLabelValues("file")))
+	require.Equal(t, float64(1), testutil.ToFloat64(pm.destinationFailures.WithLabelValues("file")))
+
+	pm.ObserveRESTRequest("/network/status", time.Millisecond, errors.New("timeout"))
+	require.Equal(t, 1, testutil.CollectAndCount(pm.restRequestDuration))
+	require.Equal(t, float64(1), testutil.ToFloat64(pm.restRequestErrors.WithLabelValues("/network/status")))
+
+	pm.ObserveRun(5, time.Minute, nil)
+	pm.ObserveRun(6, time.Second, errors.New("bulk request failed"))
+	require.Equal(t, float64(5), testutil.ToFloat64(pm.lastSuccessfulEpoch))
+	require.Equal(t, float64(1), testutil.ToFloat64(pm.lastRunDuration))
+	require.Equal(t, float64(1), testutil.ToFloat64(pm.runs.WithLabelValues(statusSuccess)))
+	require.Equal(t, float64(1), testutil.ToFloat64(pm.runs.WithLabelValues(statusFailed)))
+}
+
+func TestPrometheusMetrics_Handler(t *testing.T) {
+	t.Parallel()
+
+	pm := NewPrometheusMetrics()
+	pm.ObserveRun(5, time.Minute, nil)
+
+	recorder := httptest.NewRecorder()
+	pm.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, metricsPath, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "accounts_manager_last_successful_epoch 5")
+}
+
+func TestMetricsServer(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewMetricsServer(ArgsMetricsServer{Handler: http.NotFoundHandler()})
+	require.Equal(t, ErrEmptyListenAddress, err)
+
+	_, err = NewMetricsServer(ArgsMetricsServer{ListenAddress: "127.0.0.1:0"})
+	require.Equal(t, ErrNilHandler, err)
+
+	pm := NewPrometheusMetrics()
+	pm.AddScrollPage()
+	server, err := NewMetricsServer(ArgsMetricsServer{ListenAddress: "127.0.0.1:0", Handler: pm.Handler()})
+	require.Nil(t, err)
+	require.Nil(t, server.Start())
+
+	response, err := http.Get("http://" + server.Address() + metricsPath)
+	require.Nil(t, err)
+	body, err := ioutil.ReadAll(response.Body)
+	require.Nil(t, err)
+	_ = response.Body.Close()
+	require.Contains(t, string(body), "accounts_manager_scroll_pages_read_total 1")
+
+	// the address is already in use
+	other, _ := NewMetricsServer(ArgsMetricsServer{ListenAddress: server.Address(), Handler: pm.Handler()})
+	require.NotNil(t, other.Start())
+
+	require.Nil(t, server.Close())
+}
@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	logger "github.com/multiversx/mx-chain-logger-go"
+)
+
+const (
+	metricsPath         = "/metrics"
+	readHeaderTimeout   = 10 * time.Second
+	serverCloseDuration = 5 * time.Second
+)
+
+var log = logger.GetOrCreate("metrics")
+
+// ArgsMetricsServer holds the arguments needed to create a new metrics server
+type ArgsMetricsServer struct {
+	ListenAddress string
+	Handler       http.Handler
+}
+
+type metricsServer struct {
+	listenAddress string
+	server        *http.Server
+	listener      net.Listener
+}
+
+// NewMetricsServer will create a new instance of metricsServer, which exposes the provided handler on /metrics
+func NewMetricsServer(args ArgsMetricsServer) (*metricsServer, error) {
+	if args.ListenAddress == "" {
+		return nil, ErrEmptyListenAddress
+	}
+	if args.Handler == nil {
+		return nil, ErrNilHandler
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, args.Handler)
+
+	return &metricsServer{
+		listenAddress: args.ListenAddress,
+		server: &http.Server{
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+	}, nil
+}
+
+// Start will bind the listen address and serve the metrics in background. An address that cannot be bound is returned
+// as error
+func (ms *metricsServer) Start() error {
+	listener, err := net.Listen("tcp", ms.listenAddress)
+	if err != nil {
+		return err
+	}
+	ms.listener = listener
+
+	go func() {
+		errServe := ms.server.Serve(listener)
+		if errServe != nil && !errors.Is(errServe, http.ErrServerClosed) {
+			log.Error("metrics server stopped", "error", errServe)
+		}
+	}()
+
+	log.Info("serving the metrics", "address", listener.Addr().String()+metricsPath)
+
+	return nil
+}
+
+// Address returns the bound address of a started server
+func (ms *metricsServer) Address() string {
+	if ms.listener == nil {
+		return ""
+	}
+
+	return ms.listener.Addr().String()
+}
+
+// Close will stop the server, waiting for the in-flight scrapes
+func (ms *metricsServer) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), serverCloseDuration)
+	defer cancel()
+
+	return ms.server.Shutdown(ctx)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ms *metricsServer) IsInterfaceNil() bool {
+	return ms == nil
+}
@@ -0,0 +1,66 @@
+package mocks
+
+import "github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+
+// DestinationStub -
+type DestinationStub struct {
+	PrepareSnapshotCalled func(index string, epoch uint32) error
+	WriteBatchCalled      func(accounts map[string]*data.AccountInfoWithStakeValues) error
+	WriteRanksCalled      func(ranks map[string]*data.AccountRanks) error
+	WriteMetadataCalled   func(documentID string, document []byte) error
+	FinalizeCalled        func() error
+	CloseCalled           func() error
+}
+
+// PrepareSnapshot -
+func (ds *DestinationStub) PrepareSnapshot(index string, epoch uint32) error {
+	if ds.PrepareSnapshotCalled != nil {
+		return ds.PrepareSnapshotCalled(index, epoch)
+	}
+	return nil
+}
+
+// WriteBatch -
+func (ds *DestinationStub) WriteBatch(accounts map[string]*data.AccountInfoWithStakeValues) error {
+	if ds.WriteBatchCalled != nil {
+		return ds.WriteBatchCalled(accounts)
+	}
+	return nil
+}
+
+// WriteRanks -
+func (ds *DestinationStub) WriteRanks(ranks map[string]*data.AccountRanks) error {
+	if ds.WriteRanksCalled != nil {
+		return ds.WriteRanksCalled(ranks)
+	}
+	return nil
+}
+
+// WriteMetadata -
+func (ds *DestinationStub) WriteMetadata(documentID string, document []byte) error {
+	if ds.WriteMetadataCalled != nil {
+		return ds.WriteMetadataCalled(documentID, document)
+	}
+	return nil
+}
+
+// Finalize -
+func (ds *DestinationStub) Finalize() error {
+	if ds.FinalizeCalled != nil {
+		return ds.FinalizeCalled()
+	}
+	return nil
+}
+
+// Close -
+func (ds *DestinationStub) Close() error {
+	if ds.CloseCalled != nil {
+		return ds.CloseCalled()
+	}
+	return nil
+}
+
+// IsInterfaceNil -
+func (ds *DestinationStub) IsInterfaceNil() bool {
+	return ds == nil
+}
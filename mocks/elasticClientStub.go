@@ -0,0 +1,178 @@
+package mocks
+
+import (
+	"bytes"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+// ElasticClientStub -
+type ElasticClientStub struct {
+	PutPolicyCalled                   func(policyName string, policy *bytes.Buffer) error
+	PutMappingCalled                  func(targetIndex string, body *bytes.Buffer) error
+	CreateIndexWithMappingCalled      func(index string, mapping *bytes.Buffer) error
+	PutIndexTemplateCalled            func(name string, template *bytes.Buffer) error
+	PutComponentTemplateCalled        func(name string, template *bytes.Buffer) error
+	GetIndexTemplateCalled            func(name string) ([]byte, error)
+	GetComponentTemplateCalled        func(name string) ([]byte, error)
+	GetMappingCalled                  func(index string) (map[string][]byte, error)
+	GetIndicesCalled                  func(index string) ([]*data.IndexInfo, error)
+	DeleteIndicesCalled               func(indices []string) error
+	RefreshIndexCalled                func(index string) error
+	CountDocumentsCalled              func(index string) (uint64, error)
+	CheckIfIndexExistsCalled          func(index string) (bool, error)
+	DoRequestCalled                   func(index, documentID string, buff *bytes.Buffer) error
+	DoBulkRequestCalled               func(buff *bytes.Buffer, index string) error
+	GetBulkStatsCalled                func() *data.BulkStats
+	DoMultiGetCalled                  func(ids []string, index string) ([]byte, error)
+	DoScrollRequestAllDocumentsCalled func(index string, body []byte, handlerFunc func(responseBytes []byte) error) error
+}
+
+// PutPolicy -
+func (ecs *ElasticClientStub) PutPolicy(policyName string, policy *bytes.Buffer) error {
+	if ecs.PutPolicyCalled != nil {
+		return ecs.PutPolicyCalled(policyName, policy)
+	}
+	return nil
+}
+
+// PutMapping -
+func (ecs *ElasticClientStub) PutMapping(targetIndex string, body *bytes.Buffer) error {
+	if ecs.PutMappingCalled != nil {
+		return ecs.PutMappingCalled(targetIndex, body)
+	}
+	return nil
+}
+
+// CreateIndexWithMapping -
+func (ecs *ElasticClientStub) CreateIndexWithMapping(index string, mapping *bytes.Buffer) error {
+	if ecs.CreateIndexWithMappingCalled != nil {
+		return ecs.CreateIndexWithMappingCalled(index, mapping)
+	}
+	return nil
+}
+
+// PutIndexTemplate -
+func (ecs *ElasticClientStub) PutIndexTemplate(name string, template *bytes.Buffer) error {
+	if ecs.PutIndexTemplateCalled != nil {
+		return ecs.PutIndexTemplateCalled(name, template)
+	}
+	return nil
+}
+
+// PutComponentTemplate -
+func (ecs *ElasticClientStub) PutComponentTemplate(name string, template *bytes.Buffer) error {
+	if ecs.PutComponentTemplateCalled != nil {
+		return ecs.PutComponentTemplateCalled(name, template)
+	}
+	return nil
+}
+
+// GetIndexTemplate -
+func (ecs *ElasticClientStub) GetIndexTemplate(name string) ([]byte, error) {
+	if ecs.GetIndexTemplateCalled != nil {
+		return ecs.GetIndexTemplateCalled(name)
+	}
+	return nil, nil
+}
+
+// GetComponentTemplate -
+func (ecs *ElasticClientStub) GetComponentTemplate(name string) ([]byte, error) {
+	if ecs.GetComponentTemplateCalled != nil {
+		return ecs.GetComponentTemplateCalled(name)
+	}
+	return nil, nil
+}
+
+// GetMapping -
+func (ecs *ElasticClientStub) GetMapping(index string) (map[string][]byte, error) {
+	if ecs.GetMappingCalled != nil {
+		return ecs.GetMappingCalled(index)
+	}
+	return make(map[string][]byte), nil
+}
+
+// GetIndices -
+func (ecs *ElasticClientStub) GetIndices(index string) ([]*data.IndexInfo, error) {
+	if ecs.GetIndicesCalled != nil {
+		return ecs.GetIndicesCalled(index)
+	}
+	return nil, nil
+}
+
+// DeleteIndices -
+func (ecs *ElasticClientStub) DeleteIndices(indices []string) error {
+	if ecs.DeleteIndicesCalled != nil {
+		return ecs.DeleteIndicesCalled(indices)
+	}
+	return nil
+}
+
+// RefreshIndex -
+func (ecs *ElasticClientStub) RefreshIndex(index string) error {
+	if ecs.RefreshIndexCalled != nil {
+		return ecs.RefreshIndexCalled(index)
+	}
+	return nil
+}
+
+// CountDocuments -
+func (ecs *ElasticClientStub) CountDocuments(index string) (uint64, error) {
+	if ecs.CountDocumentsCalled != nil {
+		return ecs.CountDocumentsCalled(index)
+	}
+	return 0, nil
+}
+
+// CheckIfIndexExists -
+func (ecs *ElasticClientStub) CheckIfIndexExists(index string) (bool, error) {
+	if ecs.CheckIfIndexExistsCalled != nil {
+		return ecs.CheckIfIndexExistsCalled(index)
+	}
+	return false, nil
+}
+
+// DoRequest -
+func (ecs *ElasticClientStub) DoRequest(index, documentID string, buff *bytes.Buffer) error {
+	if ecs.DoRequestCalled != nil {
+		return ecs.DoRequestCalled(index, documentID, buff)
+	}
+	return nil
+}
+
+// DoBulkRequest -
+func (ecs *ElasticClientStub) DoBulkRequest(buff *bytes.Buffer, index string) error {
+	if ecs.DoBulkRequestCalled != nil {
+		return ecs.DoBulkRequestCalled(buff, index)
+	}
+	return nil
+}
+
+// GetBulkStats -
+func (ecs *ElasticClientStub) GetBulkStats() *data.BulkStats {
+	if ecs.GetBulkStatsCalled != nil {
+		return ecs.GetBulkStatsCalled()
+	}
+	return &data.BulkStats{}
+}
+
+// DoMultiGet -
+func (ecs *ElasticClientStub) DoMultiGet(ids []string, index string) ([]byte, error) {
+	if ecs.DoMultiGetCalled != nil {
+		return ecs.DoMultiGetCalled(ids, index)
+	}
+	return nil, nil
+}
+
+// DoScrollRequestAllDocuments -
+func (ecs *ElasticClientStub) DoScrollRequestAllDocuments(index string, body []byte, handlerFunc func(responseBytes []byte) error) error {
+	if ecs.DoScrollRequestAllDocumentsCalled != nil {
+		return ecs.DoScrollRequestAllDocumentsCalled(index, body, handlerFunc)
+	}
+	return nil
+}
+
+// IsInterfaceNil -
+func (ecs *ElasticClientStub) IsInterfaceNil() bool {
+	return ecs == nil
+}
@@ -0,0 +1,52 @@
+package mocks
+
+import "time"
+
+// MetricsHandlerStub -
+type MetricsHandlerStub struct {
+	ObserveSourceFetchCalled  func(source string, numAccounts int, duration time.Duration)
+	AddScrollPageCalled       func()
+	AddDestinationBatchCalled func(destination string, err error)
+	ObserveRESTRequestCalled  func(endpoint string, duration time.Duration, err error)
+	ObserveRunCalled          func(epoch uint32, duration time.Duration, err error)
+}
+
+// ObserveSourceFetch -
+func (mhs *MetricsHandlerStub) ObserveSourceFetch(source string, numAccounts int, duration time.Duration) {
+	if mhs.ObserveSourceFetchCalled != nil {
+		mhs.ObserveSourceFetchCalled(source, numAccounts, duration)
+	}
+}
+
+// AddScrollPage -
+func (mhs *MetricsHandlerStub) AddScrollPage() {
+	if mhs.AddScrollPageCalled != nil {
+		mhs.AddScrollPageCalled()
+	}
+}
+
+// AddDestinationBatch -
+func (mhs *MetricsHandlerStub) AddDestinationBatch(destination string, err error) {
+	if mhs.AddDestinationBatchCalled != nil {
+		mhs.AddDestinationBatchCalled(destination, err)
+	}
+}
+
+// ObserveRESTRequest -
+func (mhs *MetricsHandlerStub) ObserveRESTRequest(endpoint string, duration time.Duration, err error) {
+	if mhs.ObserveRESTRequestCalled != nil {
+		mhs.ObserveRESTRequestCalled(endpoint, duration, err)
+	}
+}
+
+// ObserveRun -
+func (mhs *MetricsHandlerStub) ObserveRun(epoch uint32, duration time.Duration, err error) {
+	if mhs.ObserveRunCalled != nil {
+		mhs.ObserveRunCalled(epoch, duration, err)
+	}
+}
+
+// IsInterfaceNil -
+func (mhs *MetricsHandlerStub) IsInterfaceNil() bool {
+	return mhs == nil
+}
@@ -3,12 +3,22 @@ package mocks
 import "github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
 
 type RestClientStub struct {
+	CallGetRestEndPointCalled  func(path string, value interface{}, authenticationData data.RestApiAuthenticationData) error
+	CallPostRestEndPointCalled func(path string, data interface{}, response interface{}, authenticationData data.RestApiAuthenticationData) error
 }
 
-func (r RestClientStub) CallGetRestEndPoint(_ string, _ interface{}, _ data.RestApiAuthenticationData) error {
+func (r RestClientStub) CallGetRestEndPoint(path string, value interface{}, authenticationData data.RestApiAuthenticationData) error {
+	if r.CallGetRestEndPointCalled != nil {
+		return r.CallGetRestEndPointCalled(path, value, authenticationData)
+	}
+
 	panic("implement me")
 }
 
-func (r RestClientStub) CallPostRestEndPoint(_ string, _ interface{}, _ interface{}, _ data.RestApiAuthenticationData) error {
+func (r RestClientStub) CallPostRestEndPoint(path string, data interface{}, response interface{}, authenticationData data.RestApiAuthenticationData) error {
+	if r.CallPostRestEndPointCalled != nil {
+		return r.CallPostRestEndPointCalled(path, data, response, authenticationData)
+	}
+
 	panic("implement me")
 }
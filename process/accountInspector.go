@@ -0,0 +1,188 @@
+package process
+
+import (
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+const totalStakeSource = "computed"
+
+// FieldComparison holds the value of a stake field as returned by its source, as merged during a run and as indexed
+type FieldComparison struct {
+	Field       string `json:"field"`
+	Source      string `json:"source"`
+	SourceValue string `json:"sourceValue"`
+	Merged      string `json:"merged"`
+	Indexed     string `json:"indexed"`
+	Matches     bool   `json:"matches"`
+}
+
+// AccountInspection holds the values of an address from every stake source, the merged stake values and the document
+// of the address from an indexed snapshot
+type AccountInspection struct {
+	Address  string                           `json:"address"`
+	Epoch    uint32                           `json:"epoch"`
+	Sources  *AccountSources                  `json:"sources"`
+	Merged   *data.StakeInfo                  `json:"merged"`
+	Index    string                           `json:"index"`
+	Document *data.AccountInfoWithStakeValues `json:"document"`
+	Fields   []*FieldComparison               `json:"fields"`
+}
+
+type stakeField struct {
+	name   string
+	source string
+	value  func(stakeInfo *data.StakeInfo) string
+}
+
+var stakeFields = []stakeField{
+	{name: "delegationLegacyActive", source: data.SourceLegacyDelegation, value: func(si *data.StakeInfo) string { return si.DelegationLegacyActive }},
+	{name: "delegationLegacyWaiting", source: data.SourceLegacyDelegation, value: func(si *data.StakeInfo) string { return si.DelegationLegacyWaiting }},
+	{name: "validatorsActive", source: data.SourceValidators, value: func(si *data.StakeInfo) string { return si.ValidatorsActive }},
+	{name: "validatorsTopUp", source: data.SourceValidators, value: func(si *data.StakeInfo) string { return si.ValidatorTopUp }},
+	{name: "delegation", source: data.SourceDelegation, value: func(si *data.StakeInfo) string { return si.Delegation }},
+	{name: "lkMexStake", source: data.SourceLKMEX, value: func(si *data.StakeInfo) string { return si.LKMEXStake }},
+	{name: "energy", source: data.SourceEnergy, value: func(si *data.StakeInfo) string { return si.Energy }},
+	{name: "totalStake", source: totalStakeSource, value: func(si *data.StakeInfo) string { return si.TotalStake }},
+}
+
+// ArgsAccountInspector holds the arguments needed to create a new account inspector
+type ArgsAccountInspector struct {
+	AccountsProcessor AccountsProcessorHandler
+	AccountSources    AccountSourcesHandler
+	AccountsIndexer   AccountsIndexerHandler
+}
+
+type accountInspector struct {
+	accountsProcessor AccountsProcessorHandler
+	accountSources    AccountSourcesHandler
+	accountsIndexer   AccountsIndexerHandler
+}
+
+// NewAccountInspector will create a new instance of accountInspector
+func NewAccountInspector(args ArgsAccountInspector) (*accountInspector, error) {
+	if check.IfNil(args.AccountsProcessor) {
+		return nil, ErrNilAccountsProcessor
+	}
+	if check.IfNil(args.AccountSources) {
+		return nil, ErrNilAccountSources
+	}
+	if check.IfNil(args.AccountsIndexer) {
+		return nil, ErrNilAccountsIndexer
+	}
+
+	return &accountInspector{
+		accountsProcessor: args.AccountsProcessor,
+		accountSources:    args.AccountSources,
+		accountsIndexer:   args.AccountsIndexer,
+	}, nil
+}
+
+// Inspect will fetch the provided address from every stake source at the current epoch, will merge the values the same
+// way as a run does and will compare every stake field with the document from the provided snapshot index
+func (ai *accountInspector) Inspect(address string, index string) (*AccountInspection, error) {
+	currentEpoch, err := ai.accountsProcessor.GetCurrentEpoch()
+	if err != nil {
+		return nil, err
+	}
+
+	sources, err := ai.accountSources.GetAccountSources(address, currentEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := ai.accountsIndexer.GetAccounts([]string{address}, index)
+	if err != nil {
+		return nil, err
+	}
+
+	inspection := &AccountInspection{
+		Address:  address,
+		Epoch:    currentEpoch,
+		Sources:  sources,
+		Merged:   mergeAccountSources(address, sources),
+		Index:    index,
+		Document: documents[address],
+	}
+	inspection.Fields = compareStakeFields(sources, inspection.Merged, inspection.Document)
+
+	return inspection, nil
+}
+
+func mergeAccountSources(address string, sources *AccountSources) *data.StakeInfo {
+	sourceAccounts := func(source string) map[string]*data.AccountInfoWithStakeValues {
+		accounts := make(map[string]*data.AccountInfoWithStakeValues)
+		account, ok := sources.Accounts[source]
+		if ok && account != nil {
+			// mergeAccounts updates the accounts in place, so the values of the sources are kept unchanged
+			accountCopy := *account
+			accounts[address] = &accountCopy
+		}
+
+		return accounts
+	}
+
+	mergedAccounts, _ := mergeAccounts(
+		sourceAccounts(data.SourceLegacyDelegation),
+		sourceAccounts(data.SourceValidators),
+		sourceAccounts(data.SourceDelegation),
+		sourceAccounts(data.SourceLKMEX),
+		sourceAccounts(data.SourceEnergy),
+	)
+	calculateTotalStakeForAccounts(mergedAccounts)
+
+	merged, ok := mergedAccounts[address]
+	if !ok {
+		return &data.StakeInfo{}
+	}
+
+	return &merged.StakeInfo
+}
+
+func compareStakeFields(sources *AccountSources, merged *data.StakeInfo, document *data.AccountInfoWithStakeValues) []*FieldComparison {
+	indexed := &data.StakeInfo{}
+	if document != nil {
+		indexed = &document.StakeInfo
+	}
+
+	fields := make([]*FieldComparison, 0, len(stakeFields))
+	for _, field := range stakeFields {
+		comparison := &FieldComparison{
+			Field:   field.name,
+			Source:  field.source,
+			Merged:  valueOrZero(field.value(merged)),
+			Indexed: valueOrZero(field.value(indexed)),
+		}
+		comparison.SourceValue = sourceFieldValue(sources, field, merged)
+		comparison.Matches = comparison.Merged == comparison.Indexed
+		fields = append(fields, comparison)
+	}
+
+	return fields
+}
+
+func sourceFieldValue(sources *AccountSources, field stakeField, merged *data.StakeInfo) string {
+	if field.source == totalStakeSource {
+		return valueOrZero(field.value(merged))
+	}
+
+	account, ok := sources.Accounts[field.source]
+	if !ok || account == nil {
+		return "0"
+	}
+
+	return valueOrZero(field.value(&account.StakeInfo))
+}
+
+func valueOrZero(value string) string {
+	if value == "" {
+		return "0"
+	}
+
+	return value
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ai *accountInspector) IsInterfaceNil() bool {
+	return ai == nil
+}
@@ -0,0 +1,208 @@
+package process
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/core"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	inspectedAddress   = "erd10f7nnvqk8xvyd50f2sc5p4e0ru4alf99p3v7zfe4uvenra2esges39a9x7"
+	inspectedEnergyHex = "0000000a0120d4da7b0bd140000000000000000007630000000901bc16d674ec800000"
+	energyContract     = "erd1qqqqqqqqqqqqqpgqnyuph46rqr29qv5gqhyxh429zcta8r0ppr9s048rjw"
+	legacyContract     = "erd1qqqqqqqqqqqqqpgqxwakt2g7u9atsnr03gqcgmhcv38pt7mkd94q6shuwt"
+)
+
+type accountsProcessorStub struct {
+	currentEpoch uint32
+}
+
+func (aps *accountsProcessorStub) GetCurrentEpoch() (uint32, error) {
+	return aps.currentEpoch, nil
+}
+
+func (aps *accountsProcessorStub) GetAllAccountsWithStake(epoch uint32) (*data.AccountsData, error) {
+	return &data.AccountsData{Epoch: epoch}, nil
+}
+
+func (aps *accountsProcessorStub) ComputeClonedAccountsIndex(epoch uint32) (string, error) {
+	return ComputeAccountsIndexName(epoch), nil
+}
+
+func (aps *accountsProcessorStub) IsInterfaceNil() bool {
+	return aps == nil
+}
+
+type accountsIndexerStub struct {
+	accounts map[string]*data.AccountInfoWithStakeValues
+}
+
+func (ais *accountsIndexerStub) GetAccounts(_ []string, _ string) (map[string]*data.AccountInfoWithStakeValues, error) {
+	return ais.accounts, nil
+}
+
+func (ais *accountsIndexerStub) IndexAccounts(_ map[string]*data.AccountInfoWithStakeValues, _ string) error {
+	return nil
+}
+
+func (ais *accountsIndexerStub) IndexAccountsRanks(_ map[string]*data.AccountRanks, _ string) error {
+	return nil
+}
+
+func (ais *accountsIndexerStub) IsInterfaceNil() bool {
+	return ais == nil
+}
+
+func createRestClientForAddress(t *testing.T, addressBytes []byte) *mocks.RestClientStub {
+	validatorsList := fmt.Sprintf(`{"list":[{"address":"%s","baseStaked":"2500","topUp":"100","total":"2600"},{"address":"erd1other","baseStaked":"1","topUp":"0","total":"1"}]}`, inspectedAddress)
+	energyPath := fmt.Sprintf(pathAccountKey, energyContract, hexEncodedEnergyPrefix+hex.EncodeToString(addressBytes))
+
+	return &mocks.RestClientStub{
+		CallGetRestEndPointCalled: func(path string, value interface{}, _ data.RestApiAuthenticationData) error {
+			response := value.(*data.GenericAPIResponse)
+			switch path {
+			case pathValidatorsStake:
+				response.Data = json.RawMessage(validatorsList)
+			case pathDelegatorStake:
+				response.Data = json.RawMessage(`{"list":[]}`)
+			case energyPath:
+				response.Data = json.RawMessage(fmt.Sprintf(`{"value":"%s"}`, inspectedEnergyHex))
+			default:
+				require.Fail(t, "unexpected path "+path)
+			}
+
+			return nil
+		},
+		CallPostRestEndPointCalled: func(_ string, request interface{}, response interface{}, _ data.RestApiAuthenticationData) error {
+			returnData := [][]byte{addressBytes, big.NewInt(1000).Bytes()}
+			if request.(*data.VmValueRequest).FuncName == getFullWaitingList {
+				returnData = [][]byte{addressBytes, big.NewInt(300).Bytes(), {1}, addressBytes, big.NewInt(200).Bytes(), {2}}
+			}
+
+			response.(*data.ResponseVmValue).Data.Data = &vm.VMOutputApi{
+				ReturnCode: "ok",
+				ReturnData: returnData,
+			}
+
+			return nil
+		},
+	}
+}
+
+func TestAccountsGetter_GetAccountSources(t *testing.T) {
+	t.Parallel()
+
+	pubKey, _ := pubkeyConverter.NewBech32PubkeyConverter(32, log)
+	addressBytes, err := pubKey.Decode(inspectedAddress)
+	require.Nil(t, err)
+
+	ag, err := NewAccountsGetter(createRestClientForAddress(t, addressBytes), pubKey, core.FetchAuthenticationData(config.APIConfig{}), config.GeneralConfig{
+		DelegationLegacyContractAddress: legacyContract,
+		EnergyContractAddress:           energyContract,
+	})
+	require.Nil(t, err)
+
+	sources, err := ag.GetAccountSources(inspectedAddress, 2047)
+	require.Nil(t, err)
+
+	require.Equal(t, []string{"1000"}, sources.LegacyDelegation.ActiveEntries)
+	require.Equal(t, []string{"300", "200"}, sources.LegacyDelegation.WaitingEntries)
+	require.Equal(t, "500", sources.Accounts[data.SourceLegacyDelegation].DelegationLegacyWaiting)
+	require.JSONEq(t, fmt.Sprintf(`{"address":"%s","baseStaked":"2500","topUp":"100","total":"2600"}`, inspectedAddress), string(sources.Validators))
+	require.Nil(t, sources.Delegation)
+	require.Nil(t, sources.LKMEX)
+
+	require.Equal(t, inspectedEnergyHex, sources.Energy.RawValue)
+	require.Equal(t, uint32(156), sources.Energy.EpochsSinceUpdate)
+	require.Equal(t, "4992000000000000000000", sources.Energy.Decay)
+	require.Equal(t, "336000000000000000000", sources.Energy.Energy)
+	require.False(t, sources.Energy.Ignored)
+	require.Equal(t, "336000000000000000000", sources.Accounts[data.SourceEnergy].Energy)
+
+	_, err = ag.GetAccountSources("invalid", 2047)
+	require.NotNil(t, err)
+}
+
+func TestAccountInspector_Inspect(t *testing.T) {
+	t.Parallel()
+
+	pubKey, _ := pubkeyConverter.NewBech32PubkeyConverter(32, log)
+	addressBytes, _ := pubKey.Decode(inspectedAddress)
+	ag, _ := NewAccountsGetter(createRestClientForAddress(t, addressBytes), pubKey, core.FetchAuthenticationData(config.APIConfig{}), config.GeneralConfig{
+		DelegationLegacyContractAddress: legacyContract,
+		EnergyContractAddress:           energyContract,
+	})
+
+	indexer := &accountsIndexerStub{
+		accounts: map[string]*data.AccountInfoWithStakeValues{
+			inspectedAddress: {
+				StakeInfo: data.StakeInfo{
+					DelegationLegacyActive:  "1000",
+					DelegationLegacyWaiting: "500",
+					ValidatorsActive:        "2500",
+					ValidatorTopUp:          "100",
+					TotalStake:              "4100",
+					Energy:                  "1",
+				},
+			},
+		},
+	}
+
+	_, err := NewAccountInspector(ArgsAccountInspector{AccountsProcessor: &accountsProcessorStub{}, AccountsIndexer: indexer})
+	require.Equal(t, ErrNilAccountSources, err)
+
+	var nilAccountsGetter *accountsGetter
+	_, err = NewAccountInspector(ArgsAccountInspector{AccountsProcessor: &accountsProcessorStub{}, AccountSources: nilAccountsGetter, AccountsIndexer: indexer})
+	require.Equal(t, ErrNilAccountSources, err)
+
+	inspector, err := NewAccountInspector(ArgsAccountInspector{
+		AccountsProcessor: &accountsProcessorStub{currentEpoch: 2047},
+		AccountSources:    ag,
+		AccountsIndexer:   indexer,
+	})
+	require.Nil(t, err)
+
+	inspection, err := inspector.Inspect(inspectedAddress, "accounts-000001_2046")
+	require.Nil(t, err)
+	require.Equal(t, uint32(2047), inspection.Epoch)
+	require.Equal(t, "4100", inspection.Merged.TotalStake)
+	require.Equal(t, "336000000000000000000", inspection.Merged.Energy)
+
+	// merging must not change the values of the sources
+	require.Empty(t, inspection.Sources.Accounts[data.SourceLegacyDelegation].ValidatorsActive)
+
+	fields := make(map[string]*FieldComparison)
+	for _, field := range inspection.Fields {
+		fields[field.Field] = field
+	}
+	require.Len(t, fields, len(stakeFields))
+	require.True(t, fields["delegationLegacyWaiting"].Matches)
+	require.True(t, fields["validatorsTopUp"].Matches)
+	require.True(t, fields["totalStake"].Matches)
+	require.Equal(t, &FieldComparison{
+		Field:       "delegation",
+		Source:      data.SourceDelegation,
+		SourceValue: "0",
+		Merged:      "0",
+		Indexed:     "0",
+		Matches:     true,
+	}, fields["delegation"])
+	require.Equal(t, &FieldComparison{
+		Field:       "energy",
+		Source:      data.SourceEnergy,
+		SourceValue: "336000000000000000000",
+		Merged:      "336000000000000000000",
+		Indexed:     "1",
+		Matches:     false,
+	}, fields["energy"])
+}
@@ -0,0 +1,261 @@
+package process
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/core"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/tidwall/gjson"
+)
+
+const pathAccountKey = "/address/%s/key/%s"
+
+// LegacyDelegationSource holds the entries of an address in the active and waiting lists of the legacy delegation
+// contract
+type LegacyDelegationSource struct {
+	ActiveEntries  []string `json:"activeEntries"`
+	WaitingEntries []string `json:"waitingEntries"`
+}
+
+// LKMEXSource holds the value of an address in the snapshot of the lkmex staking contract
+type LKMEXSource struct {
+	RawValue string `json:"rawValue"`
+	Value    string `json:"value"`
+}
+
+// EnergySource holds the storage value of an address in the energy contract and the computation of its energy at the
+// current epoch: energy = amount - (currentEpoch - lastUpdateEpoch) * totalLockedTokens
+type EnergySource struct {
+	StorageKey        string              `json:"storageKey"`
+	RawValue          string              `json:"rawValue"`
+	Details           *data.EnergyDetails `json:"details"`
+	CurrentEpoch      uint32              `json:"currentEpoch"`
+	EpochsSinceUpdate uint32              `json:"epochsSinceUpdate"`
+	Decay             string              `json:"decay"`
+	Energy            string              `json:"energy"`
+	Ignored           bool                `json:"ignored"`
+}
+
+// AccountSources holds the values of an address as returned by every stake source. Accounts holds the stake values of
+// every source, converted the same way as during a run
+type AccountSources struct {
+	LegacyDelegation *LegacyDelegationSource                     `json:"legacyDelegation"`
+	Validators       json.RawMessage                             `json:"validators"`
+	Delegation       json.RawMessage                             `json:"delegation"`
+	LKMEX            *LKMEXSource                                `json:"lkMex"`
+	Energy           *EnergySource                               `json:"energy"`
+	Accounts         map[string]*data.AccountInfoWithStakeValues `json:"-"`
+}
+
+// GetAccountSources will fetch the values of one address from every stake source
+func (ag *accountsGetter) GetAccountSources(address string, currentEpoch uint32) (*AccountSources, error) {
+	addressBytes, err := ag.pubKeyConverter.Decode(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %s: %w", address, err)
+	}
+
+	sources := &AccountSources{
+		Accounts: make(map[string]*data.AccountInfoWithStakeValues),
+	}
+
+	err = ag.addLegacyDelegationSource(address, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ag.addValidatorsSource(address, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ag.addDelegationSource(address, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ag.addLKMEXSource(address, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ag.addEnergySource(address, addressBytes, currentEpoch, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	return sources, nil
+}
+
+func (ag *accountsGetter) addLegacyDelegationSource(address string, sources *AccountSources) error {
+	activeListAccounts, err := ag.getFullActiveListAccounts()
+	if err != nil {
+		return err
+	}
+
+	fullWaitingListAccounts, err := ag.getFullWaitingListAccounts()
+	if err != nil {
+		return err
+	}
+
+	activeEntries := filterStakedInfo(address, activeListAccounts)
+	waitingEntries := filterStakedInfo(address, fullWaitingListAccounts)
+	if len(activeEntries) == 0 && len(waitingEntries) == 0 {
+		return nil
+	}
+
+	sources.LegacyDelegation = &LegacyDelegationSource{
+		ActiveEntries:  stakedValues(activeEntries),
+		WaitingEntries: stakedValues(waitingEntries),
+	}
+	sources.Accounts[data.SourceLegacyDelegation] = legacyDelegatorsToAccounts(activeEntries, waitingEntries)[address]
+
+	return nil
+}
+
+func (ag *accountsGetter) addValidatorsSource(address string, sources *AccountSources) error {
+	list, err := ag.getValidatorsList()
+	if err != nil {
+		return err
+	}
+
+	entry := list.Get(fmt.Sprintf(`#(address=="%s")`, address))
+	if !entry.Exists() {
+		return nil
+	}
+
+	acct := data.StakedInfo{}
+	err = json.Unmarshal([]byte(entry.Raw), &acct)
+	if err != nil {
+		return err
+	}
+
+	sources.Validators = json.RawMessage(entry.Raw)
+	sources.Accounts[data.SourceValidators] = validatorToAccount(acct)
+
+	return nil
+}
+
+func (ag *accountsGetter) addDelegationSource(address string, sources *AccountSources) error {
+	list, err := ag.getDelegatorsList()
+	if err != nil {
+		return err
+	}
+
+	entry := list.Get(fmt.Sprintf(`#(delegatorAddress=="%s")`, address))
+	if !entry.Exists() {
+		return nil
+	}
+
+	acct := data.DelegatorStake{}
+	err = json.Unmarshal([]byte(entry.Raw), &acct)
+	if err != nil {
+		return err
+	}
+
+	sources.Delegation = json.RawMessage(entry.Raw)
+	sources.Accounts[data.SourceDelegation] = delegatorToAccount(acct)
+
+	return nil
+}
+
+func (ag *accountsGetter) addLKMEXSource(address string, sources *AccountSources) error {
+	if ag.lkMexContractAddress == "" {
+		return nil
+	}
+
+	returnedData, err := ag.getLKMEXSnapshot()
+	if err != nil {
+		return err
+	}
+
+	stepForLoop := 2
+	for idx := 0; idx+1 < len(returnedData); idx += stepForLoop {
+		if ag.pubKeyConverter.Encode(returnedData[idx]) != address {
+			continue
+		}
+
+		value := big.NewInt(0).SetBytes(returnedData[idx+1]).String()
+		sources.LKMEX = &LKMEXSource{
+			RawValue: hex.EncodeToString(returnedData[idx+1]),
+			Value:    value,
+		}
+		sources.Accounts[data.SourceLKMEX] = lkMexStakeToAccount(value)
+	}
+
+	return nil
+}
+
+func (ag *accountsGetter) addEnergySource(address string, addressBytes []byte, currentEpoch uint32, sources *AccountSources) error {
+	if ag.energyContractAddress == "" {
+		return nil
+	}
+
+	storageKey := hexEncodedEnergyPrefix + hex.EncodeToString(addressBytes)
+	genericAPIResponse := &data.GenericAPIResponse{}
+	path := fmt.Sprintf(pathAccountKey, ag.energyContractAddress, storageKey)
+	err := ag.restClient.CallGetRestEndPoint(path, genericAPIResponse, core.GetEmptyApiCredentials())
+	if err != nil {
+		return err
+	}
+	if genericAPIResponse.Error != "" {
+		return fmt.Errorf("cannot get energy of %s: %s", address, genericAPIResponse.Error)
+	}
+
+	rawValue := gjson.GetBytes(genericAPIResponse.Data, "value").String()
+	if rawValue == "" {
+		return nil
+	}
+
+	energyDetails, ok := ag.extractEnergyFromValue(rawValue)
+	if !ok {
+		return fmt.Errorf("cannot decode energy of %s from value %s", address, rawValue)
+	}
+
+	epochsSinceUpdate := currentEpoch - energyDetails.LastUpdateEpoch
+	totalLockedTokens, ok := big.NewInt(0).SetString(energyDetails.TotalLockedTokens, 10)
+	if !ok {
+		totalLockedTokens = big.NewInt(0)
+	}
+	decay := big.NewInt(0).Mul(big.NewInt(int64(epochsSinceUpdate)), totalLockedTokens)
+	energyValue := calculateEnergyValueBasedOnCurrentEpoch(energyDetails, currentEpoch)
+
+	account, ok := energyToAccount(energyDetails, currentEpoch)
+	sources.Energy = &EnergySource{
+		StorageKey:        storageKey,
+		RawValue:          rawValue,
+		Details:           energyDetails,
+		CurrentEpoch:      currentEpoch,
+		EpochsSinceUpdate: epochsSinceUpdate,
+		Decay:             decay.String(),
+		Energy:            energyValue.String(),
+		Ignored:           !ok,
+	}
+	if ok {
+		sources.Accounts[data.SourceEnergy] = account
+	}
+
+	return nil
+}
+
+func filterStakedInfo(address string, entries []*data.StakedInfo) []*data.StakedInfo {
+	filtered := make([]*data.StakedInfo, 0)
+	for _, entry := range entries {
+		if entry.Address == address {
+			filtered = append(filtered, entry)
+		}
+	}
+
+	return filtered
+}
+
+func stakedValues(entries []*data.StakedInfo) []string {
+	values := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		values = append(values, entry.Staked)
+	}
+
+	return values
+}
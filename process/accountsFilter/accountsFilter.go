@@ -0,0 +1,183 @@
+package accountsFilter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+const (
+	operatorGreater        = ">"
+	operatorGreaterOrEqual = ">="
+	operatorLower          = "<"
+	operatorLowerOrEqual   = "<="
+)
+
+var log = logger.GetOrCreate("process/accountsFilter")
+
+type threshold struct {
+	field      string
+	fieldIndex []int
+	operator   string
+	value      float64
+}
+
+type accountsFilter struct {
+	pubKeyConverter       core.PubkeyConverter
+	excludeSmartContracts bool
+	excludedAddresses     map[string]struct{}
+	includedAddresses     map[string]struct{}
+	shards                map[uint32]struct{}
+	thresholds            []*threshold
+}
+
+// NewAccountsFilter will create a new instance of accountsFilter
+func NewAccountsFilter(cfg config.AccountsFilterConfig, pubKeyConverter core.PubkeyConverter) (*accountsFilter, error) {
+	if check.IfNil(pubKeyConverter) {
+		return nil, ErrNilPubKeyConverter
+	}
+
+	thresholds := make([]*threshold, 0, len(cfg.Thresholds))
+	for _, thresholdCfg := range cfg.Thresholds {
+		newThreshold, err := createThreshold(thresholdCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		thresholds = append(thresholds, newThreshold)
+	}
+
+	shards := make(map[uint32]struct{}, len(cfg.Shards))
+	for _, shardID := range cfg.Shards {
+		shards[shardID] = struct{}{}
+	}
+
+	return &accountsFilter{
+		pubKeyConverter:       pubKeyConverter,
+		excludeSmartContracts: cfg.ExcludeSmartContracts,
+		excludedAddresses:     sliceToSet(cfg.ExcludedAddresses),
+		includedAddresses:     sliceToSet(cfg.IncludedAddresses),
+		shards:                shards,
+		thresholds:            thresholds,
+	}, nil
+}
+
+func createThreshold(cfg config.ThresholdConfig) (*threshold, error) {
+	switch cfg.Operator {
+	case operatorGreater, operatorGreaterOrEqual, operatorLower, operatorLowerOrEqual:
+	default:
+		return nil, fmt.Errorf("%w: %s, field %s", ErrInvalidThresholdOperator, cfg.Operator, cfg.Field)
+	}
+
+	fieldIndex, ok := numericFieldsIndexes[cfg.Field]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidThresholdField, cfg.Field)
+	}
+
+	return &threshold{
+		field:      cfg.Field,
+		fieldIndex: fieldIndex,
+		operator:   cfg.Operator,
+		value:      cfg.Value,
+	}, nil
+}
+
+// Filter will split the provided accounts in the accounts that should be kept in the snapshot and the filtered ones
+func (af *accountsFilter) Filter(
+	accounts map[string]*data.AccountInfoWithStakeValues,
+) (map[string]*data.AccountInfoWithStakeValues, map[string]*data.AccountInfoWithStakeValues) {
+	keptAccounts := make(map[string]*data.AccountInfoWithStakeValues, len(accounts))
+	filteredAccounts := make(map[string]*data.AccountInfoWithStakeValues)
+
+	for address, account := range accounts {
+		if af.shouldKeep(address, account) {
+			keptAccounts[address] = account
+			continue
+		}
+
+		filteredAccounts[address] = account
+	}
+
+	return keptAccounts, filteredAccounts
+}
+
+func (af *accountsFilter) shouldKeep(address string, account *data.AccountInfoWithStakeValues) bool {
+	if len(af.includedAddresses) > 0 {
+		_, included := af.includedAddresses[address]
+		if !included {
+			return false
+		}
+	}
+
+	_, excluded := af.excludedAddresses[address]
+	if excluded {
+		return false
+	}
+
+	if len(af.shards) > 0 {
+		_, shardAllowed := af.shards[account.ShardID]
+		if !shardAllowed {
+			return false
+		}
+	}
+
+	if af.excludeSmartContracts && af.isSmartContract(address) {
+		return false
+	}
+
+	accountValue := reflect.ValueOf(account).Elem()
+	for _, t := range af.thresholds {
+		if !t.isSatisfied(accountValue) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (af *accountsFilter) isSmartContract(address string) bool {
+	addressBytes, err := af.pubKeyConverter.Decode(address)
+	if err != nil {
+		log.Debug("accountsFilter.isSmartContract: cannot decode address", "address", address, "error", err)
+		return false
+	}
+
+	return core.IsSmartContractAddress(addressBytes)
+}
+
+func (t *threshold) isSatisfied(accountValue reflect.Value) bool {
+	value := numericValueAsFloat(accountValue.FieldByIndex(t.fieldIndex))
+
+	switch t.operator {
+	case operatorGreater:
+		return value > t.value
+	case operatorGreaterOrEqual:
+		return value >= t.value
+	case operatorLower:
+		return value < t.value
+	case operatorLowerOrEqual:
+		return value <= t.value
+	default:
+		return false
+	}
+}
+
+func sliceToSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[strings.TrimSpace(value)] = struct{}{}
+	}
+
+	return set
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (af *accountsFilter) IsInterfaceNil() bool {
+	return af == nil
+}
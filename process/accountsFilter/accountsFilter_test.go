@@ -0,0 +1,136 @@
+package accountsFilter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userAddress1      = "erd10f7nnvqk8xvyd50f2sc5p4e0ru4alf99p3v7zfe4uvenra2esges39a9x7"
+	userAddress2      = "erd1ejjwyzrdj053vcs5nhupxn6kha8audf4mla6tth9339zmcx52w5q7djae2"
+	userAddress3      = "erd1yhhzgv5ql3h8gppy5286grre23vfgw68tnth7dmcl8ywpd9puluqlcvvw9"
+	contractAddress   = "erd1qqqqqqqqqqqqqpgqxwakt2g7u9atsnr03gqcgmhcv38pt7mkd94q6shuwt"
+	addressLength     = 32
+	totalBalanceField = "totalBalanceWithStakeNum"
+)
+
+func createAccounts() map[string]*data.AccountInfoWithStakeValues {
+	accounts := map[string]*data.AccountInfoWithStakeValues{
+		userAddress1:    {},
+		userAddress2:    {},
+		userAddress3:    {},
+		contractAddress: {},
+	}
+	accounts[userAddress1].TotalBalanceWithStakeNum = 10
+	accounts[userAddress1].ShardID = 0
+	accounts[userAddress2].TotalBalanceWithStakeNum = 0.5
+	accounts[userAddress2].ShardID = 1
+	accounts[userAddress3].TotalBalanceWithStakeNum = 100
+	accounts[userAddress3].ShardID = 2
+	accounts[contractAddress].TotalBalanceWithStakeNum = 1000
+	accounts[contractAddress].ShardID = 1
+
+	return accounts
+}
+
+func createFilter(t *testing.T, cfg config.AccountsFilterConfig) *accountsFilter {
+	pubKeyConv, _ := pubkeyConverter.NewBech32PubkeyConverter(addressLength, log)
+	filter, err := NewAccountsFilter(cfg, pubKeyConv)
+	require.Nil(t, err)
+
+	return filter
+}
+
+func requireAddresses(t *testing.T, expected []string, accounts map[string]*data.AccountInfoWithStakeValues) {
+	require.Len(t, accounts, len(expected))
+	for _, address := range expected {
+		require.Contains(t, accounts, address)
+	}
+}
+
+func TestNewAccountsFilter(t *testing.T) {
+	t.Parallel()
+
+	pubKeyConv, _ := pubkeyConverter.NewBech32PubkeyConverter(addressLength, log)
+
+	filter, err := NewAccountsFilter(config.AccountsFilterConfig{}, nil)
+	require.Nil(t, filter)
+	require.Equal(t, ErrNilPubKeyConverter, err)
+
+	filter, err = NewAccountsFilter(config.AccountsFilterConfig{
+		Thresholds: []config.ThresholdConfig{{Field: "missingField", Operator: ">", Value: 1}},
+	}, pubKeyConv)
+	require.Nil(t, filter)
+	require.True(t, errors.Is(err, ErrInvalidThresholdField))
+
+	// the ranks are computed after the accounts are filtered
+	for _, rankField := range []string{"rankStake", "percentileEnergy", "rankTotalBalanceWithStake"} {
+		filter, err = NewAccountsFilter(config.AccountsFilterConfig{
+			Thresholds: []config.ThresholdConfig{{Field: rankField, Operator: "<=", Value: 100}},
+		}, pubKeyConv)
+		require.Nil(t, filter)
+		require.True(t, errors.Is(err, ErrInvalidThresholdField))
+	}
+
+	filter, err = NewAccountsFilter(config.AccountsFilterConfig{
+		Thresholds: []config.ThresholdConfig{{Field: totalBalanceField, Operator: "!=", Value: 1}},
+	}, pubKeyConv)
+	require.Nil(t, filter)
+	require.True(t, errors.Is(err, ErrInvalidThresholdOperator))
+
+	filter, err = NewAccountsFilter(config.AccountsFilterConfig{}, pubKeyConv)
+	require.Nil(t, err)
+	require.False(t, filter.IsInterfaceNil())
+}
+
+func TestAccountsFilter_Filter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty config should keep all accounts", func(t *testing.T) {
+		t.Parallel()
+
+		kept, filtered := createFilter(t, config.AccountsFilterConfig{}).Filter(createAccounts())
+		requireAddresses(t, []string{userAddress1, userAddress2, userAddress3, contractAddress}, kept)
+		require.Len(t, filtered, 0)
+	})
+
+	t.Run("exclude smart contracts", func(t *testing.T) {
+		t.Parallel()
+
+		kept, filtered := createFilter(t, config.AccountsFilterConfig{
+			ExcludeSmartContracts: true,
+		}).Filter(createAccounts())
+		requireAddresses(t, []string{userAddress1, userAddress2, userAddress3}, kept)
+		requireAddresses(t, []string{contractAddress}, filtered)
+	})
+
+	t.Run("address lists", func(t *testing.T) {
+		t.Parallel()
+
+		kept, filtered := createFilter(t, config.AccountsFilterConfig{
+			IncludedAddresses: []string{userAddress1, userAddress2},
+			ExcludedAddresses: []string{userAddress2},
+		}).Filter(createAccounts())
+		requireAddresses(t, []string{userAddress1}, kept)
+		requireAddresses(t, []string{userAddress2, userAddress3, contractAddress}, filtered)
+	})
+
+	t.Run("shards and thresholds", func(t *testing.T) {
+		t.Parallel()
+
+		kept, filtered := createFilter(t, config.AccountsFilterConfig{
+			Shards: []uint32{0, 1},
+			Thresholds: []config.ThresholdConfig{
+				{Field: totalBalanceField, Operator: ">=", Value: 1},
+				{Field: totalBalanceField, Operator: "<", Value: 1000},
+			},
+		}).Filter(createAccounts())
+		requireAddresses(t, []string{userAddress1}, kept)
+		requireAddresses(t, []string{userAddress2, userAddress3, contractAddress}, filtered)
+	})
+}
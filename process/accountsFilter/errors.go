@@ -0,0 +1,12 @@
+package accountsFilter
+
+import "errors"
+
+// ErrNilPubKeyConverter signals that a nil public key converter has been provided
+var ErrNilPubKeyConverter = errors.New("nil public key converter")
+
+// ErrInvalidThresholdOperator signals that an invalid threshold operator has been provided
+var ErrInvalidThresholdOperator = errors.New("invalid threshold operator")
+
+// ErrInvalidThresholdField signals that the threshold field is not a numeric field of an account
+var ErrInvalidThresholdField = errors.New("invalid threshold field")
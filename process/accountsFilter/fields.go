@@ -0,0 +1,68 @@
+package accountsFilter
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+// numericFieldsIndexes maps the json name of every numeric field of an account to its reflect index. The ranks are not
+// included because the accounts are filtered before their ranks are computed
+var numericFieldsIndexes = extractNumericFieldsIndexes(reflect.TypeOf(data.AccountInfoWithStakeValues{}), nil)
+
+var accountRanksType = reflect.TypeOf(data.AccountRanks{})
+
+func extractNumericFieldsIndexes(structType reflect.Type, parentIndex []int) map[string][]int {
+	fields := make(map[string][]int)
+	for idx := 0; idx < structType.NumField(); idx++ {
+		field := structType.Field(idx)
+
+		fieldIndex := make([]int, len(parentIndex), len(parentIndex)+1)
+		copy(fieldIndex, parentIndex)
+		fieldIndex = append(fieldIndex, idx)
+
+		if field.Type == accountRanksType {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			for name, index := range extractNumericFieldsIndexes(field.Type, fieldIndex) {
+				fields[name] = index
+			}
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || !isNumericKind(field.Type.Kind()) {
+			continue
+		}
+
+		fields[name] = fieldIndex
+	}
+
+	return fields
+}
+
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+func numericValueAsFloat(value reflect.Value) float64 {
+	switch value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return value.Float()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint())
+	default:
+		return 0
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"sync"
+	"time"
 
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
 	dataIndexer "github.com/multiversx/mx-chain-es-indexer-go/data"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
@@ -13,18 +17,47 @@ import (
 
 const (
 	numAddressesInBulk = 2000
+
+	bytesInMegabyte = 1024 * 1024
 )
 
 var log = logger.GetOrCreate("process/accountsIndexer")
 
+// ArgsAccountsIndexer holds the arguments needed to create a new accounts indexer
+type ArgsAccountsIndexer struct {
+	ElasticClient    ElasticClientHandler
+	BulkSizeInBytes  int
+	MaxInFlightBulks int
+}
+
 type accountsIndexer struct {
-	elasticClient ElasticClientHandler
+	elasticClient    ElasticClientHandler
+	bulkSizeInBytes  int
+	maxInFlightBulks int
 }
 
-// NewAccountsIndexer will create a new instance of accountsIndexer
-func NewAccountsIndexer(elasticClient ElasticClientHandler) (*accountsIndexer, error) {
+// NewAccountsIndexer will create a new instance of accountsIndexer. A zero BulkSizeInBytes means the default bulk size
+// and a zero MaxInFlightBulks means that the bulk requests are sent one at a time
+func NewAccountsIndexer(args ArgsAccountsIndexer) (*accountsIndexer, error) {
+	if check.IfNil(args.ElasticClient) {
+		return nil, ErrNilElasticClient
+	}
+	if args.BulkSizeInBytes < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidBulkSize, args.BulkSizeInBytes)
+	}
+	if args.MaxInFlightBulks < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidMaxInFlightBulks, args.MaxInFlightBulks)
+	}
+
+	maxInFlightBulks := args.MaxInFlightBulks
+	if maxInFlightBulks == 0 {
+		maxInFlightBulks = 1
+	}
+
 	return &accountsIndexer{
-		elasticClient: elasticClient,
+		elasticClient:    args.ElasticClient,
+		bulkSizeInBytes:  args.BulkSizeInBytes,
+		maxInFlightBulks: maxInFlightBulks,
 	}, nil
 }
 
@@ -83,22 +116,109 @@ func (ai *accountsIndexer) getBulkOfAccounts(addresses []string, index string) (
 
 // IndexAccounts will index provided accounts in a given index
 func (ai *accountsIndexer) IndexAccounts(accounts map[string]*data.AccountInfoWithStakeValues, index string) error {
-	buffSlice, err := serializeAccounts(accounts)
+	buffSlice, err := serializeAccounts(accounts, ai.bulkSizeInBytes)
+	if err != nil {
+		return err
+	}
+
+	return ai.doBulkRequests(buffSlice, index)
+}
+
+// IndexAccountsRanks will update the ranks of the provided accounts in a given index
+func (ai *accountsIndexer) IndexAccountsRanks(ranks map[string]*data.AccountRanks, index string) error {
+	buffSlice, err := serializeAccountsRanks(ranks, ai.bulkSizeInBytes)
 	if err != nil {
 		return err
 	}
+
+	return ai.doBulkRequests(buffSlice, index)
+}
+
+// doBulkRequests will send the provided buffers with at most maxInFlightBulks requests at a time and will return the
+// first error, after all the started requests are done
+func (ai *accountsIndexer) doBulkRequests(buffSlice []*bytes.Buffer, index string) error {
+	startTime := time.Now()
+	inFlight := make(chan struct{}, ai.maxInFlightBulks)
+	wg := sync.WaitGroup{}
+	mut := sync.Mutex{}
+	var firstErr error
+	totalBytes := 0
+
 	for idx := range buffSlice {
-		err = ai.elasticClient.DoBulkRequest(buffSlice[idx], index)
-		if err != nil {
-			return err
+		mut.Lock()
+		failed := firstErr != nil
+		mut.Unlock()
+		if failed {
+			break
 		}
+
+		totalBytes += buffSlice[idx].Len()
+		inFlight <- struct{}{}
+		wg.Add(1)
+		go func(buff *bytes.Buffer) {
+			defer func() {
+				<-inFlight
+				wg.Done()
+			}()
+
+			err := ai.doBulkRequest(buff, index)
+			if err != nil {
+				mut.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mut.Unlock()
+			}
+		}(buffSlice[idx])
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
 	}
 
+	duration := time.Since(startTime)
+	log.Info("indexed bulks",
+		"index", index,
+		"num bulks", len(buffSlice),
+		"size", core.ConvertBytes(uint64(totalBytes)),
+		"duration", duration,
+		"throughput MB/s", computeThroughput(totalBytes, duration),
+	)
+
 	return nil
 }
 
-func serializeAccounts(accounts map[string]*data.AccountInfoWithStakeValues) ([]*bytes.Buffer, error) {
-	buffSlice := dataIndexer.NewBufferSlice(0)
+func (ai *accountsIndexer) doBulkRequest(buff *bytes.Buffer, index string) error {
+	size := buff.Len()
+	startTime := time.Now()
+
+	err := ai.elasticClient.DoBulkRequest(buff, index)
+	if err != nil {
+		return err
+	}
+
+	duration := time.Since(startTime)
+	log.Debug("bulk request",
+		"index", index,
+		"size", core.ConvertBytes(uint64(size)),
+		"latency", duration,
+		"throughput MB/s", computeThroughput(size, duration),
+	)
+
+	return nil
+}
+
+func computeThroughput(numBytes int, duration time.Duration) string {
+	if duration <= 0 {
+		return "0"
+	}
+
+	return fmt.Sprintf("%.2f", float64(numBytes)/bytesInMegabyte/duration.Seconds())
+}
+
+func serializeAccounts(accounts map[string]*data.AccountInfoWithStakeValues, bulkSizeInBytes int) ([]*bytes.Buffer, error) {
+	buffSlice := dataIndexer.NewBufferSlice(bulkSizeInBytes)
 	for address, acc := range accounts {
 		meta, serializedData, err := prepareSerializedAccountInfo(address, acc)
 		if err != nil {
@@ -127,6 +247,25 @@ func prepareSerializedAccountInfo(
 	return meta, serializedData, nil
 }
 
+func serializeAccountsRanks(ranks map[string]*data.AccountRanks, bulkSizeInBytes int) ([]*bytes.Buffer, error) {
+	buffSlice := dataIndexer.NewBufferSlice(bulkSizeInBytes)
+	for address, accountRanks := range ranks {
+		meta := []byte(fmt.Sprintf(`{ "update" : { "_id" : "%s" } }%s`, address, "\n"))
+		serializedRanks, err := json.Marshal(accountRanks)
+		if err != nil {
+			return nil, err
+		}
+
+		serializedData := []byte(fmt.Sprintf(`{ "doc" : %s }`, serializedRanks))
+		err = buffSlice.PutData(meta, serializedData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return buffSlice.Buffers(), nil
+}
+
 func mergeAccountsMaps(dst, src map[string]*data.AccountInfoWithStakeValues) {
 	for key, value := range src {
 		dst[key] = value
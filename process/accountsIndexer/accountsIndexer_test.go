@@ -0,0 +1,106 @@
+package accountsIndexer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func createAccounts(num int) map[string]*data.AccountInfoWithStakeValues {
+	accounts := make(map[string]*data.AccountInfoWithStakeValues, num)
+	for idx := 0; idx < num; idx++ {
+		accounts[fmt.Sprintf("erd1%d", idx)] = &data.AccountInfoWithStakeValues{}
+	}
+
+	return accounts
+}
+
+func TestNewAccountsIndexer(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewAccountsIndexer(ArgsAccountsIndexer{})
+	require.Equal(t, ErrNilElasticClient, err)
+
+	_, err = NewAccountsIndexer(ArgsAccountsIndexer{ElasticClient: &mocks.ElasticClientStub{}, BulkSizeInBytes: -1})
+	require.True(t, errors.Is(err, ErrInvalidBulkSize))
+
+	_, err = NewAccountsIndexer(ArgsAccountsIndexer{ElasticClient: &mocks.ElasticClientStub{}, MaxInFlightBulks: -1})
+	require.True(t, errors.Is(err, ErrInvalidMaxInFlightBulks))
+
+	ai, err := NewAccountsIndexer(ArgsAccountsIndexer{ElasticClient: &mocks.ElasticClientStub{}})
+	require.Nil(t, err)
+	require.False(t, ai.IsInterfaceNil())
+	require.Equal(t, 1, ai.maxInFlightBulks)
+}
+
+func TestAccountsIndexer_IndexAccountsRespectsMaxInFlightBulks(t *testing.T) {
+	t.Parallel()
+
+	maxInFlight := 3
+	numAccounts := 100
+	numInFlight := int32(0)
+	maxObserved := int32(0)
+	numBulks := int32(0)
+	mut := sync.Mutex{}
+	// the bulk requests are sent from other goroutines, so their indices are checked once IndexAccounts returns. Every
+	// bulk holds at least one account, so the channel cannot fill up
+	indices := make(chan string, numAccounts)
+	client := &mocks.ElasticClientStub{
+		DoBulkRequestCalled: func(buff *bytes.Buffer, index string) error {
+			current := atomic.AddInt32(&numInFlight, 1)
+			mut.Lock()
+			if current > maxObserved {
+				maxObserved = current
+			}
+			mut.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&numInFlight, -1)
+			atomic.AddInt32(&numBulks, 1)
+			indices <- index
+			return nil
+		},
+	}
+	ai, _ := NewAccountsIndexer(ArgsAccountsIndexer{
+		ElasticClient:    client,
+		BulkSizeInBytes:  1000,
+		MaxInFlightBulks: maxInFlight,
+	})
+
+	err := ai.IndexAccounts(createAccounts(numAccounts), "accounts")
+	require.Nil(t, err)
+	close(indices)
+	for index := range indices {
+		require.Equal(t, "accounts", index)
+	}
+	require.True(t, atomic.LoadInt32(&numBulks) > int32(maxInFlight))
+	require.True(t, maxObserved <= int32(maxInFlight))
+	require.True(t, maxObserved > 1)
+}
+
+func TestAccountsIndexer_IndexAccountsReturnsBulkError(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	client := &mocks.ElasticClientStub{
+		DoBulkRequestCalled: func(_ *bytes.Buffer, _ string) error {
+			return expectedErr
+		},
+	}
+	ai, _ := NewAccountsIndexer(ArgsAccountsIndexer{
+		ElasticClient:    client,
+		BulkSizeInBytes:  1000,
+		MaxInFlightBulks: 2,
+	})
+
+	err := ai.IndexAccounts(createAccounts(100), "accounts")
+	require.Equal(t, expectedErr, err)
+}
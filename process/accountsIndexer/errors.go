@@ -0,0 +1,12 @@
+package accountsIndexer
+
+import "errors"
+
+// ErrNilElasticClient signals that a nil elastic client has been provided
+var ErrNilElasticClient = errors.New("nil elastic client")
+
+// ErrInvalidBulkSize signals that an invalid bulk size has been provided
+var ErrInvalidBulkSize = errors.New("invalid bulk size")
+
+// ErrInvalidMaxInFlightBulks signals that an invalid number of in-flight bulk requests has been provided
+var ErrInvalidMaxInFlightBulks = errors.New("invalid number of in-flight bulk requests")
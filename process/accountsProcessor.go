@@ -3,7 +3,9 @@ package process
 import (
 	"fmt"
 	"math/big"
+	"time"
 
+	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/core"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
 	"github.com/tidwall/gjson"
@@ -15,45 +17,58 @@ const (
 
 type accountsProcessor struct {
 	AccountsGetterHandler
-	restClient RestClientHandler
+	restClient     RestClientHandler
+	metricsHandler MetricsHandler
 }
 
 // NewAccountsProcessor will create a new instance of accountsProcessor
-func NewAccountsProcessor(restClient RestClientHandler, acctsGetter AccountsGetterHandler) (*accountsProcessor, error) {
+func NewAccountsProcessor(restClient RestClientHandler, acctsGetter AccountsGetterHandler, metricsHandler MetricsHandler) (*accountsProcessor, error) {
+	if check.IfNil(metricsHandler) {
+		return nil, ErrNilMetricsHandler
+	}
+
 	return &accountsProcessor{
 		restClient:            restClient,
 		AccountsGetterHandler: acctsGetter,
+		metricsHandler:        metricsHandler,
 	}, nil
 }
 
 // GetAllAccountsWithStake will return all accounts with stake
 func (ap *accountsProcessor) GetAllAccountsWithStake(currentEpoch uint32) (*data.AccountsData, error) {
-	legacyDelegators, err := ap.GetLegacyDelegatorsAccounts()
+	sourcesStats := make([]*data.SourceFetchStats, 0)
+	legacyDelegators, err := ap.fetchSource(data.SourceLegacyDelegation, &sourcesStats, ap.GetLegacyDelegatorsAccounts)
 	if err != nil {
 		return nil, err
 	}
 
-	validators, err := ap.GetValidatorsAccounts()
+	validators, err := ap.fetchSource(data.SourceValidators, &sourcesStats, ap.GetValidatorsAccounts)
 	if err != nil {
 		return nil, err
 	}
 
-	delegators, err := ap.GetDelegatorsAccounts()
+	delegators, err := ap.fetchSource(data.SourceDelegation, &sourcesStats, ap.GetDelegatorsAccounts)
 	if err != nil {
 		return nil, err
 	}
 
-	lkMexAccountsWithStake, err := ap.GetLKMEXStakeAccounts()
+	lkMexAccountsWithStake, err := ap.fetchSource(data.SourceLKMEX, &sourcesStats, ap.GetLKMEXStakeAccounts)
 	if err != nil {
 		return nil, err
 	}
 
-	accountsWithEnergy, blockInfoEnergy, err := ap.GetAccountsWithEnergy(currentEpoch)
+	var blockInfoEnergy *data.BlockInfo
+	accountsWithEnergy, err := ap.fetchSource(data.SourceEnergy, &sourcesStats, func() (map[string]*data.AccountInfoWithStakeValues, error) {
+		accounts, blockInfo, errEnergy := ap.GetAccountsWithEnergy(currentEpoch)
+		blockInfoEnergy = blockInfo
+
+		return accounts, errEnergy
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	allAccounts, allAddresses := ap.mergeAccounts(legacyDelegators, validators, delegators, lkMexAccountsWithStake, accountsWithEnergy)
+	allAccounts, allAddresses := mergeAccounts(legacyDelegators, validators, delegators, lkMexAccountsWithStake, accountsWithEnergy)
 
 	calculateTotalStakeForAccounts(allAccounts)
 
@@ -62,9 +77,32 @@ func (ap *accountsProcessor) GetAllAccountsWithStake(currentEpoch uint32) (*data
 		Addresses:         allAddresses,
 		EnergyBlockInfo:   blockInfoEnergy,
 		Epoch:             currentEpoch,
+		SourcesStats:      sourcesStats,
 	}, nil
 }
 
+func (ap *accountsProcessor) fetchSource(
+	name string,
+	sourcesStats *[]*data.SourceFetchStats,
+	getAccounts func() (map[string]*data.AccountInfoWithStakeValues, error),
+) (map[string]*data.AccountInfoWithStakeValues, error) {
+	start := time.Now()
+	accounts, err := getAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch the %s accounts: %w", name, err)
+	}
+
+	duration := time.Since(start)
+	ap.metricsHandler.ObserveSourceFetch(name, len(accounts), duration)
+	*sourcesStats = append(*sourcesStats, &data.SourceFetchStats{
+		Name:        name,
+		NumAccounts: len(accounts),
+		DurationMs:  duration.Milliseconds(),
+	})
+
+	return accounts, nil
+}
+
 func calculateTotalStakeForAccounts(accounts map[string]*data.AccountInfoWithStakeValues) {
 	for _, account := range accounts {
 		totalStake, totalStakeNum := computeTotalBalance(
@@ -80,7 +118,7 @@ func calculateTotalStakeForAccounts(accounts map[string]*data.AccountInfoWithSta
 	}
 }
 
-func (ap *accountsProcessor) mergeAccounts(
+func mergeAccounts(
 	legacyDelegators, validators, delegators, lkMexAccountsWithStake, accountsWithEnergy map[string]*data.AccountInfoWithStakeValues,
 ) (map[string]*data.AccountInfoWithStakeValues, []string) {
 	allAddresses := make([]string, 0)
@@ -154,7 +192,12 @@ func (ap *accountsProcessor) mergeAccounts(
 func (ap *accountsProcessor) ComputeClonedAccountsIndex(epoch uint32) (string, error) {
 	log.Info("Compute name of the new index...")
 
-	return fmt.Sprintf("%s_%d", accountsIndex, epoch), nil
+	return ComputeAccountsIndexName(epoch), nil
+}
+
+// ComputeAccountsIndexName will return the name of the accounts index of the provided epoch
+func ComputeAccountsIndexName(epoch uint32) string {
+	return fmt.Sprintf("%s_%d", accountsIndex, epoch)
 }
 
 // GetCurrentEpoch will fetch the current epoch from the network
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/core"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
@@ -27,6 +28,7 @@ func TestAccountsProcessor_GetAllAccountsWithStake(t *testing.T) {
 	mapLegacyDelegation := makeMapFromArrays(keys[5:35], accountsDelegationLegacy)
 	mapValidators := makeMapFromArrays(keys[15:45], accountsValidators)
 
+	observedSources := make(map[string]int)
 	ap, err := NewAccountsProcessor(&mocks.RestClientStub{}, &mocks.AccountsGetterStub{
 		GetDelegatorsAccountsCalled: func() (map[string]*data.AccountInfoWithStakeValues, error) {
 			return mapDelegation, nil
@@ -37,11 +39,17 @@ func TestAccountsProcessor_GetAllAccountsWithStake(t *testing.T) {
 		GetValidatorsAccountsCalled: func() (map[string]*data.AccountInfoWithStakeValues, error) {
 			return mapValidators, nil
 		},
+	}, &mocks.MetricsHandlerStub{
+		ObserveSourceFetchCalled: func(source string, numAccounts int, _ time.Duration) {
+			observedSources[source] = numAccounts
+		},
 	})
 	require.Nil(t, err)
 
 	accountsData, err := ap.GetAllAccountsWithStake(0)
 	require.Nil(t, err)
+	require.Len(t, observedSources, 5)
+	require.Equal(t, len(accountsValidators), observedSources[data.SourceValidators])
 	require.Equal(t, len(accountsData.AccountsWithStake), len(accountsData.Addresses))
 
 	for addr, processedAccount := range accountsData.AccountsWithStake {
@@ -65,6 +65,15 @@ func (ag *accountsGetter) GetLegacyDelegatorsAccounts() (map[string]*data.Accoun
 		return nil, err
 	}
 
+	accountsMap := legacyDelegatorsToAccounts(activeListAccounts, fullWaitingListAccounts)
+
+	log.Info("legacy delegators accounts", "num", len(accountsMap))
+
+	return accountsMap, nil
+}
+
+// legacyDelegatorsToAccounts will sum the entries of the active and waiting lists of the legacy delegation contract
+func legacyDelegatorsToAccounts(activeListAccounts []*data.StakedInfo, fullWaitingListAccounts []*data.StakedInfo) map[string]*data.AccountInfoWithStakeValues {
 	accountsMap := make(map[string]*data.AccountInfoWithStakeValues)
 	for _, legacyStakeInfo := range activeListAccounts {
 		key, value := legacyStakeInfo.Address, legacyStakeInfo.Staked
@@ -106,9 +115,7 @@ func (ag *accountsGetter) GetLegacyDelegatorsAccounts() (map[string]*data.Accoun
 		accountsMap[key].DelegationLegacyWaitingNum = valueWaitingNum
 	}
 
-	log.Info("legacy delegators accounts", "num", len(accountsMap))
-
-	return accountsMap, nil
+	return accountsMap
 }
 
 func (ag *accountsGetter) getFullActiveListAccounts() ([]*data.StakedInfo, error) {
@@ -159,16 +166,11 @@ func (ag *accountsGetter) getAccountsVMQuery(funcName string, stepForLoop int) (
 func (ag *accountsGetter) GetValidatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
 	defer logExecutionTime(time.Now(), "Fetched accounts from validators contract")
 
-	genericApiResponse := &data.GenericAPIResponse{}
-	err := ag.restClient.CallGetRestEndPoint(pathValidatorsStake, genericApiResponse, ag.authenticationData)
+	list, err := ag.getValidatorsList()
 	if err != nil {
 		return nil, err
 	}
-	if genericApiResponse.Error != "" {
-		return nil, fmt.Errorf("%s", genericApiResponse.Error)
-	}
 
-	list := gjson.Get(string(genericApiResponse.Data), "list")
 	accountsInfo := make([]data.StakedInfo, 0)
 	err = json.Unmarshal([]byte(list.String()), &accountsInfo)
 	if err != nil {
@@ -177,14 +179,7 @@ func (ag *accountsGetter) GetValidatorsAccounts() (map[string]*data.AccountInfoW
 
 	accountsStake := make(map[string]*data.AccountInfoWithStakeValues)
 	for _, acct := range accountsInfo {
-		accountsStake[acct.Address] = &data.AccountInfoWithStakeValues{
-			StakeInfo: data.StakeInfo{
-				ValidatorsActive:    acct.Staked,
-				ValidatorsActiveNum: core.ComputeBalanceAsFloat(acct.Staked),
-				ValidatorTopUp:      acct.TopUp,
-				ValidatorTopUpNum:   core.ComputeBalanceAsFloat(acct.TopUp),
-			},
-		}
+		accountsStake[acct.Address] = validatorToAccount(acct)
 	}
 
 	log.Info("validators accounts", "num", len(accountsStake))
@@ -192,21 +187,39 @@ func (ag *accountsGetter) GetValidatorsAccounts() (map[string]*data.AccountInfoW
 	return accountsStake, nil
 }
 
+func (ag *accountsGetter) getValidatorsList() (gjson.Result, error) {
+	genericApiResponse := &data.GenericAPIResponse{}
+	err := ag.restClient.CallGetRestEndPoint(pathValidatorsStake, genericApiResponse, ag.authenticationData)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+	if genericApiResponse.Error != "" {
+		return gjson.Result{}, fmt.Errorf("%s", genericApiResponse.Error)
+	}
+
+	return gjson.Get(string(genericApiResponse.Data), "list"), nil
+}
+
+func validatorToAccount(acct data.StakedInfo) *data.AccountInfoWithStakeValues {
+	return &data.AccountInfoWithStakeValues{
+		StakeInfo: data.StakeInfo{
+			ValidatorsActive:    acct.Staked,
+			ValidatorsActiveNum: core.ComputeBalanceAsFloat(acct.Staked),
+			ValidatorTopUp:      acct.TopUp,
+			ValidatorTopUpNum:   core.ComputeBalanceAsFloat(acct.TopUp),
+		},
+	}
+}
+
 // GetDelegatorsAccounts will fetch all delegators accounts
 func (ag *accountsGetter) GetDelegatorsAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
 	defer logExecutionTime(time.Now(), "Fetched accounts from delegation manager contracts")
 
-	genericApiResponse := &data.GenericAPIResponse{}
-	err := ag.restClient.CallGetRestEndPoint(pathDelegatorStake, genericApiResponse, ag.authenticationData)
+	list, err := ag.getDelegatorsList()
 	if err != nil {
-		log.Warn("CallGetRestEndPoint", "error", err.Error())
 		return nil, err
 	}
-	if genericApiResponse.Error != "" {
-		return nil, fmt.Errorf("cannot get delegators accounts %s", genericApiResponse.Error)
-	}
 
-	list := gjson.Get(string(genericApiResponse.Data), "list")
 	accountsInfo := make([]data.DelegatorStake, 0)
 	err = json.Unmarshal([]byte(list.String()), &accountsInfo)
 	if err != nil {
@@ -216,12 +229,7 @@ func (ag *accountsGetter) GetDelegatorsAccounts() (map[string]*data.AccountInfoW
 
 	accountsStake := make(map[string]*data.AccountInfoWithStakeValues)
 	for _, acct := range accountsInfo {
-		accountsStake[acct.DelegatorAddress] = &data.AccountInfoWithStakeValues{
-			StakeInfo: data.StakeInfo{
-				Delegation:    acct.Total,
-				DelegationNum: core.ComputeBalanceAsFloat(acct.Total),
-			},
-		}
+		accountsStake[acct.DelegatorAddress] = delegatorToAccount(acct)
 	}
 
 	log.Info("delegators accounts", "num", len(accountsStake))
@@ -229,6 +237,29 @@ func (ag *accountsGetter) GetDelegatorsAccounts() (map[string]*data.AccountInfoW
 	return accountsStake, nil
 }
 
+func (ag *accountsGetter) getDelegatorsList() (gjson.Result, error) {
+	genericApiResponse := &data.GenericAPIResponse{}
+	err := ag.restClient.CallGetRestEndPoint(pathDelegatorStake, genericApiResponse, ag.authenticationData)
+	if err != nil {
+		log.Warn("CallGetRestEndPoint", "error", err.Error())
+		return gjson.Result{}, err
+	}
+	if genericApiResponse.Error != "" {
+		return gjson.Result{}, fmt.Errorf("cannot get delegators accounts %s", genericApiResponse.Error)
+	}
+
+	return gjson.Get(string(genericApiResponse.Data), "list"), nil
+}
+
+func delegatorToAccount(acct data.DelegatorStake) *data.AccountInfoWithStakeValues {
+	return &data.AccountInfoWithStakeValues{
+		StakeInfo: data.StakeInfo{
+			Delegation:    acct.Total,
+			DelegationNum: core.ComputeBalanceAsFloat(acct.Total),
+		},
+	}
+}
+
 // GetLKMEXStakeAccounts will fetch all accounts that have stake lkmex tokens
 func (ag *accountsGetter) GetLKMEXStakeAccounts() (map[string]*data.AccountInfoWithStakeValues, error) {
 	accountsMap := make(map[string]*data.AccountInfoWithStakeValues)
@@ -238,6 +269,30 @@ func (ag *accountsGetter) GetLKMEXStakeAccounts() (map[string]*data.AccountInfoW
 
 	defer logExecutionTime(time.Now(), "Fetched accounts from lkmex staking contract")
 
+	returnedData, err := ag.getLKMEXSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	stepForLoop := 2
+	accountsStake := make(map[string]string, 0)
+	for idx := 0; idx < len(returnedData); idx += stepForLoop {
+		address := ag.pubKeyConverter.Encode(returnedData[idx])
+		stakedBalance := big.NewInt(0).SetBytes(returnedData[idx+1])
+
+		accountsStake[address] = stakedBalance.String()
+	}
+
+	for key, value := range accountsStake {
+		accountsMap[key] = lkMexStakeToAccount(value)
+	}
+
+	log.Info("staked lkmex accounts", "num", len(accountsStake))
+
+	return accountsMap, nil
+}
+
+func (ag *accountsGetter) getLKMEXSnapshot() ([][]byte, error) {
 	vmRequest := &data.VmValueRequest{
 		Address:    ag.lkMexContractAddress,
 		FuncName:   lkMexSnapShot,
@@ -258,30 +313,23 @@ func (ag *accountsGetter) GetLKMEXStakeAccounts() (map[string]*data.AccountInfoW
 		}
 	}
 
-	stepForLoop := 2
-	returnedData := responseVmValue.Data.Data.ReturnData
-	accountsStake := make(map[string]string, 0)
-	for idx := 0; idx < len(returnedData); idx += stepForLoop {
-		address := ag.pubKeyConverter.Encode(returnedData[idx])
-		stakedBalance := big.NewInt(0).SetBytes(returnedData[idx+1])
-
-		accountsStake[address] = stakedBalance.String()
-	}
+	return responseVmValue.Data.Data.ReturnData, nil
+}
 
-	for key, value := range accountsStake {
-		accountsMap[key] = &data.AccountInfoWithStakeValues{
-			StakeInfo: data.StakeInfo{
-				LKMEXStake:    value,
-				LKMEXStakeNum: core.ComputeBalanceAsFloat(value),
-			},
-		}
+func lkMexStakeToAccount(value string) *data.AccountInfoWithStakeValues {
+	return &data.AccountInfoWithStakeValues{
+		StakeInfo: data.StakeInfo{
+			LKMEXStake:    value,
+			LKMEXStakeNum: core.ComputeBalanceAsFloat(value),
+		},
 	}
-
-	log.Info("staked lkmex accounts", "num", len(accountsStake))
-
-	return accountsMap, nil
 }
 
 func logExecutionTime(start time.Time, message string) {
 	log.Info(message, "duration in seconds", time.Since(start).Seconds())
 }
+
+// IsInterfaceNil returns true if the value under the interface is nil
+func (ag *accountsGetter) IsInterfaceNil() bool {
+	return ag == nil
+}
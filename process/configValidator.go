@@ -0,0 +1,149 @@
+package process
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex/elasticDestination"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+const addressPubkeyConverterTypeBech32 = "bech32"
+
+// ConfigValidationResult holds the problems found in a configuration. The warnings do not prevent a run
+type ConfigValidationResult struct {
+	Errors   []string
+	Warnings []string
+}
+
+func (cvr *ConfigValidationResult) addError(format string, args ...interface{}) {
+	cvr.Errors = append(cvr.Errors, fmt.Sprintf(format, args...))
+}
+
+func (cvr *ConfigValidationResult) addWarning(format string, args ...interface{}) {
+	cvr.Warnings = append(cvr.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Err returns a config error listing all the errors found, or nil if the configuration is valid
+func (cvr *ConfigValidationResult) Err() error {
+	if len(cvr.Errors) == 0 {
+		return nil
+	}
+
+	return NewCategorizedError(ErrConfig, fmt.Errorf("%w: %s", ErrInvalidConfig, strings.Join(cvr.Errors, "; ")))
+}
+
+// ValidateConfig will check the addresses, the URLs and the pubkey converter of the provided configuration. The
+// indices config folder is checked only if the path is not empty and the elasticsearch destinations are used
+func ValidateConfig(cfg *config.Config, indicesConfigPath string) *ConfigValidationResult {
+	result := &ConfigValidationResult{
+		Errors:   make([]string, 0),
+		Warnings: make([]string, 0),
+	}
+
+	validateAddresses(cfg, result)
+	validateURLs(cfg, result)
+
+	if indicesConfigPath != "" && isDestinationTypeUsed(cfg, destinationTypeElasticSearch) {
+		err := elasticDestination.CheckIndicesConfig(indicesConfigPath)
+		if err != nil {
+			result.addError("%s", err.Error())
+		}
+	}
+
+	return result
+}
+
+func validateAddresses(cfg *config.Config, result *ConfigValidationResult) {
+	if cfg.AddressPubkeyConverter.Type != addressPubkeyConverterTypeBech32 {
+		result.addError("AddressPubkeyConverter.Type: unsupported type %q, only %s is supported",
+			cfg.AddressPubkeyConverter.Type, addressPubkeyConverterTypeBech32)
+	}
+
+	converter, err := pubkeyConverter.NewBech32PubkeyConverter(cfg.AddressPubkeyConverter.Length, log)
+	if err != nil {
+		result.addError("AddressPubkeyConverter.Length: %s", err.Error())
+		return
+	}
+
+	validateAddress := func(name string, address string) {
+		_, errDecode := converter.Decode(address)
+		if errDecode != nil {
+			result.addError("%s: invalid address %q: %s", name, address, errDecode.Error())
+		}
+	}
+
+	if cfg.GeneralConfig.DelegationLegacyContractAddress == "" {
+		result.addError("GeneralConfig.DelegationLegacyContractAddress: empty address")
+	} else {
+		validateAddress("GeneralConfig.DelegationLegacyContractAddress", cfg.GeneralConfig.DelegationLegacyContractAddress)
+	}
+
+	optionalSources := []struct {
+		name    string
+		source  string
+		address string
+	}{
+		{name: "GeneralConfig.LKMEXStakingContractAddress", source: data.SourceLKMEX, address: cfg.GeneralConfig.LKMEXStakingContractAddress},
+		{name: "GeneralConfig.EnergyContractAddress", source: data.SourceEnergy, address: cfg.GeneralConfig.EnergyContractAddress},
+	}
+	for _, optionalSource := range optionalSources {
+		if optionalSource.address == "" {
+			result.addWarning("%s: empty address, the %s source is disabled", optionalSource.name, optionalSource.source)
+			continue
+		}
+		validateAddress(optionalSource.name, optionalSource.address)
+	}
+
+	for idx, address := range cfg.AccountsFilter.ExcludedAddresses {
+		validateAddress(fmt.Sprintf("AccountsFilter.ExcludedAddresses[%d]", idx), address)
+	}
+	for idx, address := range cfg.AccountsFilter.IncludedAddresses {
+		validateAddress(fmt.Sprintf("AccountsFilter.IncludedAddresses[%d]", idx), address)
+	}
+}
+
+func validateURLs(cfg *config.Config, result *ConfigValidationResult) {
+	validateURL("APIConfig.URL", cfg.APIConfig.URL, result)
+	validateEsClientConfig("Reindexer.SourceElasticSearchClient", cfg.Reindexer.SourceElasticSearchClient, result)
+
+	if isDestinationTypeUsed(cfg, destinationTypeElasticSearch) {
+		for idx, esCfg := range cfg.Destination.DestinationElasticSearchClients {
+			validateEsClientConfig(fmt.Sprintf("Destination.DestinationElasticSearchClients[%d]", idx), esCfg, result)
+		}
+	}
+
+	for idx, webhookCfg := range cfg.Notifications.Webhooks {
+		validateURL(fmt.Sprintf("Notifications.Webhooks[%d].URL", idx), webhookCfg.URL, result)
+	}
+}
+
+// validateEsClientConfig checks the addresses of an elastic client, which are not used if a cloud id is set
+func validateEsClientConfig(name string, esCfg data.EsClientConfig, result *ConfigValidationResult) {
+	if esCfg.CloudID != "" {
+		return
+	}
+
+	validateURL(name+".Address", esCfg.Address, result)
+	for idx, address := range esCfg.Addresses {
+		validateURL(fmt.Sprintf("%s.Addresses[%d]", name, idx), address, result)
+	}
+}
+
+func validateURL(name string, value string, result *ConfigValidationResult) {
+	parsedURL, err := url.Parse(value)
+	if err != nil {
+		result.addError("%s: invalid URL %q: %s", name, value, err.Error())
+		return
+	}
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		result.addError("%s: invalid URL %q: the scheme should be http or https", name, value)
+		return
+	}
+	if parsedURL.Host == "" {
+		result.addError("%s: invalid URL %q: empty host", name, value)
+	}
+}
@@ -0,0 +1,92 @@
+package process
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+const pathToIndicesConfig = "../cmd/manager/config/indices"
+
+func createValidConfig() *config.Config {
+	cfg := &config.Config{
+		GeneralConfig: config.GeneralConfig{
+			DelegationLegacyContractAddress: "erd1qqqqqqqqqqqqqpgqxwakt2g7u9atsnr03gqcgmhcv38pt7mkd94q6shuwt",
+			LKMEXStakingContractAddress:     "erd1qqqqqqqqqqqqqpgqt7tyyswqvplpcqnhwe20xqrj7q7ap27d2jps7zczse",
+			EnergyContractAddress:           "erd1qqqqqqqqqqqqqpgqnyuph46rqr29qv5gqhyxh429zcta8r0ppr9s048rjw",
+		},
+		APIConfig: config.APIConfig{URL: "https://api.multiversx.com"},
+	}
+	cfg.AddressPubkeyConverter.Length = 32
+	cfg.AddressPubkeyConverter.Type = "bech32"
+	cfg.Reindexer.SourceElasticSearchClient = data.EsClientConfig{Address: "http://127.0.0.1:9200"}
+	cfg.Destination.DestinationElasticSearchClients = []data.EsClientConfig{{Address: "http://127.0.0.1:9201"}}
+
+	return cfg
+}
+
+func TestValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	result := ValidateConfig(createValidConfig(), pathToIndicesConfig)
+	require.Empty(t, result.Errors)
+	require.Empty(t, result.Warnings)
+	require.Nil(t, result.Err())
+}
+
+func TestValidateConfigReportsAllTheErrors(t *testing.T) {
+	t.Parallel()
+
+	cfg := createValidConfig()
+	cfg.AddressPubkeyConverter.Type = "hex"
+	cfg.GeneralConfig.DelegationLegacyContractAddress = "erd1invalid"
+	cfg.GeneralConfig.EnergyContractAddress = ""
+	cfg.AccountsFilter.ExcludedAddresses = []string{"erd1qqqqqqqqqqqqqpgqt7tyyswqvplpcqnhwe20xqrj7q7ap27d2jps7zczse", "invalid"}
+	cfg.APIConfig.URL = "127.0.0.1:7950"
+	cfg.Reindexer.SourceElasticSearchClient = data.EsClientConfig{CloudID: "deployment:abc"}
+	cfg.Destination.DestinationElasticSearchClients[0].Addresses = []string{"http://"}
+	cfg.Notifications.Webhooks = []config.WebhookNotifierConfig{{URL: "ftp://alerts.example.com"}}
+
+	result := ValidateConfig(cfg, t.TempDir())
+	require.Len(t, result.Errors, 7)
+	require.Contains(t, result.Errors[0], "AddressPubkeyConverter.Type")
+	require.Contains(t, result.Errors[1], "GeneralConfig.DelegationLegacyContractAddress")
+	require.Contains(t, result.Errors[2], "AccountsFilter.ExcludedAddresses[1]")
+	require.Contains(t, result.Errors[3], "APIConfig.URL")
+	require.Contains(t, result.Errors[4], "Destination.DestinationElasticSearchClients[0].Addresses[0]")
+	require.Contains(t, result.Errors[5], "Notifications.Webhooks[0].URL")
+	require.Contains(t, result.Errors[6], "accounts.json")
+	require.Equal(t, []string{"GeneralConfig.EnergyContractAddress: empty address, the energy source is disabled"}, result.Warnings)
+
+	err := result.Err()
+	require.True(t, errors.Is(err, ErrConfig))
+	require.True(t, errors.Is(err, ErrInvalidConfig))
+	require.Equal(t, ExitCodeConfig, ExitCode(err))
+	require.Equal(t, 6, strings.Count(err.Error(), "; "))
+}
+
+func TestValidateConfigInvalidPubkeyLength(t *testing.T) {
+	t.Parallel()
+
+	cfg := createValidConfig()
+	cfg.AddressPubkeyConverter.Length = 0
+
+	result := ValidateConfig(cfg, "")
+	require.Len(t, result.Errors, 1)
+	require.Contains(t, result.Errors[0], "AddressPubkeyConverter.Length")
+}
+
+func TestValidateConfigSkipsTheIndicesConfigWithoutElasticsearchDestinations(t *testing.T) {
+	t.Parallel()
+
+	cfg := createValidConfig()
+	cfg.Destination.Types = []string{destinationTypeFile}
+	cfg.Destination.DestinationElasticSearchClients[0].Address = "invalid"
+
+	result := ValidateConfig(cfg, t.TempDir())
+	require.Nil(t, result.Err())
+}
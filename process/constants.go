@@ -2,4 +2,12 @@ package process
 
 const (
 	accountsIndex = "accounts-000001"
+
+	filteredAccountsIndexPrefix = "filtered-"
+
+	destinationTypeElasticSearch = "elasticsearch"
+	destinationTypeFile          = "file"
+	destinationTypeSQL           = "sql"
+
+	defaultDestinationQueueSize = 10
 )
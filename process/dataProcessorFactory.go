@@ -2,45 +2,228 @@ package process
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
+	nodeCore "github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	"github.com/multiversx/mx-chain-core-go/hashing/keccak"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/core"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex/destinationWriter"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex/elasticDestination"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex/fileSink"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex/reindexer"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex/sqlSink"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/elasticClient"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/metrics"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/accountsFilter"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/accountsIndexer"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/eligibility"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/guardrails"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/notifier"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/retention"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/snapshots"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/restClient"
 )
 
 var log = logger.GetOrCreate("process")
 
+// ArgsDataProcessorFactory holds the arguments needed to create a new data processor
+type ArgsDataProcessorFactory struct {
+	Config            *config.Config
+	IndicesConfigPath string
+	Force             bool
+	MetricsHandler    MetricsHandler
+}
+
 // CreateDataProcessor will create a new instance of a data processor
-func CreateDataProcessor(cfg *config.Config, indicesConfigPath string) (DataProcessor, error) {
-	return getReindexerDataProcessor(cfg, indicesConfigPath)
+func CreateDataProcessor(args ArgsDataProcessorFactory) (DataProcessor, error) {
+	return getReindexerDataProcessor(args)
 }
 
-func getReindexerDataProcessor(cfg *config.Config, indicesConfigPath string) (DataProcessor, error) {
+func getReindexerDataProcessor(args ArgsDataProcessorFactory) (DataProcessor, error) {
+	if check.IfNil(args.MetricsHandler) {
+		return nil, ErrNilMetricsHandler
+	}
+
+	cfg := args.Config
 	sourceEsClient, err := elasticClient.NewElasticClient(cfg.Reindexer.SourceElasticSearchClient)
 	if err != nil {
 		return nil, err
 	}
 
-	destinationESClients, err := createESClients(cfg)
+	pubKeyConverter, err := pubkeyConverter.NewBech32PubkeyConverter(cfg.AddressPubkeyConverter.Length, log)
 	if err != nil {
 		return nil, err
 	}
 
-	rClient, err := restClient.NewRestClient(cfg.APIConfig.URL)
+	acctsProcessor, _, err := createAccountsProcessor(cfg, pubKeyConverter, args.MetricsHandler)
 	if err != nil {
 		return nil, err
 	}
 
-	pubKeyConverter, err := pubkeyConverter.NewBech32PubkeyConverter(cfg.AddressPubkeyConverter.Length, log)
+	acctsFilter, err := accountsFilter.NewAccountsFilter(cfg.AccountsFilter, pubKeyConverter)
+	if err != nil {
+		return nil, err
+	}
+
+	destinationESClients := make([]crossIndex.ElasticClientHandler, 0)
+	if isDestinationTypeUsed(cfg, destinationTypeElasticSearch) {
+		destinationESClients, err = createESClients(cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	destinations, filteredAccountsDestinations, err := createDestinations(args, destinationESClients)
 	if err != nil {
 		return nil, err
 	}
 
+	reindexerProc, err := reindexer.New(reindexer.ArgsReindexer{
+		SourceIndexer:                sourceEsClient,
+		Destinations:                 destinations,
+		FilteredAccountsDestinations: filteredAccountsDestinations,
+		AccountsFilter:               acctsFilter,
+		PathToAddressLabels:          cfg.AddressLabels.Path,
+		NumSourceSlices:              cfg.Reindexer.NumSlices,
+		VerificationSampleSize:       cfg.Verification.SampleSize,
+		Metrics:                      args.MetricsHandler,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	runVerifier, err := createRunVerifier(cfg, sourceEsClient, destinationESClients)
+	if err != nil {
+		return nil, err
+	}
+
+	guardrailsChecker, err := createGuardrails(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	reportedClients := make([]*ReportedElasticClient, len(destinationESClients))
+	for idx, client := range destinationESClients {
+		esCfg := cfg.Destination.DestinationElasticSearchClients[idx]
+		address := esCfg.Address
+		if address == "" {
+			address = esCfg.CloudID
+		}
+		reportedClients[idx] = &ReportedElasticClient{
+			Address: address,
+			Client:  client,
+		}
+	}
+
+	return NewReindexerDataProcessor(ArgsReindexerDataProcessor{
+		AccountsProcessor: acctsProcessor,
+		Reindexer:         reindexerProc,
+		RunVerifier:       runVerifier,
+		Guardrails:        guardrailsChecker,
+		Force:             args.Force,
+		Destinations:      collectDestinationsStats(destinations, filteredAccountsDestinations),
+		ElasticClients:    reportedClients,
+	})
+}
+
+// collectDestinationsStats returns the destinations that count their written accounts, all the destinations being
+// wrapped in destination writers
+func collectDestinationsStats(destinationsLists ...[]crossIndex.Destination) []DestinationStatsHandler {
+	statsHandlers := make([]DestinationStatsHandler, 0)
+	for _, destinations := range destinationsLists {
+		for _, destination := range destinations {
+			statsHandler, ok := destination.(DestinationStatsHandler)
+			if ok {
+				statsHandlers = append(statsHandlers, statsHandler)
+			}
+		}
+	}
+
+	return statsHandlers
+}
+
+// createRunVerifier returns nil if the verification is disabled or if no elasticsearch destination is used
+func createRunVerifier(
+	cfg *config.Config,
+	sourceEsClient snapshots.ElasticClientHandler,
+	destinationESClients []crossIndex.ElasticClientHandler,
+) (RunVerifier, error) {
+	if !cfg.Verification.Enabled || len(destinationESClients) == 0 {
+		return nil, nil
+	}
+
+	clients := make([]snapshots.ElasticClientHandler, 0, len(destinationESClients))
+	for _, client := range destinationESClients {
+		clients = append(clients, client)
+	}
+
+	filteredIndexPrefix := ""
+	if cfg.AccountsFilter.IndexFilteredAccounts {
+		filteredIndexPrefix = filteredAccountsIndexPrefix
+	}
+
+	return snapshots.NewSnapshotVerifier(snapshots.ArgsSnapshotVerifier{
+		Clients:                     clients,
+		SourceClient:                sourceEsClient,
+		FilteredAccountsIndexPrefix: filteredIndexPrefix,
+	})
+}
+
+// getDestinationTypes returns the configured destination types, elasticsearch if none is configured, together with the
+// file and sql types when their sections are not empty
+func getDestinationTypes(cfg *config.Config) []string {
+	types := cfg.Destination.Types
+	if len(types) == 0 {
+		types = []string{destinationTypeElasticSearch}
+	}
+
+	destinationTypes := make([]string, 0, len(types))
+	for _, destinationType := range types {
+		if !containsString(destinationTypes, destinationType) {
+			destinationTypes = append(destinationTypes, destinationType)
+		}
+	}
+	if len(cfg.Destination.FileSinks) > 0 && !containsString(destinationTypes, destinationTypeFile) {
+		destinationTypes = append(destinationTypes, destinationTypeFile)
+	}
+	if len(cfg.Destination.DestinationSQLDatabases) > 0 && !containsString(destinationTypes, destinationTypeSQL) {
+		destinationTypes = append(destinationTypes, destinationTypeSQL)
+	}
+
+	return destinationTypes
+}
+
+func isDestinationTypeUsed(cfg *config.Config, destinationType string) bool {
+	return containsString(getDestinationTypes(cfg), destinationType)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func createAccountsProcessor(
+	cfg *config.Config,
+	pubKeyConverter nodeCore.PubkeyConverter,
+	metricsHandler MetricsHandler,
+) (AccountsProcessorHandler, *accountsGetter, error) {
+	rClient, err := restClient.NewRestClient(cfg.APIConfig.URL, metricsHandler)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	authenticationData := core.FetchAuthenticationData(cfg.APIConfig)
 	acctGetter, err := NewAccountsGetter(
 		rClient,
@@ -48,21 +231,287 @@ func getReindexerDataProcessor(cfg *config.Config, indicesConfigPath string) (Da
 		authenticationData,
 		cfg.GeneralConfig,
 	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	acctsProcessor, err := NewAccountsProcessor(rClient, acctGetter, metricsHandler)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return acctsProcessor, acctGetter, nil
+}
+
+// CreateRunReportWriter will create a new instance of a run report writer. The reports are indexed in the values index
+// of all the destination clients only if enabled
+func CreateRunReportWriter(cfg *config.Config) (RunReportWriter, error) {
+	clients := make([]crossIndex.ElasticClientHandler, 0)
+	if cfg.RunReport.IndexInValues {
+		var err error
+		clients, err = createESClients(cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewRunReportWriter(ArgsRunReportWriter{
+		Path:    cfg.RunReport.Path,
+		Clients: clients,
+	})
+}
+
+// CreateRunNotifier will create a new instance of a run notifier with the configured webhooks and exec hooks. The
+// anomaly checks read the stake stats of the previous run from the first destination client, so they are enabled only
+// if thresholds are set and the elasticsearch destinations are used
+func CreateRunNotifier(cfg *config.Config) (RunNotifier, error) {
+	notifiers := make([]Notifier, 0, len(cfg.Notifications.Webhooks)+len(cfg.Notifications.ExecHooks))
+	for _, webhookCfg := range cfg.Notifications.Webhooks {
+		webhook, err := notifier.NewWebhookNotifier(notifier.ArgsWebhookNotifier{
+			URL:     webhookCfg.URL,
+			Headers: webhookCfg.Headers,
+			Events:  webhookCfg.Events,
+			Timeout: time.Duration(webhookCfg.TimeoutInSec) * time.Second,
+		})
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, webhook)
+	}
+	for _, execCfg := range cfg.Notifications.ExecHooks {
+		execHook, err := notifier.NewExecNotifier(notifier.ArgsExecNotifier{
+			Command: execCfg.Command,
+			Args:    execCfg.Args,
+			Events:  execCfg.Events,
+			Timeout: time.Duration(execCfg.TimeoutInSec) * time.Second,
+		})
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, execHook)
+	}
+
+	anomalyDetector, err := notifier.NewAnomalyDetector(cfg.Notifications.AnomalyThresholds)
+	if err != nil {
+		return nil, err
+	}
+
+	var stakeStatsGetter StakeStatsGetter
+	if len(cfg.Notifications.AnomalyThresholds) > 0 {
+		stakeStatsGetter, err = createStakeStatsGetter(cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewRunNotifier(ArgsRunNotifier{
+		Notifiers:        notifiers,
+		AnomalyDetector:  anomalyDetector,
+		StakeStatsGetter: stakeStatsGetter,
+	})
+}
+
+// createStakeStatsGetter returns a getter of the stake stats of the previous run, read from the first destination
+// client, or nil if the elasticsearch destinations are not used
+func createStakeStatsGetter(cfg *config.Config) (StakeStatsGetter, error) {
+	if !isDestinationTypeUsed(cfg, destinationTypeElasticSearch) {
+		return nil, nil
+	}
+
+	clients, err := createSnapshotsClients(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots.NewSnapshotsLister(snapshots.ArgsSnapshotsLister{
+		Clients:       clients[:1],
+		AccountsIndex: accountsIndex,
+	})
+}
+
+// createGuardrails returns the checker of the configured guardrails, or nil if they are disabled. The total stake
+// changes are checked against the previous snapshot of the first destination client
+func createGuardrails(cfg *config.Config) (Guardrails, error) {
+	if !cfg.Guardrails.Enabled {
+		return nil, nil
+	}
+
+	stakeStatsGetter, err := createStakeStatsGetter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return guardrails.NewGuardrails(guardrails.ArgsGuardrails{
+		Config:           cfg.Guardrails,
+		EnabledSources:   getEnabledSources(cfg.GeneralConfig),
+		StakeStatsGetter: stakeStatsGetter,
+	})
+}
+
+// getEnabledSources returns the stake sources fetched by a run, the LKMEX and energy ones requiring a contract address
+func getEnabledSources(cfg config.GeneralConfig) []string {
+	sources := []string{data.SourceLegacyDelegation, data.SourceValidators, data.SourceDelegation}
+	if cfg.LKMEXStakingContractAddress != "" {
+		sources = append(sources, data.SourceLKMEX)
+	}
+	if cfg.EnergyContractAddress != "" {
+		sources = append(sources, data.SourceEnergy)
+	}
+
+	return sources
+}
+
+// CreateAccountInspector will create a new instance of an account inspector. The indexed documents are read from the
+// first destination client
+func CreateAccountInspector(cfg *config.Config) (AccountInspector, error) {
+	destinationESClients, err := createESClients(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKeyConverter, err := pubkeyConverter.NewBech32PubkeyConverter(cfg.AddressPubkeyConverter.Length, log)
+	if err != nil {
+		return nil, err
+	}
+
+	acctsProcessor, acctGetter, err := createAccountsProcessor(cfg, pubKeyConverter, metrics.NewDisabledMetrics())
+	if err != nil {
+		return nil, err
+	}
+
+	acctsIndexer, err := accountsIndexer.NewAccountsIndexer(accountsIndexer.ArgsAccountsIndexer{
+		ElasticClient: destinationESClients[0],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAccountInspector(ArgsAccountInspector{
+		AccountsProcessor: acctsProcessor,
+		AccountSources:    acctGetter,
+		AccountsIndexer:   acctsIndexer,
+	})
+}
+
+// CreateSnapshotsLister will create a new instance of a snapshots lister over all the destination clients
+func CreateSnapshotsLister(cfg *config.Config) (SnapshotsLister, error) {
+	clients, err := createSnapshotsClients(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots.NewSnapshotsLister(snapshots.ArgsSnapshotsLister{
+		Clients:       clients,
+		AccountsIndex: accountsIndex,
+	})
+}
+
+// CreateSnapshotVerifier will create a new instance of a snapshot verifier over all the destination clients
+func CreateSnapshotVerifier(cfg *config.Config) (SnapshotVerifier, error) {
+	clients, err := createSnapshotsClients(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots.NewSnapshotVerifier(snapshots.ArgsSnapshotVerifier{
+		Clients: clients,
+	})
+}
+
+// CreateSnapshotExporter will create a new instance of a snapshot exporter, which reads the snapshot from the first
+// destination client and writes it in the configured file sinks
+func CreateSnapshotExporter(cfg *config.Config) (SnapshotExporter, error) {
+	clients, err := createSnapshotsClients(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	destinations, err := createFileDestinations(cfg, metrics.NewDisabledMetrics())
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots.NewSnapshotExporter(snapshots.ArgsSnapshotExporter{
+		Client:       clients[0],
+		Destinations: destinations,
+	})
+}
+
+// CreateSnapshotsDiffer will create a new instance of a snapshots differ, which reads the snapshots from the first
+// destination client
+func CreateSnapshotsDiffer(cfg *config.Config) (SnapshotsDiffer, error) {
+	clients, err := createSnapshotsClients(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots.NewSnapshotsDiffer(snapshots.ArgsSnapshotsDiffer{
+		Client: clients[0],
+	})
+}
+
+func createSnapshotsClients(cfg *config.Config) ([]snapshots.ElasticClientHandler, error) {
+	destinationESClients, err := createESClients(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make([]snapshots.ElasticClientHandler, 0, len(destinationESClients))
+	for _, client := range destinationESClients {
+		clients = append(clients, client)
+	}
+
+	return clients, nil
+}
+
+// CreateEligibilityExporter will create a new instance of an eligibility exporter. The snapshot is read from the first
+// destination client and the merkle root is indexed in all the destination clients
+func CreateEligibilityExporter(cfg *config.Config) (EligibilityExporter, error) {
+	destinationESClients, err := createESClients(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	acctsProcessor, err := NewAccountsProcessor(rClient, acctGetter)
+	pubKeyConverter, err := pubkeyConverter.NewBech32PubkeyConverter(cfg.AddressPubkeyConverter.Length, log)
 	if err != nil {
 		return nil, err
 	}
 
-	reindexerProc, err := reindexer.New(sourceEsClient, destinationESClients, indicesConfigPath)
+	valuesClients := make([]eligibility.ElasticClientHandler, 0, len(destinationESClients))
+	for _, client := range destinationESClients {
+		valuesClients = append(valuesClients, client)
+	}
+
+	return eligibility.NewEligibilityExporter(eligibility.ArgsEligibilityExporter{
+		SnapshotClient:  destinationESClients[0],
+		ValuesClients:   valuesClients,
+		PubKeyConverter: pubKeyConverter,
+		Hasher:          keccak.NewKeccak(),
+		Config:          cfg.Eligibility,
+	})
+}
+
+// CreateRetentionManager will create a new instance of a retention manager, which prunes the accounts snapshots and the
+// values documents from all the destination clients
+func CreateRetentionManager(cfg *config.Config, dryRun bool) (RetentionManager, error) {
+	destinationESClients, err := createESClients(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewReindexerDataProcessor(acctsProcessor, reindexerProc)
+	clients := make([]retention.ElasticClientHandler, 0, len(destinationESClients))
+	for _, client := range destinationESClients {
+		clients = append(clients, client)
+	}
+
+	return retention.NewRetentionManager(retention.ArgsRetentionManager{
+		Clients:       clients,
+		AccountsIndex: accountsIndex,
+		IndexPrefixes: []string{filteredAccountsIndexPrefix},
+		KeepEpochs:    cfg.Retention.KeepEpochs,
+		KeepDays:      cfg.Retention.KeepDays,
+		DryRun:        dryRun,
+	})
 }
 
 func createESClients(cfg *config.Config) ([]crossIndex.ElasticClientHandler, error) {
@@ -82,3 +531,157 @@ func createESClients(cfg *config.Config) ([]crossIndex.ElasticClientHandler, err
 
 	return clients, nil
 }
+
+// createDestinations will create the destinations of the accounts snapshots based on the configured types. The
+// accounts removed by the filter are indexed only in the elasticsearch destinations, if enabled
+func createDestinations(args ArgsDataProcessorFactory, esClients []crossIndex.ElasticClientHandler) ([]crossIndex.Destination, []crossIndex.Destination, error) {
+	cfg := args.Config
+	destinations := make([]crossIndex.Destination, 0)
+	filteredAccountsDestinations := make([]crossIndex.Destination, 0)
+	for _, destinationType := range getDestinationTypes(cfg) {
+		switch destinationType {
+		case destinationTypeElasticSearch:
+			esDestinations, esFilteredDestinations, err := createElasticDestinations(args, esClients)
+			if err != nil {
+				return nil, nil, err
+			}
+			destinations = append(destinations, esDestinations...)
+			filteredAccountsDestinations = append(filteredAccountsDestinations, esFilteredDestinations...)
+		case destinationTypeFile:
+			fileDestinations, err := createFileDestinations(cfg, args.MetricsHandler)
+			if err != nil {
+				return nil, nil, err
+			}
+			destinations = append(destinations, fileDestinations...)
+		case destinationTypeSQL:
+			sqlDestinations, err := createSQLDestinations(cfg, args.MetricsHandler)
+			if err != nil {
+				return nil, nil, err
+			}
+			destinations = append(destinations, sqlDestinations...)
+		default:
+			return nil, nil, fmt.Errorf("%w: %s", ErrInvalidDestinationType, destinationType)
+		}
+	}
+
+	return destinations, filteredAccountsDestinations, nil
+}
+
+func createDestinationWriter(
+	cfg *config.Config,
+	destination crossIndex.Destination,
+	name string,
+	onFailure string,
+	metricsHandler MetricsHandler,
+) (crossIndex.Destination, error) {
+	queueSize := cfg.Destination.QueueSize
+	if queueSize == 0 {
+		queueSize = defaultDestinationQueueSize
+	}
+
+	return destinationWriter.NewDestinationWriter(destinationWriter.ArgsDestinationWriter{
+		Destination:   destination,
+		Name:          name,
+		QueueSize:     queueSize,
+		FailurePolicy: onFailure,
+		Metrics:       metricsHandler,
+	})
+}
+
+func createElasticDestinations(args ArgsDataProcessorFactory, clients []crossIndex.ElasticClientHandler) ([]crossIndex.Destination, []crossIndex.Destination, error) {
+	cfg := args.Config
+
+	destinations := make([]crossIndex.Destination, 0, len(clients))
+	filteredAccountsDestinations := make([]crossIndex.Destination, 0)
+	for idx, client := range clients {
+		esCfg := cfg.Destination.DestinationElasticSearchClients[idx]
+		esDestination, errCreate := elasticDestination.NewElasticDestination(elasticDestination.ArgsElasticDestination{
+			Client:              client,
+			PathToIndicesConfig: args.IndicesConfigPath,
+			BulkSizeInBytes:     cfg.Destination.BulkSizeInBytes,
+			MaxInFlightBulks:    cfg.Destination.MaxInFlightBulks,
+			ForceMappingChanges: args.Force,
+		})
+		if errCreate != nil {
+			return nil, nil, errCreate
+		}
+
+		destination, errCreate := createDestinationWriter(cfg, esDestination, destinationTypeElasticSearch+" "+esCfg.Address, esCfg.OnFailure, args.MetricsHandler)
+		if errCreate != nil {
+			return nil, nil, errCreate
+		}
+		destinations = append(destinations, destination)
+
+		if !cfg.AccountsFilter.IndexFilteredAccounts {
+			continue
+		}
+
+		esFilteredDestination, errCreate := elasticDestination.NewElasticDestination(elasticDestination.ArgsElasticDestination{
+			Client:              client,
+			PathToIndicesConfig: args.IndicesConfigPath,
+			IndexPrefix:         filteredAccountsIndexPrefix,
+			BulkSizeInBytes:     cfg.Destination.BulkSizeInBytes,
+			MaxInFlightBulks:    cfg.Destination.MaxInFlightBulks,
+			ForceMappingChanges: args.Force,
+		})
+		if errCreate != nil {
+			return nil, nil, errCreate
+		}
+
+		filteredDestination, errCreate := createDestinationWriter(cfg, esFilteredDestination, filteredAccountsIndexPrefix+destinationTypeElasticSearch+" "+esCfg.Address, esCfg.OnFailure, args.MetricsHandler)
+		if errCreate != nil {
+			return nil, nil, errCreate
+		}
+		filteredAccountsDestinations = append(filteredAccountsDestinations, filteredDestination)
+	}
+
+	return destinations, filteredAccountsDestinations, nil
+}
+
+func createFileDestinations(cfg *config.Config, metricsHandler MetricsHandler) ([]crossIndex.Destination, error) {
+	if len(cfg.Destination.FileSinks) == 0 {
+		return nil, fmt.Errorf("%w for type %s", ErrEmptyDestinationConfig, destinationTypeFile)
+	}
+
+	destinations := make([]crossIndex.Destination, 0, len(cfg.Destination.FileSinks))
+	for _, sinkCfg := range cfg.Destination.FileSinks {
+		sink, err := fileSink.NewFileSink(sinkCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		name := fmt.Sprintf("%s %s (%s)", destinationTypeFile, sinkCfg.Directory, sinkCfg.Format)
+		destination, err := createDestinationWriter(cfg, sink, name, sinkCfg.OnFailure, metricsHandler)
+		if err != nil {
+			return nil, err
+		}
+
+		destinations = append(destinations, destination)
+	}
+
+	return destinations, nil
+}
+
+func createSQLDestinations(cfg *config.Config, metricsHandler MetricsHandler) ([]crossIndex.Destination, error) {
+	if len(cfg.Destination.DestinationSQLDatabases) == 0 {
+		return nil, fmt.Errorf("%w for type %s", ErrEmptyDestinationConfig, destinationTypeSQL)
+	}
+
+	destinations := make([]crossIndex.Destination, 0, len(cfg.Destination.DestinationSQLDatabases))
+	for _, sinkCfg := range cfg.Destination.DestinationSQLDatabases {
+		sink, err := sqlSink.NewSQLSink(sinkCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		name := fmt.Sprintf("%s %s (%s)", destinationTypeSQL, sinkCfg.Driver, sinkCfg.TablePrefix)
+		destination, err := createDestinationWriter(cfg, sink, name, sinkCfg.OnFailure, metricsHandler)
+		if err != nil {
+			return nil, err
+		}
+
+		destinations = append(destinations, destination)
+	}
+
+	return destinations, nil
+}
@@ -0,0 +1,24 @@
+package process
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDestinationTypes(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{}
+	require.Equal(t, []string{destinationTypeElasticSearch}, getDestinationTypes(cfg))
+
+	cfg.Destination.FileSinks = []config.FileSinkConfig{{Format: "ndjson", Directory: "./snapshots"}}
+	cfg.Destination.DestinationSQLDatabases = []config.SQLSinkConfig{{Driver: "sqlite3", DSN: "./accounts.db"}}
+	require.Equal(t, []string{destinationTypeElasticSearch, destinationTypeFile, destinationTypeSQL}, getDestinationTypes(cfg))
+
+	cfg.Destination.Types = []string{destinationTypeSQL, destinationTypeSQL}
+	require.Equal(t, []string{destinationTypeSQL, destinationTypeFile}, getDestinationTypes(cfg))
+	require.False(t, isDestinationTypeUsed(cfg, destinationTypeElasticSearch))
+	require.True(t, isDestinationTypeUsed(cfg, destinationTypeFile))
+}
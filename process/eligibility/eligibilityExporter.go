@@ -0,0 +1,317 @@
+package eligibility
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/hashing"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+const (
+	valuesIndex         = "values"
+	merkleRootKey       = "eligibilityMerkleRoot"
+	amountNumBytes      = 32
+	denomination        = 18
+	amountsFileFormat   = "eligibility-%d.csv"
+	proofsFileFormat    = "eligibility-proofs-%d.json"
+	merkleRootDocFormat = "eligibility-merkle-root-%d"
+)
+
+var log = logger.GetOrCreate("process/eligibility")
+
+type eligibleAccount struct {
+	address string
+	amount  *big.Int
+	leaf    []byte
+}
+
+type accountProof struct {
+	Amount string   `json:"amount"`
+	Leaf   string   `json:"leaf"`
+	Proof  []string `json:"proof"`
+}
+
+type proofsFile struct {
+	Epoch      uint32                   `json:"epoch"`
+	MerkleRoot string                   `json:"merkleRoot"`
+	Proofs     map[string]*accountProof `json:"proofs"`
+}
+
+// ArgsEligibilityExporter holds the arguments needed to create a new eligibility exporter
+type ArgsEligibilityExporter struct {
+	SnapshotClient  ElasticClientHandler
+	ValuesClients   []ElasticClientHandler
+	PubKeyConverter core.PubkeyConverter
+	Hasher          hashing.Hasher
+	Config          config.EligibilityConfig
+}
+
+type eligibilityExporter struct {
+	snapshotClient  ElasticClientHandler
+	valuesClients   []ElasticClientHandler
+	pubKeyConverter core.PubkeyConverter
+	hasher          hashing.Hasher
+	outputDirectory string
+	minAmount       *big.Rat
+	weights         []*weight
+}
+
+// NewEligibilityExporter will create a new instance of eligibilityExporter
+func NewEligibilityExporter(args ArgsEligibilityExporter) (*eligibilityExporter, error) {
+	if check.IfNil(args.SnapshotClient) {
+		return nil, fmt.Errorf("%w for snapshot client", ErrNilElasticClient)
+	}
+	for idx, valuesClient := range args.ValuesClients {
+		if check.IfNil(valuesClient) {
+			return nil, fmt.Errorf("%w for values client, index %d", ErrNilElasticClient, idx)
+		}
+	}
+	if check.IfNil(args.PubKeyConverter) {
+		return nil, ErrNilPubKeyConverter
+	}
+	if check.IfNil(args.Hasher) {
+		return nil, ErrNilHasher
+	}
+	if args.Config.OutputDirectory == "" {
+		return nil, ErrEmptyOutputDirectory
+	}
+
+	minAmount, err := parseMinAmount(args.Config.MinAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	weights, err := parseWeights(args.Config.Weights)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eligibilityExporter{
+		snapshotClient:  args.SnapshotClient,
+		valuesClients:   args.ValuesClients,
+		pubKeyConverter: args.PubKeyConverter,
+		hasher:          args.Hasher,
+		outputDirectory: args.Config.OutputDirectory,
+		minAmount:       minAmount,
+		weights:         weights,
+	}, nil
+}
+
+// ExportEligibility will compute the eligible amounts of all the accounts from the provided epoch index, will write
+// the amounts and the merkle proofs files and will index the merkle root
+func (ee *eligibilityExporter) ExportEligibility(index string, epoch uint32) error {
+	log.Info("Exporting eligibility snapshot", "index", index, "epoch", epoch)
+
+	accounts, err := ee.readEligibleAccounts(index)
+	if err != nil {
+		return err
+	}
+	if len(accounts) == 0 {
+		return fmt.Errorf("%w in index %s", ErrNoEligibleAccounts, index)
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].address < accounts[j].address
+	})
+
+	leaves := make([][]byte, len(accounts))
+	for idx, account := range accounts {
+		leaves[idx] = account.leaf
+	}
+	tree := newMerkleTree(leaves, ee.hasher)
+	merkleRoot := hex.EncodeToString(tree.root())
+	log.Info("eligibility snapshot", "num accounts", len(accounts), "merkle root", merkleRoot)
+
+	err = os.MkdirAll(ee.outputDirectory, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	err = ee.writeAmountsFile(accounts, epoch)
+	if err != nil {
+		return err
+	}
+
+	err = ee.writeProofsFile(accounts, tree, merkleRoot, epoch)
+	if err != nil {
+		return err
+	}
+
+	return ee.indexMerkleRoot(merkleRoot, epoch)
+}
+
+func (ee *eligibilityExporter) readEligibleAccounts(index string) ([]*eligibleAccount, error) {
+	accounts := make([]*eligibleAccount, 0)
+	handlerFunc := func(responseBytes []byte) error {
+		accountsResponse := &crossIndex.AllAccountsResponse{}
+		err := json.Unmarshal(responseBytes, accountsResponse)
+		if err != nil {
+			return err
+		}
+
+		for _, hit := range accountsResponse.Hits.Hits {
+			account, errCreate := ee.createEligibleAccount(hit.ID, &hit.Account.StakeInfo)
+			if errCreate != nil {
+				return errCreate
+			}
+			if account == nil {
+				continue
+			}
+
+			accounts = append(accounts, account)
+		}
+
+		return nil
+	}
+
+	err := ee.snapshotClient.DoScrollRequestAllDocuments(index, crossIndex.GetAll().Bytes(), handlerFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// parseMinAmount returns the configured minimum amount, in denominated units, as an amount in base units
+func parseMinAmount(minAmountConfig string) (*big.Rat, error) {
+	if minAmountConfig == "" {
+		return big.NewRat(0, 1), nil
+	}
+
+	minAmount, ok := big.NewRat(0, 1).SetString(minAmountConfig)
+	if !ok || minAmount.Sign() < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMinAmount, minAmountConfig)
+	}
+
+	denominationMultiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(denomination), nil)
+
+	return minAmount.Mul(minAmount, new(big.Rat).SetInt(denominationMultiplier)), nil
+}
+
+func (ee *eligibilityExporter) createEligibleAccount(address string, stakeInfo *data.StakeInfo) (*eligibleAccount, error) {
+	amount := computeWeightedAmount(stakeInfo, ee.weights)
+	if amount.Sign() <= 0 || new(big.Rat).SetInt(amount).Cmp(ee.minAmount) < 0 {
+		return nil, nil
+	}
+
+	addressBytes, err := ee.pubKeyConverter.Decode(address)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode address %s: %w", address, err)
+	}
+
+	return &eligibleAccount{
+		address: address,
+		amount:  amount,
+		leaf:    computeLeaf(ee.hasher, addressBytes, amount),
+	}, nil
+}
+
+// computeLeaf returns keccak256(0x00 | address bytes | amount as 32 bytes big endian)
+func computeLeaf(hasher hashing.Hasher, addressBytes []byte, amount *big.Int) []byte {
+	amountBytes := make([]byte, amountNumBytes)
+	amount.FillBytes(amountBytes)
+
+	return hasher.Compute(string(leafPrefix) + string(addressBytes) + string(amountBytes))
+}
+
+func (ee *eligibilityExporter) writeAmountsFile(accounts []*eligibleAccount, epoch uint32) error {
+	path := filepath.Join(ee.outputDirectory, fmt.Sprintf(amountsFileFormat, epoch))
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	writer := bufio.NewWriter(file)
+	_, err = writer.WriteString("address,amount\n")
+	if err != nil {
+		return err
+	}
+	for _, account := range accounts {
+		_, err = fmt.Fprintf(writer, "%s,%s\n", account.address, account.amount.String())
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Info("wrote eligibility amounts", "path", path)
+
+	return writer.Flush()
+}
+
+func (ee *eligibilityExporter) writeProofsFile(accounts []*eligibleAccount, tree *merkleTree, merkleRoot string, epoch uint32) error {
+	proofs := &proofsFile{
+		Epoch:      epoch,
+		MerkleRoot: merkleRoot,
+		Proofs:     make(map[string]*accountProof, len(accounts)),
+	}
+	for idx, account := range accounts {
+		proof := tree.proof(idx)
+		encodedProof := make([]string, len(proof))
+		for proofIdx := range proof {
+			encodedProof[proofIdx] = hex.EncodeToString(proof[proofIdx])
+		}
+
+		proofs.Proofs[account.address] = &accountProof{
+			Amount: account.amount.String(),
+			Leaf:   hex.EncodeToString(account.leaf),
+			Proof:  encodedProof,
+		}
+	}
+
+	proofsBytes, err := json.MarshalIndent(proofs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(ee.outputDirectory, fmt.Sprintf(proofsFileFormat, epoch))
+	err = os.WriteFile(path, proofsBytes, 0644)
+	if err != nil {
+		return err
+	}
+
+	log.Info("wrote eligibility proofs", "path", path)
+
+	return nil
+}
+
+func (ee *eligibilityExporter) indexMerkleRoot(merkleRoot string, epoch uint32) error {
+	keyValueObj := &data.KeyValueObj{
+		Key:   merkleRootKey,
+		Value: merkleRoot,
+	}
+	keyValueObjBytes, err := json.Marshal(keyValueObj)
+	if err != nil {
+		return err
+	}
+
+	id := fmt.Sprintf(merkleRootDocFormat, epoch)
+	for _, valuesClient := range ee.valuesClients {
+		err = valuesClient.DoRequest(valuesIndex, id, bytes.NewBuffer(keyValueObjBytes))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ee *eligibilityExporter) IsInterfaceNil() bool {
+	return ee == nil
+}
@@ -0,0 +1,175 @@
+package eligibility
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/pubkeyConverter"
+	"github.com/multiversx/mx-chain-core-go/hashing/keccak"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	address1 = "erd10f7nnvqk8xvyd50f2sc5p4e0ru4alf99p3v7zfe4uvenra2esges39a9x7"
+	address2 = "erd1ejjwyzrdj053vcs5nhupxn6kha8audf4mla6tth9339zmcx52w5q7djae2"
+	address3 = "erd1yhhzgv5ql3h8gppy5286grre23vfgw68tnth7dmcl8ywpd9puluqlcvvw9"
+)
+
+func createScrollResponse(accounts map[string]data.StakeInfo) []byte {
+	hits := make([]interface{}, 0, len(accounts))
+	for address, stakeInfo := range accounts {
+		hits = append(hits, map[string]interface{}{
+			"_id":     address,
+			"_source": stakeInfo,
+		})
+	}
+
+	response, _ := json.Marshal(map[string]interface{}{
+		"hits": map[string]interface{}{
+			"hits": hits,
+		},
+	})
+
+	return response
+}
+
+func createArgs(outputDirectory string) ArgsEligibilityExporter {
+	pubKeyConv, _ := pubkeyConverter.NewBech32PubkeyConverter(32, log)
+
+	return ArgsEligibilityExporter{
+		SnapshotClient:  &mocks.ElasticClientStub{},
+		ValuesClients:   []ElasticClientHandler{&mocks.ElasticClientStub{}},
+		PubKeyConverter: pubKeyConv,
+		Hasher:          keccak.NewKeccak(),
+		Config: config.EligibilityConfig{
+			OutputDirectory: outputDirectory,
+			// 10 base units
+			MinAmount: "0.00000000000000001",
+			Weights: map[string]string{
+				"delegation": "1",
+				"energy":     "0.5",
+			},
+		},
+	}
+}
+
+func TestNewEligibilityExporter(t *testing.T) {
+	t.Parallel()
+
+	args := createArgs(t.TempDir())
+	args.SnapshotClient = nil
+	exporter, err := NewEligibilityExporter(args)
+	require.Nil(t, exporter)
+	require.True(t, errors.Is(err, ErrNilElasticClient))
+
+	args = createArgs(t.TempDir())
+	args.Config.Weights = map[string]string{"balance": "1"}
+	exporter, err = NewEligibilityExporter(args)
+	require.Nil(t, exporter)
+	require.True(t, errors.Is(err, ErrInvalidWeightField))
+
+	args = createArgs(t.TempDir())
+	args.Config.Weights = map[string]string{"delegation": "-1"}
+	exporter, err = NewEligibilityExporter(args)
+	require.Nil(t, exporter)
+	require.True(t, errors.Is(err, ErrInvalidWeightValue))
+
+	args = createArgs(t.TempDir())
+	args.Config.MinAmount = "ten"
+	exporter, err = NewEligibilityExporter(args)
+	require.Nil(t, exporter)
+	require.True(t, errors.Is(err, ErrInvalidMinAmount))
+
+	args = createArgs(t.TempDir())
+	args.Config.MinAmount = "-1"
+	exporter, err = NewEligibilityExporter(args)
+	require.Nil(t, exporter)
+	require.True(t, errors.Is(err, ErrInvalidMinAmount))
+
+	exporter, err = NewEligibilityExporter(createArgs(t.TempDir()))
+	require.Nil(t, err)
+	require.False(t, exporter.IsInterfaceNil())
+}
+
+func TestEligibilityExporter_ExportEligibility(t *testing.T) {
+	t.Parallel()
+
+	outputDirectory := t.TempDir()
+	args := createArgs(outputDirectory)
+	args.SnapshotClient = &mocks.ElasticClientStub{
+		DoScrollRequestAllDocumentsCalled: func(index string, body []byte, handlerFunc func(responseBytes []byte) error) error {
+			require.Equal(t, "accounts-000001_10", index)
+			return handlerFunc(createScrollResponse(map[string]data.StakeInfo{
+				address1: {Delegation: "100", Energy: "51"},
+				address2: {Delegation: "5", Energy: "9"},
+				address3: {Energy: "20", TotalStake: "1000"},
+			}))
+		},
+	}
+
+	indexedRoot := ""
+	args.ValuesClients = []ElasticClientHandler{&mocks.ElasticClientStub{
+		DoRequestCalled: func(index, documentID string, buff *bytes.Buffer) error {
+			require.Equal(t, valuesIndex, index)
+			require.Equal(t, "eligibility-merkle-root-10", documentID)
+
+			keyValue := &data.KeyValueObj{}
+			require.Nil(t, json.Unmarshal(buff.Bytes(), keyValue))
+			require.Equal(t, merkleRootKey, keyValue.Key)
+			indexedRoot = keyValue.Value
+
+			return nil
+		},
+	}}
+
+	exporter, _ := NewEligibilityExporter(args)
+	err := exporter.ExportEligibility("accounts-000001_10", 10)
+	require.Nil(t, err)
+
+	amounts, err := os.ReadFile(filepath.Join(outputDirectory, fmt.Sprintf(amountsFileFormat, 10)))
+	require.Nil(t, err)
+	require.Equal(t, "address,amount\n"+address1+",125\n"+address3+",10\n", string(amounts))
+
+	proofsBytes, err := os.ReadFile(filepath.Join(outputDirectory, fmt.Sprintf(proofsFileFormat, 10)))
+	require.Nil(t, err)
+	proofs := &proofsFile{}
+	require.Nil(t, json.Unmarshal(proofsBytes, proofs))
+	require.Equal(t, indexedRoot, proofs.MerkleRoot)
+	require.Len(t, proofs.Proofs, 2)
+
+	root, _ := hex.DecodeString(proofs.MerkleRoot)
+	for _, proof := range proofs.Proofs {
+		leaf, _ := hex.DecodeString(proof.Leaf)
+		siblings := make([][]byte, len(proof.Proof))
+		for idx := range proof.Proof {
+			siblings[idx], _ = hex.DecodeString(proof.Proof[idx])
+		}
+
+		require.True(t, verifyProof(args.Hasher, leaf, siblings, root))
+	}
+}
+
+func TestParseMinAmount(t *testing.T) {
+	t.Parallel()
+
+	minAmount, err := parseMinAmount("")
+	require.Nil(t, err)
+	require.Equal(t, 0, minAmount.Sign())
+
+	minAmount, err = parseMinAmount("1")
+	require.Nil(t, err)
+	require.Equal(t, "1000000000000000000", minAmount.FloatString(0))
+
+	minAmount, err = parseMinAmount("0.5")
+	require.Nil(t, err)
+	require.Equal(t, "500000000000000000", minAmount.FloatString(0))
+}
@@ -0,0 +1,30 @@
+package eligibility
+
+import "errors"
+
+// ErrNilElasticClient signals that a nil elastic client has been provided
+var ErrNilElasticClient = errors.New("nil elastic client")
+
+// ErrNilPubKeyConverter signals that a nil public key converter has been provided
+var ErrNilPubKeyConverter = errors.New("nil public key converter")
+
+// ErrNilHasher signals that a nil hasher has been provided
+var ErrNilHasher = errors.New("nil hasher")
+
+// ErrEmptyOutputDirectory signals that an empty output directory has been provided
+var ErrEmptyOutputDirectory = errors.New("empty output directory")
+
+// ErrNoWeights signals that no weight has been configured
+var ErrNoWeights = errors.New("no weights configured")
+
+// ErrInvalidWeightField signals that a weight has been configured for an unknown stake field
+var ErrInvalidWeightField = errors.New("invalid weight field")
+
+// ErrInvalidWeightValue signals that an invalid weight value has been configured
+var ErrInvalidWeightValue = errors.New("invalid weight value")
+
+// ErrInvalidMinAmount signals that an invalid minimum amount has been configured
+var ErrInvalidMinAmount = errors.New("invalid minimum amount")
+
+// ErrNoEligibleAccounts signals that no account is eligible
+var ErrNoEligibleAccounts = errors.New("no eligible accounts")
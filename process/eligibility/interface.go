@@ -0,0 +1,10 @@
+package eligibility
+
+import "bytes"
+
+// ElasticClientHandler defines what an elastic client should be able to do
+type ElasticClientHandler interface {
+	DoRequest(index, documentID string, buff *bytes.Buffer) error
+	DoScrollRequestAllDocuments(index string, body []byte, handlerFunc func(responseBytes []byte) error) error
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,89 @@
+package eligibility
+
+import (
+	"bytes"
+
+	"github.com/multiversx/mx-chain-core-go/hashing"
+)
+
+const (
+	leafPrefix = byte(0x00)
+	nodePrefix = byte(0x01)
+)
+
+// merkleTree is a binary merkle tree where every parent is the hash of its sorted children, so a proof can be
+// verified without knowing the position of the leaf. A node without a sibling is promoted to the upper level. The
+// leaves and the parents are hashed with different prefixes, so a parent can never be proven as a leaf
+type merkleTree struct {
+	hasher hashing.Hasher
+	levels [][][]byte
+}
+
+func newMerkleTree(leaves [][]byte, hasher hashing.Hasher) *merkleTree {
+	tree := &merkleTree{
+		hasher: hasher,
+		levels: [][][]byte{leaves},
+	}
+
+	currentLevel := leaves
+	for len(currentLevel) > 1 {
+		nextLevel := make([][]byte, 0, (len(currentLevel)+1)/2)
+		for idx := 0; idx < len(currentLevel); idx += 2 {
+			if idx+1 == len(currentLevel) {
+				nextLevel = append(nextLevel, currentLevel[idx])
+				continue
+			}
+
+			nextLevel = append(nextLevel, hashPair(hasher, currentLevel[idx], currentLevel[idx+1]))
+		}
+
+		tree.levels = append(tree.levels, nextLevel)
+		currentLevel = nextLevel
+	}
+
+	return tree
+}
+
+// root returns the root of the tree or nil if the tree has no leaves
+func (mt *merkleTree) root() []byte {
+	lastLevel := mt.levels[len(mt.levels)-1]
+	if len(lastLevel) == 0 {
+		return nil
+	}
+
+	return lastLevel[0]
+}
+
+// proof returns the siblings needed to compute the root starting from the leaf with the provided index
+func (mt *merkleTree) proof(leafIndex int) [][]byte {
+	proof := make([][]byte, 0, len(mt.levels))
+	idx := leafIndex
+	for _, level := range mt.levels[:len(mt.levels)-1] {
+		siblingIdx := idx ^ 1
+		if siblingIdx < len(level) {
+			proof = append(proof, level[siblingIdx])
+		}
+
+		idx /= 2
+	}
+
+	return proof
+}
+
+func verifyProof(hasher hashing.Hasher, leaf []byte, proof [][]byte, root []byte) bool {
+	computedHash := leaf
+	for _, sibling := range proof {
+		computedHash = hashPair(hasher, computedHash, sibling)
+	}
+
+	return bytes.Equal(computedHash, root)
+}
+
+// hashPair returns keccak256(0x01 | smaller child | greater child)
+func hashPair(hasher hashing.Hasher, first []byte, second []byte) []byte {
+	if bytes.Compare(first, second) > 0 {
+		first, second = second, first
+	}
+
+	return hasher.Compute(string(nodePrefix) + string(first) + string(second))
+}
@@ -0,0 +1,60 @@
+package eligibility
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/hashing/keccak"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerkleTree_ProofsShouldVerify(t *testing.T) {
+	t.Parallel()
+
+	hasher := keccak.NewKeccak()
+	for numLeaves := 1; numLeaves <= 9; numLeaves++ {
+		leaves := make([][]byte, numLeaves)
+		for idx := range leaves {
+			leaves[idx] = hasher.Compute(fmt.Sprintf("leaf %d", idx))
+		}
+
+		tree := newMerkleTree(leaves, hasher)
+		root := tree.root()
+		require.NotNil(t, root)
+
+		for idx, leaf := range leaves {
+			require.True(t, verifyProof(hasher, leaf, tree.proof(idx), root), "num leaves %d, leaf %d", numLeaves, idx)
+		}
+
+		require.False(t, verifyProof(hasher, hasher.Compute("not a leaf"), tree.proof(0), root))
+	}
+}
+
+func TestMerkleTree_EmptyTree(t *testing.T) {
+	t.Parallel()
+
+	tree := newMerkleTree(make([][]byte, 0), keccak.NewKeccak())
+	require.Nil(t, tree.root())
+}
+
+func TestMerkleTree_ParentCannotBeProvenAsLeaf(t *testing.T) {
+	t.Parallel()
+
+	hasher := keccak.NewKeccak()
+	leaves := make([][]byte, 4)
+	for idx := range leaves {
+		leaves[idx] = computeLeaf(hasher, hasher.Compute(fmt.Sprintf("address %d", idx)), big.NewInt(int64(idx+1)))
+	}
+	tree := newMerkleTree(leaves, hasher)
+	root := tree.root()
+
+	// the children of the first parent, read as a 32 bytes address followed by a 32 bytes amount
+	first, second := leaves[0], leaves[1]
+	if string(first) > string(second) {
+		first, second = second, first
+	}
+	forgedLeaf := computeLeaf(hasher, first, new(big.Int).SetBytes(second))
+	require.False(t, verifyProof(hasher, forgedLeaf, tree.proof(0)[1:], root))
+	require.NotEqual(t, hasher.Compute(string(first)+string(second)), hashPair(hasher, first, second))
+}
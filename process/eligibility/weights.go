@@ -0,0 +1,74 @@
+package eligibility
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+var stakeInfoFields = map[string]func(stakeInfo *data.StakeInfo) string{
+	"delegationLegacyWaiting": func(stakeInfo *data.StakeInfo) string { return stakeInfo.DelegationLegacyWaiting },
+	"delegationLegacyActive":  func(stakeInfo *data.StakeInfo) string { return stakeInfo.DelegationLegacyActive },
+	"validatorsActive":        func(stakeInfo *data.StakeInfo) string { return stakeInfo.ValidatorsActive },
+	"validatorsTopUp":         func(stakeInfo *data.StakeInfo) string { return stakeInfo.ValidatorTopUp },
+	"delegation":              func(stakeInfo *data.StakeInfo) string { return stakeInfo.Delegation },
+	"totalStake":              func(stakeInfo *data.StakeInfo) string { return stakeInfo.TotalStake },
+	"lkMexStake":              func(stakeInfo *data.StakeInfo) string { return stakeInfo.LKMEXStake },
+	"energy":                  func(stakeInfo *data.StakeInfo) string { return stakeInfo.Energy },
+}
+
+type weight struct {
+	field    string
+	getValue func(stakeInfo *data.StakeInfo) string
+	value    *big.Rat
+}
+
+// parseWeights will parse the configured weights. The returned weights are sorted by field name so the amounts are
+// always computed in the same order
+func parseWeights(weightsConfig map[string]string) ([]*weight, error) {
+	if len(weightsConfig) == 0 {
+		return nil, ErrNoWeights
+	}
+
+	weights := make([]*weight, 0, len(weightsConfig))
+	for field, weightValue := range weightsConfig {
+		getValue, ok := stakeInfoFields[field]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidWeightField, field)
+		}
+
+		value, ok := big.NewRat(0, 1).SetString(weightValue)
+		if !ok || value.Sign() < 0 {
+			return nil, fmt.Errorf("%w: %s for field %s", ErrInvalidWeightValue, weightValue, field)
+		}
+
+		weights = append(weights, &weight{
+			field:    field,
+			getValue: getValue,
+			value:    value,
+		})
+	}
+
+	sort.Slice(weights, func(i, j int) bool {
+		return weights[i].field < weights[j].field
+	})
+
+	return weights, nil
+}
+
+// computeWeightedAmount returns the sum of every weighted stake field, rounded down
+func computeWeightedAmount(stakeInfo *data.StakeInfo, weights []*weight) *big.Int {
+	total := big.NewRat(0, 1)
+	for _, w := range weights {
+		value, ok := big.NewRat(0, 1).SetString(w.getValue(stakeInfo))
+		if !ok {
+			continue
+		}
+
+		total.Add(total, value.Mul(value, w.value))
+	}
+
+	return big.NewInt(0).Quo(total.Num(), total.Denom())
+}
@@ -69,21 +69,12 @@ func (ag *accountsGetter) extractAddressesAndEnergy(accountStorage []byte, curre
 			continue
 		}
 
-		energyValue := calculateEnergyValueBasedOnCurrentEpoch(energyDetails, currentEpoch)
-
-		// ignore addresses with energyValue less or equal to zero
-		zero := big.NewInt(0)
-		if zero.Cmp(energyValue) > 0 {
+		account, ok := energyToAccount(energyDetails, currentEpoch)
+		if !ok {
 			continue
 		}
 
-		accountsWithEnergy[address] = &data.AccountInfoWithStakeValues{
-			StakeInfo: data.StakeInfo{
-				Energy:        energyValue.String(),
-				EnergyNum:     core.ComputeBalanceAsFloat(energyValue.String()),
-				EnergyDetails: energyDetails,
-			},
-		}
+		accountsWithEnergy[address] = account
 	}
 
 	log.Info("accounts with energy", "num", len(accountsWithEnergy))
@@ -91,6 +82,25 @@ func (ag *accountsGetter) extractAddressesAndEnergy(accountStorage []byte, curre
 	return accountsWithEnergy, nil
 }
 
+// energyToAccount will compute the energy at the current epoch. The accounts with a negative energy are ignored
+func energyToAccount(energyDetails *data.EnergyDetails, currentEpoch uint32) (*data.AccountInfoWithStakeValues, bool) {
+	energyValue := calculateEnergyValueBasedOnCurrentEpoch(energyDetails, currentEpoch)
+
+	// ignore addresses with energyValue less or equal to zero
+	zero := big.NewInt(0)
+	if zero.Cmp(energyValue) > 0 {
+		return nil, false
+	}
+
+	return &data.AccountInfoWithStakeValues{
+		StakeInfo: data.StakeInfo{
+			Energy:        energyValue.String(),
+			EnergyNum:     core.ComputeBalanceAsFloat(energyValue.String()),
+			EnergyDetails: energyDetails,
+		},
+	}, true
+}
+
 func (ag *accountsGetter) extractAddressFromKey(key string) (string, bool) {
 	hasPrefix := strings.HasPrefix(key, hexEncodedEnergyPrefix)
 	if !hasPrefix {
@@ -13,3 +13,36 @@ var ErrNilReindexer = errors.New("nil reindexer")
 
 // ErrNilCloner signals that a nil cloner has been provided
 var ErrNilCloner = errors.New("nil cloner")
+
+// ErrInvalidDestinationType signals that an unknown destination type has been provided
+var ErrInvalidDestinationType = errors.New("invalid destination type")
+
+// ErrEmptyDestinationConfig signals that a destination type is enabled but it has no configuration
+var ErrEmptyDestinationConfig = errors.New("empty destination config")
+
+// ErrNilAccountSources signals that a nil account sources handler has been provided
+var ErrNilAccountSources = errors.New("nil account sources handler")
+
+// ErrConfig is the category of the errors caused by an invalid configuration
+var ErrConfig = errors.New("configuration error")
+
+// ErrInvalidConfig signals that the configuration failed the validation
+var ErrInvalidConfig = errors.New("invalid configuration")
+
+// ErrUpstreamAPI is the category of the errors returned while fetching the accounts from the API
+var ErrUpstreamAPI = errors.New("upstream API error")
+
+// ErrElasticsearch is the category of the errors returned while reading the source index or writing the destinations
+var ErrElasticsearch = errors.New("elasticsearch error")
+
+// ErrGuardrailsViolated is the category of the runs whose merged accounts do not satisfy the configured guardrails
+var ErrGuardrailsViolated = errors.New("guardrails violated")
+
+// ErrNilAnomalyDetector signals that a nil anomaly detector has been provided
+var ErrNilAnomalyDetector = errors.New("nil anomaly detector")
+
+// ErrNilNotifier signals that a nil notifier has been provided
+var ErrNilNotifier = errors.New("nil notifier")
+
+// ErrNilMetricsHandler signals that a nil metrics handler has been provided
+var ErrNilMetricsHandler = errors.New("nil metrics handler")
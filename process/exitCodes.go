@@ -0,0 +1,106 @@
+package process
+
+import (
+	"errors"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/snapshots"
+)
+
+const (
+	// ExitCodeSuccess is the exit code of a successful run
+	ExitCodeSuccess = 0
+	// ExitCodeFailure is the exit code of an error without a category
+	ExitCodeFailure = 1
+	// ExitCodeConfig is the exit code of an invalid configuration
+	ExitCodeConfig = 2
+	// ExitCodeUpstreamAPI is the exit code of an error returned by the API
+	ExitCodeUpstreamAPI = 3
+	// ExitCodeElasticsearch is the exit code of an error returned by the source index or the destinations
+	ExitCodeElasticsearch = 4
+	// ExitCodeVerification is the exit code of a run whose written index failed the verification
+	ExitCodeVerification = 5
+	// ExitCodeGuardrails is the exit code of a run blocked by the guardrails
+	ExitCodeGuardrails = 6
+)
+
+const (
+	errorCategoryConfig        = "config"
+	errorCategoryUpstreamAPI   = "upstreamAPI"
+	errorCategoryElasticsearch = "elasticsearch"
+	errorCategoryVerification  = "verification"
+	errorCategoryGuardrails    = "guardrails"
+	errorCategoryOther         = "other"
+)
+
+type categorizedError struct {
+	category error
+	err      error
+}
+
+// Error returns the message of the wrapped error, prefixed by its category
+func (ce *categorizedError) Error() string {
+	return ce.category.Error() + ": " + ce.err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (ce *categorizedError) Unwrap() error {
+	return ce.err
+}
+
+// Is returns true if the target is the category of the error
+func (ce *categorizedError) Is(target error) bool {
+	return target == ce.category
+}
+
+// NewCategorizedError will wrap the provided error in the provided category, one of ErrConfig, ErrUpstreamAPI and
+// ErrElasticsearch. An error that already has a category is returned unchanged
+func NewCategorizedError(category error, err error) error {
+	if err == nil || ErrorCategory(err) != errorCategoryOther {
+		return err
+	}
+
+	return &categorizedError{
+		category: category,
+		err:      err,
+	}
+}
+
+// ErrorCategory returns the name of the category of the provided error, empty for a nil error
+func ErrorCategory(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrConfig):
+		return errorCategoryConfig
+	case errors.Is(err, ErrGuardrailsViolated):
+		return errorCategoryGuardrails
+	case errors.Is(err, ErrUpstreamAPI):
+		return errorCategoryUpstreamAPI
+	case errors.Is(err, snapshots.ErrVerificationFailed):
+		return errorCategoryVerification
+	case errors.Is(err, ErrElasticsearch):
+		return errorCategoryElasticsearch
+	default:
+		return errorCategoryOther
+	}
+}
+
+// ExitCode returns the exit code of the process for the provided error
+func ExitCode(err error) int {
+	switch ErrorCategory(err) {
+	case "":
+		return ExitCodeSuccess
+	case errorCategoryConfig:
+		return ExitCodeConfig
+	case errorCategoryUpstreamAPI:
+		return ExitCodeUpstreamAPI
+	case errorCategoryVerification:
+		return ExitCodeVerification
+	case errorCategoryGuardrails:
+		return ExitCodeGuardrails
+	case errorCategoryElasticsearch:
+		return ExitCodeElasticsearch
+	default:
+		return ExitCodeFailure
+	}
+}
@@ -0,0 +1,41 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/snapshots"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCategorizedError(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, NewCategorizedError(ErrConfig, nil))
+
+	originalErr := errors.New("connection refused")
+	err := NewCategorizedError(ErrUpstreamAPI, originalErr)
+	require.True(t, errors.Is(err, ErrUpstreamAPI))
+	require.True(t, errors.Is(err, originalErr))
+	require.Equal(t, "upstream API error: connection refused", err.Error())
+
+	// the first category is kept
+	wrappedErr := NewCategorizedError(ErrElasticsearch, fmt.Errorf("run failed: %w", err))
+	require.True(t, errors.Is(wrappedErr, ErrUpstreamAPI))
+	require.False(t, errors.Is(wrappedErr, ErrElasticsearch))
+}
+
+func TestExitCode(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, ExitCodeSuccess, ExitCode(nil))
+	require.Equal(t, ExitCodeFailure, ExitCode(errors.New("error")))
+	require.Equal(t, ExitCodeConfig, ExitCode(NewCategorizedError(ErrConfig, errors.New("error"))))
+	require.Equal(t, ExitCodeUpstreamAPI, ExitCode(NewCategorizedError(ErrUpstreamAPI, errors.New("error"))))
+	require.Equal(t, ExitCodeElasticsearch, ExitCode(NewCategorizedError(ErrElasticsearch, errors.New("error"))))
+	require.Equal(t, ExitCodeVerification, ExitCode(fmt.Errorf("%w: client 0", snapshots.ErrVerificationFailed)))
+	require.Equal(t, ExitCodeGuardrails, ExitCode(fmt.Errorf("%w: 1 rule(s) violated", ErrGuardrailsViolated)))
+	require.Equal(t, "verification", ErrorCategory(snapshots.ErrVerificationFailed))
+	require.Equal(t, "guardrails", ErrorCategory(ErrGuardrailsViolated))
+}
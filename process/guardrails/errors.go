@@ -0,0 +1,12 @@
+package guardrails
+
+import "errors"
+
+// ErrUnknownSource signals that a guardrail refers to an unknown stake source
+var ErrUnknownSource = errors.New("unknown source")
+
+// ErrInvalidRule signals that a guardrail has an invalid rule
+var ErrInvalidRule = errors.New("invalid guardrail rule")
+
+// ErrNilAccountsData signals that nil accounts data has been provided
+var ErrNilAccountsData = errors.New("nil accounts data")
@@ -0,0 +1,210 @@
+package guardrails
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex/reindexer"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+const (
+	// RuleMinAccounts is the rule violated by a source that returned fewer accounts than configured
+	RuleMinAccounts = "minAccounts"
+	// RuleMaxTotalStakeChange is the rule violated by a source whose total changed too much since the previous epoch
+	RuleMaxTotalStakeChange = "maxTotalStakeChange"
+	// RuleNonZeroTotal is the rule violated by an enabled source with a zero total
+	RuleNonZeroTotal = "nonZeroTotal"
+)
+
+var log = logger.GetOrCreate("process/guardrails")
+
+var knownSources = map[string]struct{}{
+	data.SourceLegacyDelegation: {},
+	data.SourceValidators:       {},
+	data.SourceDelegation:       {},
+	data.SourceLKMEX:            {},
+	data.SourceEnergy:           {},
+}
+
+// Violation holds a guardrail that the merged accounts of a run do not satisfy
+type Violation struct {
+	Source  string `json:"source"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// String returns a human readable description of the violation
+func (v *Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Source, v.Message)
+}
+
+// ArgsGuardrails holds the arguments needed to create a new guardrails checker
+type ArgsGuardrails struct {
+	Config         config.GuardrailsConfig
+	EnabledSources []string
+	// StakeStatsGetter is optional, a nil value disables the checks against the previous epoch
+	StakeStatsGetter StakeStatsGetter
+}
+
+type guardrails struct {
+	sources              []config.SourceGuardrailConfig
+	enabledSources       []string
+	requireNonZeroTotals bool
+	stakeStatsGetter     StakeStatsGetter
+}
+
+// NewGuardrails will create a new instance of guardrails
+func NewGuardrails(args ArgsGuardrails) (*guardrails, error) {
+	enabledSources := make(map[string]struct{}, len(args.EnabledSources))
+	for _, source := range args.EnabledSources {
+		if _, ok := knownSources[source]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSource, source)
+		}
+		enabledSources[source] = struct{}{}
+	}
+
+	configuredSources := make(map[string]struct{}, len(args.Config.Sources))
+	for _, sourceCfg := range args.Config.Sources {
+		if _, ok := knownSources[sourceCfg.Name]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSource, sourceCfg.Name)
+		}
+		if _, ok := enabledSources[sourceCfg.Name]; !ok {
+			return nil, fmt.Errorf("%w for %s: the source is disabled", ErrInvalidRule, sourceCfg.Name)
+		}
+		if _, ok := configuredSources[sourceCfg.Name]; ok {
+			return nil, fmt.Errorf("%w for %s: duplicated source", ErrInvalidRule, sourceCfg.Name)
+		}
+		if sourceCfg.MinAccounts < 0 || sourceCfg.MaxTotalStakeChangePercent < 0 {
+			return nil, fmt.Errorf("%w for %s: negative limit", ErrInvalidRule, sourceCfg.Name)
+		}
+		configuredSources[sourceCfg.Name] = struct{}{}
+	}
+
+	return &guardrails{
+		sources:              args.Config.Sources,
+		enabledSources:       args.EnabledSources,
+		requireNonZeroTotals: args.Config.RequireNonZeroTotals,
+		stakeStatsGetter:     args.StakeStatsGetter,
+	}, nil
+}
+
+// Check will return the guardrails that the accounts with stake fetched from the API do not satisfy. The total of
+// every source is compared with the fetched total of the latest previous snapshot, if any
+func (g *guardrails) Check(accountsData *data.AccountsData) ([]*Violation, error) {
+	if accountsData == nil {
+		return nil, ErrNilAccountsData
+	}
+
+	totals := reindexer.ComputeSourcesStakeStats(accountsData)
+	violations := make([]*Violation, 0)
+	if g.requireNonZeroTotals {
+		violations = append(violations, checkNonZeroTotals(g.enabledSources, totals)...)
+	}
+
+	numAccounts := make(map[string]int, len(accountsData.SourcesStats))
+	for _, sourceStats := range accountsData.SourcesStats {
+		numAccounts[sourceStats.Name] = sourceStats.NumAccounts
+	}
+	for _, sourceCfg := range g.sources {
+		if sourceCfg.MinAccounts > 0 && numAccounts[sourceCfg.Name] < sourceCfg.MinAccounts {
+			violations = append(violations, &Violation{
+				Source:  sourceCfg.Name,
+				Rule:    RuleMinAccounts,
+				Message: fmt.Sprintf("returned %d accounts, expected at least %d", numAccounts[sourceCfg.Name], sourceCfg.MinAccounts),
+			})
+		}
+	}
+
+	changeViolations, err := g.checkTotalStakeChanges(accountsData.Epoch, totals)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(violations, changeViolations...), nil
+}
+
+func checkNonZeroTotals(enabledSources []string, totals map[string]*data.SourceStakeStats) []*Violation {
+	violations := make([]*Violation, 0)
+	for _, source := range enabledSources {
+		if totals[source].NumStakers > 0 {
+			continue
+		}
+
+		violations = append(violations, &Violation{
+			Source:  source,
+			Rule:    RuleNonZeroTotal,
+			Message: "the total is zero",
+		})
+	}
+
+	return violations
+}
+
+func (g *guardrails) checkTotalStakeChanges(epoch uint32, totals map[string]*data.SourceStakeStats) ([]*Violation, error) {
+	violations := make([]*Violation, 0)
+	if check.IfNil(g.stakeStatsGetter) || !g.hasTotalStakeChangeRules() {
+		return violations, nil
+	}
+
+	previous, err := g.stakeStatsGetter.GetPreviousStakeStats(epoch)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch the stake stats of the previous epoch: %w", err)
+	}
+	if previous == nil {
+		log.Info("no previous stake stats found, the total stake changes are not checked", "epoch", epoch)
+		return violations, nil
+	}
+
+	for _, sourceCfg := range g.sources {
+		if sourceCfg.MaxTotalStakeChangePercent == 0 {
+			continue
+		}
+
+		previousTotal := getPreviousTotal(previous, sourceCfg.Name)
+		if previousTotal == 0 {
+			continue
+		}
+
+		changePercent := (totals[sourceCfg.Name].TotalStakeNum - previousTotal) / previousTotal * 100
+		if math.Abs(changePercent) <= sourceCfg.MaxTotalStakeChangePercent {
+			continue
+		}
+
+		violations = append(violations, &Violation{
+			Source: sourceCfg.Name,
+			Rule:   RuleMaxTotalStakeChange,
+			Message: fmt.Sprintf("the total changed by %.2f%% since epoch %d, from %v to %v, more than the allowed %v%%",
+				changePercent, previous.Epoch, previousTotal, totals[sourceCfg.Name].TotalStakeNum, sourceCfg.MaxTotalStakeChangePercent),
+		})
+	}
+
+	return violations, nil
+}
+
+func (g *guardrails) hasTotalStakeChangeRules() bool {
+	for _, sourceCfg := range g.sources {
+		if sourceCfg.MaxTotalStakeChangePercent > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getPreviousTotal(previous *data.StakeStats, source string) float64 {
+	sourceStats, ok := previous.FetchedSources[source]
+	if !ok {
+		return 0
+	}
+
+	return sourceStats.TotalStakeNum
+}
+
+// IsInterfaceNil returns true if the value under the interface is nil
+func (g *guardrails) IsInterfaceNil() bool {
+	return g == nil
+}
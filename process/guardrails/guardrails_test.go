@@ -0,0 +1,180 @@
+package guardrails
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+type stakeStatsGetterStub struct {
+	stakeStats *data.StakeStats
+	err        error
+	numCalls   int
+}
+
+func (stub *stakeStatsGetterStub) GetPreviousStakeStats(_ uint32) (*data.StakeStats, error) {
+	stub.numCalls++
+	return stub.stakeStats, stub.err
+}
+
+func (stub *stakeStatsGetterStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+var allSources = []string{data.SourceLegacyDelegation, data.SourceValidators, data.SourceDelegation, data.SourceLKMEX, data.SourceEnergy}
+
+func createAccountsData() *data.AccountsData {
+	return &data.AccountsData{
+		Epoch: 10,
+		AccountsWithStake: map[string]*data.AccountInfoWithStakeValues{
+			"erd1a": {StakeInfo: data.StakeInfo{Delegation: "1000000000000000000000", ValidatorsActive: "2500000000000000000000"}},
+			"erd1b": {StakeInfo: data.StakeInfo{Delegation: "1000000000000000000000", Energy: "5000000000000000000"}},
+		},
+		SourcesStats: []*data.SourceFetchStats{
+			{Name: data.SourceDelegation, NumAccounts: 2},
+			{Name: data.SourceValidators, NumAccounts: 1},
+			{Name: data.SourceEnergy, NumAccounts: 1},
+		},
+	}
+}
+
+func TestNewGuardrails(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewGuardrails(ArgsGuardrails{EnabledSources: []string{"unknown"}})
+	require.True(t, errors.Is(err, ErrUnknownSource))
+
+	_, err = NewGuardrails(ArgsGuardrails{
+		Config:         config.GuardrailsConfig{Sources: []config.SourceGuardrailConfig{{Name: "unknown"}}},
+		EnabledSources: allSources,
+	})
+	require.True(t, errors.Is(err, ErrUnknownSource))
+
+	_, err = NewGuardrails(ArgsGuardrails{
+		Config:         config.GuardrailsConfig{Sources: []config.SourceGuardrailConfig{{Name: data.SourceLKMEX, MinAccounts: 1}}},
+		EnabledSources: []string{data.SourceDelegation},
+	})
+	require.True(t, errors.Is(err, ErrInvalidRule))
+
+	_, err = NewGuardrails(ArgsGuardrails{
+		Config:         config.GuardrailsConfig{Sources: []config.SourceGuardrailConfig{{Name: data.SourceDelegation, MinAccounts: -1}}},
+		EnabledSources: allSources,
+	})
+	require.True(t, errors.Is(err, ErrInvalidRule))
+
+	_, err = NewGuardrails(ArgsGuardrails{
+		Config: config.GuardrailsConfig{Sources: []config.SourceGuardrailConfig{
+			{Name: data.SourceDelegation, MinAccounts: 1},
+			{Name: data.SourceDelegation, MaxTotalStakeChangePercent: 1},
+		}},
+		EnabledSources: allSources,
+	})
+	require.True(t, errors.Is(err, ErrInvalidRule))
+
+	g, err := NewGuardrails(ArgsGuardrails{EnabledSources: allSources})
+	require.Nil(t, err)
+	require.False(t, g.IsInterfaceNil())
+}
+
+func TestGuardrails_CheckNilAccountsData(t *testing.T) {
+	t.Parallel()
+
+	g, _ := NewGuardrails(ArgsGuardrails{})
+	_, err := g.Check(nil)
+	require.Equal(t, ErrNilAccountsData, err)
+}
+
+func TestGuardrails_CheckMinAccountsAndNonZeroTotals(t *testing.T) {
+	t.Parallel()
+
+	g, _ := NewGuardrails(ArgsGuardrails{
+		Config: config.GuardrailsConfig{
+			RequireNonZeroTotals: true,
+			Sources: []config.SourceGuardrailConfig{
+				{Name: data.SourceDelegation, MinAccounts: 2},
+				{Name: data.SourceValidators, MinAccounts: 5},
+			},
+		},
+		EnabledSources: []string{data.SourceLegacyDelegation, data.SourceValidators, data.SourceDelegation, data.SourceEnergy},
+	})
+
+	violations, err := g.Check(createAccountsData())
+	require.Nil(t, err)
+	require.Equal(t, []*Violation{
+		{Source: data.SourceLegacyDelegation, Rule: RuleNonZeroTotal, Message: "the total is zero"},
+		{Source: data.SourceValidators, Rule: RuleMinAccounts, Message: "returned 1 accounts, expected at least 5"},
+	}, violations)
+	require.Equal(t, "validators: returned 1 accounts, expected at least 5", violations[1].String())
+}
+
+func TestGuardrails_CheckTotalStakeChanges(t *testing.T) {
+	t.Parallel()
+
+	getter := &stakeStatsGetterStub{
+		stakeStats: &data.StakeStats{
+			Epoch: 9,
+			FetchedSources: map[string]*data.SourceStakeStats{
+				data.SourceDelegation: {TotalStakeNum: 2100},
+				data.SourceValidators: {TotalStakeNum: 5000},
+				data.SourceLKMEX:      {TotalStakeNum: 0},
+				data.SourceEnergy:     {TotalStakeNum: 5},
+			},
+			// the totals of the indexed accounts are not compared with the fetched ones
+			Sources: map[string]*data.SourceStakeStats{
+				data.SourceDelegation: {TotalStakeNum: 1},
+			},
+			TotalEnergyNum: 1,
+		},
+	}
+	g, _ := NewGuardrails(ArgsGuardrails{
+		Config: config.GuardrailsConfig{
+			Sources: []config.SourceGuardrailConfig{
+				{Name: data.SourceDelegation, MaxTotalStakeChangePercent: 10},
+				{Name: data.SourceValidators, MaxTotalStakeChangePercent: 10},
+				{Name: data.SourceLKMEX, MaxTotalStakeChangePercent: 10},
+				{Name: data.SourceEnergy, MaxTotalStakeChangePercent: 10},
+			},
+		},
+		EnabledSources:   allSources,
+		StakeStatsGetter: getter,
+	})
+
+	violations, err := g.Check(createAccountsData())
+	require.Nil(t, err)
+	require.Len(t, violations, 1)
+	require.Equal(t, data.SourceValidators, violations[0].Source)
+	require.Equal(t, RuleMaxTotalStakeChange, violations[0].Rule)
+	require.Contains(t, violations[0].Message, "-50.00% since epoch 9")
+
+	// the first snapshot has nothing to be compared with
+	getter.stakeStats = nil
+	violations, err = g.Check(createAccountsData())
+	require.Nil(t, err)
+	require.Empty(t, violations)
+
+	expectedErr := errors.New("expected error")
+	getter.err = expectedErr
+	_, err = g.Check(createAccountsData())
+	require.True(t, errors.Is(err, expectedErr))
+}
+
+func TestGuardrails_CheckWithoutTotalStakeChangeRulesDoesNotFetchThePreviousStats(t *testing.T) {
+	t.Parallel()
+
+	getter := &stakeStatsGetterStub{}
+	g, _ := NewGuardrails(ArgsGuardrails{
+		Config: config.GuardrailsConfig{
+			Sources: []config.SourceGuardrailConfig{{Name: data.SourceDelegation, MinAccounts: 1}},
+		},
+		EnabledSources:   allSources,
+		StakeStatsGetter: getter,
+	})
+
+	violations, err := g.Check(createAccountsData())
+	require.Nil(t, err)
+	require.Empty(t, violations)
+	require.Equal(t, 0, getter.numCalls)
+}
@@ -0,0 +1,9 @@
+package guardrails
+
+import "github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+
+// StakeStatsGetter defines what the component fetching the stake statistics of the previous run should be able to do
+type StakeStatsGetter interface {
+	GetPreviousStakeStats(epoch uint32) (*data.StakeStats, error)
+	IsInterfaceNil() bool
+}
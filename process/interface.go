@@ -2,8 +2,13 @@ package process
 
 import (
 	"bytes"
+	"time"
 
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/guardrails"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/notifier"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/retention"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/snapshots"
 )
 
 // ElasticClientHandler defines what an elastic client should be able to do
@@ -25,6 +30,7 @@ type RestClientHandler interface {
 type AccountsIndexerHandler interface {
 	GetAccounts(addresses []string, index string) (map[string]*data.AccountInfoWithStakeValues, error)
 	IndexAccounts(accounts map[string]*data.AccountInfoWithStakeValues, index string) error
+	IndexAccountsRanks(ranks map[string]*data.AccountRanks, index string) error
 	IsInterfaceNil() bool
 }
 
@@ -45,6 +51,12 @@ type AccountsGetterHandler interface {
 	GetAccountsWithEnergy(currentEpoch uint32) (map[string]*data.AccountInfoWithStakeValues, *data.BlockInfo, error)
 }
 
+// AccountSourcesHandler defines what an account sources getter should be able to do
+type AccountSourcesHandler interface {
+	GetAccountSources(address string, currentEpoch uint32) (*AccountSources, error)
+	IsInterfaceNil() bool
+}
+
 // Cloner defines what a clone should be able to do
 type Cloner interface {
 	CloneIndex(index, newIndex string, body *bytes.Buffer) error
@@ -54,10 +66,121 @@ type Cloner interface {
 // Reindexer defines what a reindexer should be able to do
 type Reindexer interface {
 	ReindexAccounts(sourceIndex string, destinationIndex string, accountsData *data.AccountsData) error
+	GetReindexSummary() *data.ReindexSummary
+	Close() error
 	IsInterfaceNil() bool
 }
 
 // DataProcessor defines what a data processor should be able to do
 type DataProcessor interface {
+	GetCurrentEpoch() (uint32, error)
 	ProcessAccountsData() error
+	GetRunReport() *RunReport
+	Close() error
+}
+
+// DestinationStatsHandler defines what a destination that counts its written accounts should be able to do
+type DestinationStatsHandler interface {
+	GetStats() *data.DestinationStats
+}
+
+// BulkStatsHandler defines what an elastic client that counts its bulk requests should be able to do
+type BulkStatsHandler interface {
+	GetBulkStats() *data.BulkStats
+}
+
+// RunReportWriter defines what the writer of the run reports should be able to do
+type RunReportWriter interface {
+	Write(report *RunReport) error
+	IsInterfaceNil() bool
+}
+
+// MetricsHandler defines what the collector of the metrics of the manager should be able to do
+type MetricsHandler interface {
+	ObserveSourceFetch(source string, numAccounts int, duration time.Duration)
+	AddScrollPage()
+	AddDestinationBatch(destination string, err error)
+	ObserveRESTRequest(endpoint string, duration time.Duration, err error)
+	ObserveRun(epoch uint32, duration time.Duration, err error)
+	IsInterfaceNil() bool
+}
+
+// RunNotifier defines what the component notifying the hooks after every run should be able to do
+type RunNotifier interface {
+	Notify(report *RunReport) error
+	IsInterfaceNil() bool
+}
+
+// Notifier defines what a hook notified after every run should be able to do
+type Notifier interface {
+	Notify(notification *notifier.Notification) error
+	IsSubscribed(event string) bool
+	IsInterfaceNil() bool
+}
+
+// AnomalyDetector defines what the component comparing the stake statistics of two runs should be able to do
+type AnomalyDetector interface {
+	Detect(previous *data.StakeStats, current *data.StakeStats) ([]*notifier.Anomaly, error)
+	IsInterfaceNil() bool
+}
+
+// Guardrails defines what the component checking the sanity rules of the merged accounts should be able to do
+type Guardrails interface {
+	Check(accountsData *data.AccountsData) ([]*guardrails.Violation, error)
+	IsInterfaceNil() bool
+}
+
+// StakeStatsGetter defines what the component fetching the stake statistics of the previous run should be able to do
+type StakeStatsGetter interface {
+	GetPreviousStakeStats(epoch uint32) (*data.StakeStats, error)
+	IsInterfaceNil() bool
+}
+
+// EligibilityExporter defines what an eligibility exporter should be able to do
+type EligibilityExporter interface {
+	ExportEligibility(index string, epoch uint32) error
+	IsInterfaceNil() bool
+}
+
+// RetentionManager defines what a retention manager should be able to do
+type RetentionManager interface {
+	Prune() (*retention.PruneResult, error)
+	IsInterfaceNil() bool
+}
+
+// AccountInspector defines what an account inspector should be able to do
+type AccountInspector interface {
+	Inspect(address string, index string) (*AccountInspection, error)
+	IsInterfaceNil() bool
+}
+
+// SnapshotsLister defines what a snapshots lister should be able to do
+type SnapshotsLister interface {
+	ListSnapshots() ([]*snapshots.SnapshotInfo, error)
+	GetLatestSnapshot() (*snapshots.SnapshotInfo, error)
+	IsInterfaceNil() bool
+}
+
+// SnapshotVerifier defines what a snapshot verifier should be able to do
+type SnapshotVerifier interface {
+	Verify(index string, epoch uint32) ([]*snapshots.VerificationResult, error)
+	IsInterfaceNil() bool
+}
+
+// RunVerifier defines what the verifier of the index written by a run should be able to do
+type RunVerifier interface {
+	VerifyRun(sourceIndex string, index string, summary *data.ReindexSummary) ([]*snapshots.VerificationResult, error)
+	IsInterfaceNil() bool
+}
+
+// SnapshotExporter defines what a snapshot exporter should be able to do
+type SnapshotExporter interface {
+	Export(index string, epoch uint32) error
+	IsInterfaceNil() bool
+}
+
+// SnapshotsDiffer defines what a snapshots differ should be able to do
+type SnapshotsDiffer interface {
+	Diff(indexBefore string, indexAfter string) (*snapshots.DiffResult, error)
+	IsInterfaceNil() bool
 }
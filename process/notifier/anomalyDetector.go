@@ -0,0 +1,114 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/tidwall/gjson"
+)
+
+// Anomaly holds a stake statistic that changed more than its threshold allows since the previous run
+type Anomaly struct {
+	Field              string  `json:"field"`
+	PreviousEpoch      uint32  `json:"previousEpoch"`
+	Previous           float64 `json:"previous"`
+	Current            float64 `json:"current"`
+	ChangePercent      float64 `json:"changePercent"`
+	MaxDropPercent     float64 `json:"maxDropPercent,omitempty"`
+	MaxIncreasePercent float64 `json:"maxIncreasePercent,omitempty"`
+}
+
+// String returns a human readable description of the anomaly
+func (a *Anomaly) String() string {
+	return fmt.Sprintf("%s changed by %.2f%% since epoch %d, from %v to %v", a.Field, a.ChangePercent, a.PreviousEpoch, a.Previous, a.Current)
+}
+
+type anomalyDetector struct {
+	thresholds []config.AnomalyThresholdConfig
+}
+
+// NewAnomalyDetector will create a new instance of anomalyDetector. Every threshold refers to a field of the stake
+// statistics by its JSON path, like numStakers or sources.delegation.totalStakeNum
+func NewAnomalyDetector(thresholds []config.AnomalyThresholdConfig) (*anomalyDetector, error) {
+	templateBytes, err := json.Marshal(createStakeStatsTemplate())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, threshold := range thresholds {
+		field := gjson.GetBytes(templateBytes, threshold.Field)
+		if threshold.Field == "" || field.Type != gjson.Number {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownStakeStatsField, threshold.Field)
+		}
+		if threshold.MaxDropPercent < 0 || threshold.MaxIncreasePercent < 0 {
+			return nil, fmt.Errorf("%w for %s: negative limit", ErrInvalidThreshold, threshold.Field)
+		}
+		if threshold.MaxDropPercent == 0 && threshold.MaxIncreasePercent == 0 {
+			return nil, fmt.Errorf("%w for %s: MaxDropPercent or MaxIncreasePercent should be set", ErrInvalidThreshold, threshold.Field)
+		}
+	}
+
+	return &anomalyDetector{
+		thresholds: thresholds,
+	}, nil
+}
+
+func createStakeStatsTemplate() *data.StakeStats {
+	sources := []string{data.SourceLegacyDelegation, data.SourceValidators, data.SourceDelegation, data.SourceLKMEX}
+	stakeStats := &data.StakeStats{
+		Sources: make(map[string]*data.SourceStakeStats, len(sources)),
+	}
+	for _, source := range sources {
+		stakeStats.Sources[source] = &data.SourceStakeStats{}
+	}
+
+	return stakeStats
+}
+
+// Detect returns the fields of the current stake statistics that changed more than their thresholds allow. A field
+// whose previous value is 0 is skipped, as its change cannot be expressed in percents
+func (ad *anomalyDetector) Detect(previous *data.StakeStats, current *data.StakeStats) ([]*Anomaly, error) {
+	previousBytes, err := json.Marshal(previous)
+	if err != nil {
+		return nil, err
+	}
+	currentBytes, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+
+	anomalies := make([]*Anomaly, 0)
+	for _, threshold := range ad.thresholds {
+		previousValue := gjson.GetBytes(previousBytes, threshold.Field).Float()
+		currentValue := gjson.GetBytes(currentBytes, threshold.Field).Float()
+		if previousValue == 0 {
+			continue
+		}
+
+		changePercent := (currentValue - previousValue) / previousValue * 100
+		isDrop := threshold.MaxDropPercent > 0 && -changePercent > threshold.MaxDropPercent
+		isIncrease := threshold.MaxIncreasePercent > 0 && changePercent > threshold.MaxIncreasePercent
+		if !isDrop && !isIncrease {
+			continue
+		}
+
+		anomalies = append(anomalies, &Anomaly{
+			Field:              threshold.Field,
+			PreviousEpoch:      previous.Epoch,
+			Previous:           previousValue,
+			Current:            currentValue,
+			ChangePercent:      changePercent,
+			MaxDropPercent:     threshold.MaxDropPercent,
+			MaxIncreasePercent: threshold.MaxIncreasePercent,
+		})
+	}
+
+	return anomalies, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ad *anomalyDetector) IsInterfaceNil() bool {
+	return ad == nil
+}
@@ -0,0 +1,77 @@
+package notifier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAnomalyDetector(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewAnomalyDetector([]config.AnomalyThresholdConfig{{Field: "numStakerz", MaxDropPercent: 10}})
+	require.True(t, errors.Is(err, ErrUnknownStakeStatsField))
+
+	_, err = NewAnomalyDetector([]config.AnomalyThresholdConfig{{Field: "sources", MaxDropPercent: 10}})
+	require.True(t, errors.Is(err, ErrUnknownStakeStatsField))
+
+	_, err = NewAnomalyDetector([]config.AnomalyThresholdConfig{{Field: "numStakers"}})
+	require.True(t, errors.Is(err, ErrInvalidThreshold))
+
+	_, err = NewAnomalyDetector([]config.AnomalyThresholdConfig{{Field: "numStakers", MaxDropPercent: -1}})
+	require.True(t, errors.Is(err, ErrInvalidThreshold))
+
+	detector, err := NewAnomalyDetector([]config.AnomalyThresholdConfig{
+		{Field: "numStakers", MaxDropPercent: 10},
+		{Field: "sources.delegation.totalStakeNum", MaxIncreasePercent: 50},
+	})
+	require.Nil(t, err)
+	require.False(t, detector.IsInterfaceNil())
+}
+
+func TestAnomalyDetector_Detect(t *testing.T) {
+	t.Parallel()
+
+	detector, _ := NewAnomalyDetector([]config.AnomalyThresholdConfig{
+		{Field: "numStakers", MaxDropPercent: 10},
+		{Field: "numAccounts", MaxDropPercent: 10, MaxIncreasePercent: 10},
+		{Field: "sources.delegation.totalStakeNum", MaxIncreasePercent: 50},
+		{Field: "totalEnergyNum", MaxDropPercent: 10},
+	})
+
+	previous := &data.StakeStats{
+		Epoch:       9,
+		NumStakers:  1000,
+		NumAccounts: 2000,
+		Sources: map[string]*data.SourceStakeStats{
+			data.SourceDelegation: {TotalStakeNum: 100},
+		},
+	}
+	current := &data.StakeStats{
+		Epoch:          10,
+		NumStakers:     890,
+		NumAccounts:    2150,
+		TotalEnergyNum: 5,
+		Sources: map[string]*data.SourceStakeStats{
+			data.SourceDelegation: {TotalStakeNum: 149},
+		},
+	}
+
+	anomalies, err := detector.Detect(previous, current)
+	require.Nil(t, err)
+	require.Len(t, anomalies, 1)
+	require.Equal(t, "numStakers", anomalies[0].Field)
+	require.Equal(t, uint32(9), anomalies[0].PreviousEpoch)
+	require.InDelta(t, -11, anomalies[0].ChangePercent, 0.001)
+	require.Equal(t, "numStakers changed by -11.00% since epoch 9, from 1000 to 890", anomalies[0].String())
+
+	current.Sources[data.SourceDelegation].TotalStakeNum = 151
+	current.NumStakers = 1000
+	anomalies, err = detector.Detect(previous, current)
+	require.Nil(t, err)
+	require.Len(t, anomalies, 1)
+	require.Equal(t, "sources.delegation.totalStakeNum", anomalies[0].Field)
+}
@@ -0,0 +1,21 @@
+package notifier
+
+import "errors"
+
+// ErrEmptyURL signals that the URL of a webhook is empty
+var ErrEmptyURL = errors.New("empty webhook URL")
+
+// ErrEmptyCommand signals that the command of an exec hook is empty
+var ErrEmptyCommand = errors.New("empty command")
+
+// ErrInvalidEvent signals that a hook is subscribed to an unknown event
+var ErrInvalidEvent = errors.New("invalid event")
+
+// ErrUnknownStakeStatsField signals that an anomaly threshold refers to a field the stake statistics do not have
+var ErrUnknownStakeStatsField = errors.New("unknown stake stats field")
+
+// ErrInvalidThreshold signals that an anomaly threshold has no positive limit or has a negative one
+var ErrInvalidThreshold = errors.New("invalid anomaly threshold")
+
+// ErrUnexpectedStatusCode signals that a webhook answered with a status code other than 2xx
+var ErrUnexpectedStatusCode = errors.New("unexpected status code")
@@ -0,0 +1,86 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const defaultExecTimeout = 30 * time.Second
+
+// ArgsExecNotifier holds the arguments needed to create a new exec notifier
+type ArgsExecNotifier struct {
+	Command string
+	Args    []string
+	Events  []string
+	Timeout time.Duration
+}
+
+type execNotifier struct {
+	*eventsFilter
+	command string
+	args    []string
+	timeout time.Duration
+}
+
+// NewExecNotifier will create a new instance of execNotifier, which runs a local command for every notification. The
+// JSON notification is written on the standard input of the command, while its event, epoch and status are also set in
+// the ACCOUNTS_MANAGER_EVENT, ACCOUNTS_MANAGER_EPOCH and ACCOUNTS_MANAGER_STATUS environment variables
+func NewExecNotifier(args ArgsExecNotifier) (*execNotifier, error) {
+	if args.Command == "" {
+		return nil, ErrEmptyCommand
+	}
+	filter, err := newEventsFilter(args.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := args.Timeout
+	if timeout <= 0 {
+		timeout = defaultExecTimeout
+	}
+
+	return &execNotifier{
+		eventsFilter: filter,
+		command:      args.Command,
+		args:         args.Args,
+		timeout:      timeout,
+	}, nil
+}
+
+// Notify will run the command with the provided notification, killing it after the timeout. The processes started by
+// the command are not killed, so a long-running one should be started in background with its output redirected
+func (en *execNotifier) Notify(notification *Notification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), en.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, en.command, en.args...)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(),
+		"ACCOUNTS_MANAGER_EVENT="+notification.Event,
+		fmt.Sprintf("ACCOUNTS_MANAGER_EPOCH=%d", notification.Epoch),
+		"ACCOUNTS_MANAGER_STATUS="+notification.Status,
+	)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("command %s: %w, output: %s", en.command, err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (en *execNotifier) IsInterfaceNil() bool {
+	return en == nil
+}
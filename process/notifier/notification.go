@@ -0,0 +1,59 @@
+package notifier
+
+import (
+	"fmt"
+
+	logger "github.com/multiversx/mx-chain-logger-go"
+)
+
+const (
+	// EventSuccess is the event of a successful run without anomalies
+	EventSuccess = "success"
+	// EventFailure is the event of a failed run
+	EventFailure = "failure"
+	// EventAnomaly is the event of a successful run whose stake statistics changed more than the thresholds allow
+	EventAnomaly = "anomaly"
+)
+
+var log = logger.GetOrCreate("process/notifier")
+
+// Notification is the payload sent to the hooks after a run
+type Notification struct {
+	Event         string      `json:"event"`
+	Epoch         uint32      `json:"epoch"`
+	Status        string      `json:"status"`
+	ErrorCategory string      `json:"errorCategory,omitempty"`
+	Error         string      `json:"error,omitempty"`
+	ExitCode      int         `json:"exitCode"`
+	Anomalies     []*Anomaly  `json:"anomalies,omitempty"`
+	Report        interface{} `json:"report"`
+}
+
+// eventsFilter holds the events a hook is subscribed to, all of them if empty
+type eventsFilter struct {
+	events map[string]struct{}
+}
+
+func newEventsFilter(events []string) (*eventsFilter, error) {
+	filter := &eventsFilter{
+		events: make(map[string]struct{}, len(events)),
+	}
+	for _, event := range events {
+		if event != EventSuccess && event != EventFailure && event != EventAnomaly {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidEvent, event)
+		}
+		filter.events[event] = struct{}{}
+	}
+
+	return filter, nil
+}
+
+// IsSubscribed returns true if the hook should be notified of the provided event
+func (ef *eventsFilter) IsSubscribed(event string) bool {
+	if len(ef.events) == 0 {
+		return true
+	}
+
+	_, ok := ef.events[event]
+	return ok
+}
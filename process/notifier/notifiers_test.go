@@ -0,0 +1,119 @@
+package notifier
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createNotification() *Notification {
+	return &Notification{
+		Event:  EventAnomaly,
+		Epoch:  10,
+		Status: "success",
+		Anomalies: []*Anomaly{
+			{Field: "numStakers", PreviousEpoch: 9, Previous: 1000, Current: 800, ChangePercent: -20},
+		},
+		Report: map[string]interface{}{"index": "accounts-000001_10"},
+	}
+}
+
+func TestEventsFilter(t *testing.T) {
+	t.Parallel()
+
+	_, err := newEventsFilter([]string{EventFailure, "warning"})
+	require.True(t, errors.Is(err, ErrInvalidEvent))
+
+	filter, _ := newEventsFilter(nil)
+	require.True(t, filter.IsSubscribed(EventSuccess))
+	require.True(t, filter.IsSubscribed(EventAnomaly))
+
+	filter, _ = newEventsFilter([]string{EventFailure, EventAnomaly})
+	require.False(t, filter.IsSubscribed(EventSuccess))
+	require.True(t, filter.IsSubscribed(EventFailure))
+}
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewWebhookNotifier(ArgsWebhookNotifier{})
+	require.Equal(t, ErrEmptyURL, err)
+
+	received := &Notification{}
+	statusCode := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.Nil(t, json.NewDecoder(r.Body).Decode(received))
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+
+	wn, err := NewWebhookNotifier(ArgsWebhookNotifier{
+		URL:     server.URL,
+		Headers: map[string]string{"Authorization": "Bearer token"},
+		Events:  []string{EventAnomaly},
+	})
+	require.Nil(t, err)
+	require.False(t, wn.IsInterfaceNil())
+	require.False(t, wn.IsSubscribed(EventSuccess))
+
+	require.Nil(t, wn.Notify(createNotification()))
+	require.Equal(t, EventAnomaly, received.Event)
+	require.Equal(t, uint32(10), received.Epoch)
+	require.Len(t, received.Anomalies, 1)
+
+	statusCode = http.StatusInternalServerError
+	err = wn.Notify(createNotification())
+	require.True(t, errors.Is(err, ErrUnexpectedStatusCode))
+}
+
+func TestExecNotifier_Notify(t *testing.T) {
+	t.Parallel()
+
+	shell, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh is not available")
+	}
+
+	_, err = NewExecNotifier(ArgsExecNotifier{})
+	require.Equal(t, ErrEmptyCommand, err)
+
+	outputPath := filepath.Join(t.TempDir(), "notification.json")
+	en, err := NewExecNotifier(ArgsExecNotifier{
+		Command: shell,
+		Args:    []string{"-c", `cat > "$0" && echo "$ACCOUNTS_MANAGER_EVENT $ACCOUNTS_MANAGER_EPOCH $ACCOUNTS_MANAGER_STATUS" > "$0.env"`, outputPath},
+	})
+	require.Nil(t, err)
+	require.False(t, en.IsInterfaceNil())
+	require.True(t, en.IsSubscribed(EventSuccess))
+
+	require.Nil(t, en.Notify(createNotification()))
+	environment, err := ioutil.ReadFile(outputPath + ".env")
+	require.Nil(t, err)
+	require.Equal(t, "anomaly 10 success", strings.TrimSpace(string(environment)))
+
+	output, err := ioutil.ReadFile(outputPath)
+	require.Nil(t, err)
+	received := &Notification{}
+	require.Nil(t, json.Unmarshal(output, received))
+	require.Equal(t, "numStakers", received.Anomalies[0].Field)
+
+	failing, _ := NewExecNotifier(ArgsExecNotifier{Command: shell, Args: []string{"-c", "echo boom && exit 3"}})
+	err = failing.Notify(createNotification())
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "boom")
+
+	slow, _ := NewExecNotifier(ArgsExecNotifier{Command: shell, Args: []string{"-c", "exec sleep 5"}, Timeout: 50 * time.Millisecond})
+	require.NotNil(t, slow.Notify(createNotification()))
+}
@@ -0,0 +1,92 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const defaultWebhookTimeout = 10 * time.Second
+
+// ArgsWebhookNotifier holds the arguments needed to create a new webhook notifier
+type ArgsWebhookNotifier struct {
+	URL     string
+	Headers map[string]string
+	Events  []string
+	Timeout time.Duration
+}
+
+type webhookNotifier struct {
+	*eventsFilter
+	url        string
+	headers    map[string]string
+	httpClient *http.Client
+}
+
+// NewWebhookNotifier will create a new instance of webhookNotifier, which posts the notifications as JSON
+func NewWebhookNotifier(args ArgsWebhookNotifier) (*webhookNotifier, error) {
+	if args.URL == "" {
+		return nil, ErrEmptyURL
+	}
+	filter, err := newEventsFilter(args.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := args.Timeout
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+
+	return &webhookNotifier{
+		eventsFilter: filter,
+		url:          args.URL,
+		headers:      args.Headers,
+		httpClient:   &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Notify will post the provided notification to the webhook
+func (wn *webhookNotifier) Notify(notification *Notification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, wn.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range wn.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := wn.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		errNotCritical := resp.Body.Close()
+		if errNotCritical != nil {
+			log.Warn("webhookNotifier.Notify: close body", "error", errNotCritical.Error())
+		}
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %d", ErrUnexpectedStatusCode, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (wn *webhookNotifier) IsInterfaceNil() bool {
+	return wn == nil
+}
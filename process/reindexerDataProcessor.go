@@ -1,38 +1,99 @@
 package process
 
-import "github.com/multiversx/mx-chain-core-go/core/check"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex/elasticDestination"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+// ArgsReindexerDataProcessor holds the arguments needed to create a new reindexer data processor
+type ArgsReindexerDataProcessor struct {
+	AccountsProcessor AccountsProcessorHandler
+	Reindexer         Reindexer
+	// RunVerifier is optional, a nil value disables the verification of the written index
+	RunVerifier RunVerifier
+	// Guardrails is optional, a nil value disables the sanity checks of the merged accounts. With Force, the violated
+	// guardrails are only reported and the snapshot is published anyway
+	Guardrails Guardrails
+	Force      bool
+	// Destinations and ElasticClients, in the order of the configured destination clients, are used only for the run
+	// report
+	Destinations   []DestinationStatsHandler
+	ElasticClients []*ReportedElasticClient
+}
+
+// ReportedElasticClient is a destination elastic client whose bulk counters are added to the run report
+type ReportedElasticClient struct {
+	// Address is the configured address of the client, or its cloud ID if it has no address
+	Address string
+	Client  BulkStatsHandler
+}
 
 type reindexerDataProcessor struct {
 	accountsProcessor AccountsProcessorHandler
 	reindexer         Reindexer
+	runVerifier       RunVerifier
+	guardrails        Guardrails
+	force             bool
+	destinations      []DestinationStatsHandler
+	elasticClients    []*ReportedElasticClient
+	report            *RunReport
 }
 
 // NewReindexerDataProcessor will create a new instance of reindexerDataProcessor
-func NewReindexerDataProcessor(
-	accountsProcessor AccountsProcessorHandler,
-	reindexer Reindexer,
-) (*reindexerDataProcessor, error) {
-	if check.IfNil(accountsProcessor) {
+func NewReindexerDataProcessor(args ArgsReindexerDataProcessor) (*reindexerDataProcessor, error) {
+	if check.IfNil(args.AccountsProcessor) {
 		return nil, ErrNilAccountsProcessor
 	}
-	if check.IfNil(reindexer) {
+	if check.IfNil(args.Reindexer) {
 		return nil, ErrNilReindexer
 	}
 
 	return &reindexerDataProcessor{
-		accountsProcessor: accountsProcessor,
-		reindexer:         reindexer,
+		accountsProcessor: args.AccountsProcessor,
+		reindexer:         args.Reindexer,
+		runVerifier:       args.RunVerifier,
+		guardrails:        args.Guardrails,
+		force:             args.Force,
+		destinations:      args.Destinations,
+		elasticClients:    args.ElasticClients,
+		report:            NewRunReport(),
 	}, nil
 }
 
-// ProcessAccountsData will process accounts data
+// ProcessAccountsData will process accounts data. The returned error has the category of the failed step
 func (dp *reindexerDataProcessor) ProcessAccountsData() error {
+	dp.report = NewRunReport()
+	bulkStatsAtStart := dp.getBulkStats()
+
+	err := dp.processAccountsData()
+	dp.addElasticClientsToReport(bulkStatsAtStart)
+
+	return err
+}
+
+func (dp *reindexerDataProcessor) processAccountsData() error {
 	epoch, err := dp.accountsProcessor.GetCurrentEpoch()
 	if err != nil {
-		return err
+		return NewCategorizedError(ErrUpstreamAPI, err)
 	}
+	dp.report.Epoch = epoch
+	dp.report.Key = runReportKey(epoch)
 
 	accountsRest, err := dp.accountsProcessor.GetAllAccountsWithStake(epoch)
+	if err != nil {
+		return NewCategorizedError(ErrUpstreamAPI, err)
+	}
+	dp.report.Sources = accountsRest.SourcesStats
+	dp.report.ReferenceBlock = accountsRest.EnergyBlockInfo
+	dp.report.NumAccountsWithStake = len(accountsRest.AccountsWithStake)
+
+	err = dp.checkGuardrails(accountsRest)
 	if err != nil {
 		return err
 	}
@@ -41,6 +102,137 @@ func (dp *reindexerDataProcessor) ProcessAccountsData() error {
 	if err != nil {
 		return err
 	}
+	dp.report.Index = newIndex
+
+	err = dp.reindexer.ReindexAccounts(accountsIndex, newIndex, accountsRest)
+	dp.addReindexingToReport()
+	if err != nil {
+		return categorizeReindexingError(err)
+	}
+
+	return dp.verifyRun(newIndex)
+}
+
+// categorizeReindexingError marks the failures caused by the local configuration as config errors, since retrying the
+// run cannot fix them, and all the other failures as elasticsearch errors
+func categorizeReindexingError(err error) error {
+	if errors.Is(err, crossIndex.ErrInvalidAddressLabels) || errors.Is(err, elasticDestination.ErrIncompatibleMapping) {
+		return NewCategorizedError(ErrConfig, err)
+	}
+
+	return NewCategorizedError(ErrElasticsearch, err)
+}
+
+// checkGuardrails will block the run, before anything is written, if the merged accounts violate a guardrail
+func (dp *reindexerDataProcessor) checkGuardrails(accountsData *data.AccountsData) error {
+	if check.IfNil(dp.guardrails) {
+		return nil
+	}
+
+	violations, err := dp.guardrails.Check(accountsData)
+	if err != nil {
+		return NewCategorizedError(ErrElasticsearch, err)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	dp.report.GuardrailViolations = violations
+	for _, violation := range violations {
+		log.Error("guardrail violated", "source", violation.Source, "rule", violation.Rule, "details", violation.Message)
+	}
+	if dp.force {
+		log.Warn("publishing the snapshot despite the violated guardrails, as the force flag is set")
+		dp.report.GuardrailsForced = true
+		return nil
+	}
+
+	return fmt.Errorf("%w: %d violation(s), the snapshot was not published. Use the force flag to publish it anyway",
+		ErrGuardrailsViolated, len(violations))
+}
+
+func (dp *reindexerDataProcessor) verifyRun(newIndex string) error {
+	if check.IfNil(dp.runVerifier) {
+		return nil
+	}
+
+	log.Info("verifying the written index", "index", newIndex)
+	results, err := dp.runVerifier.VerifyRun(accountsIndex, newIndex, dp.reindexer.GetReindexSummary())
+	dp.report.Verification = results
+	for _, result := range results {
+		resultBytes, errMarshal := json.Marshal(result)
+		if errMarshal != nil {
+			return errMarshal
+		}
+
+		for _, warning := range result.Warnings {
+			log.Warn("verification", "client index", result.ClientIndex, "warning", warning)
+		}
+		if !result.Passed() {
+			log.Error("verification failed", "result", string(resultBytes))
+			continue
+		}
+		log.Info("verification passed", "result", string(resultBytes))
+	}
+
+	return NewCategorizedError(ErrElasticsearch, err)
+}
+
+func (dp *reindexerDataProcessor) addReindexingToReport() {
+	summary := dp.reindexer.GetReindexSummary()
+	dp.report.NumSourceDocuments = summary.NumSourceDocuments
+	dp.report.NumIndexedAccounts = summary.NumIndexedAccounts
+	dp.report.NumFilteredAccounts = summary.NumFilteredAccounts
+	dp.report.StakeStats = summary.StakeStats
+
+	for _, destination := range dp.destinations {
+		dp.report.Destinations = append(dp.report.Destinations, destination.GetStats())
+	}
+}
+
+func (dp *reindexerDataProcessor) getBulkStats() []*data.BulkStats {
+	bulkStats := make([]*data.BulkStats, len(dp.elasticClients))
+	for idx, reportedClient := range dp.elasticClients {
+		bulkStats[idx] = reportedClient.Client.GetBulkStats()
+	}
+
+	return bulkStats
+}
+
+// addElasticClientsToReport will add the bulk counters of every client, without the requests of the previous runs
+func (dp *reindexerDataProcessor) addElasticClientsToReport(bulkStatsAtStart []*data.BulkStats) {
+	for idx, reportedClient := range dp.elasticClients {
+		current := reportedClient.Client.GetBulkStats()
+		start := bulkStatsAtStart[idx]
+		dp.report.ElasticClients = append(dp.report.ElasticClients, &ElasticClientReport{
+			Index:   idx,
+			Address: reportedClient.Address,
+			BulkStats: data.BulkStats{
+				NumRequests:          current.NumRequests - start.NumRequests,
+				NumRetriedItems:      current.NumRetriedItems - start.NumRetriedItems,
+				NumDeadLetteredItems: current.NumDeadLetteredItems - start.NumDeadLetteredItems,
+			},
+		})
+	}
+}
+
+// GetCurrentEpoch returns the current epoch of the network
+func (dp *reindexerDataProcessor) GetCurrentEpoch() (uint32, error) {
+	epoch, err := dp.accountsProcessor.GetCurrentEpoch()
+	if err != nil {
+		return 0, NewCategorizedError(ErrUpstreamAPI, err)
+	}
+
+	return epoch, nil
+}
+
+// Close will close the destinations of the snapshots
+func (dp *reindexerDataProcessor) Close() error {
+	return dp.reindexer.Close()
+}
 
-	return dp.reindexer.ReindexAccounts(accountsIndex, newIndex, accountsRest)
+// GetRunReport returns the report of the last run. The status, the exit code and the end time are set by the caller
+// with RunReport.Finish, after all the steps of the run
+func (dp *reindexerDataProcessor) GetRunReport() *RunReport {
+	return dp.report
 }
@@ -0,0 +1,274 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex/elasticDestination"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/guardrails"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/snapshots"
+	"github.com/stretchr/testify/require"
+)
+
+type reindexerStub struct {
+	reindexedIndex string
+	summary        *data.ReindexSummary
+	err            error
+}
+
+func (rs *reindexerStub) ReindexAccounts(_ string, destinationIndex string, _ *data.AccountsData) error {
+	rs.reindexedIndex = destinationIndex
+	return rs.err
+}
+
+func (rs *reindexerStub) GetReindexSummary() *data.ReindexSummary {
+	return rs.summary
+}
+
+func (rs *reindexerStub) Close() error {
+	return nil
+}
+
+func (rs *reindexerStub) IsInterfaceNil() bool {
+	return rs == nil
+}
+
+type runVerifierStub struct {
+	verifyRunCalled func(sourceIndex string, index string, summary *data.ReindexSummary) ([]*snapshots.VerificationResult, error)
+}
+
+func (rvs *runVerifierStub) VerifyRun(sourceIndex string, index string, summary *data.ReindexSummary) ([]*snapshots.VerificationResult, error) {
+	return rvs.verifyRunCalled(sourceIndex, index, summary)
+}
+
+func (rvs *runVerifierStub) IsInterfaceNil() bool {
+	return rvs == nil
+}
+
+type guardrailsStub struct {
+	violations []*guardrails.Violation
+	err        error
+}
+
+func (gs *guardrailsStub) Check(_ *data.AccountsData) ([]*guardrails.Violation, error) {
+	return gs.violations, gs.err
+}
+
+func (gs *guardrailsStub) IsInterfaceNil() bool {
+	return gs == nil
+}
+
+type destinationStatsStub struct {
+	stats *data.DestinationStats
+}
+
+func (dss *destinationStatsStub) GetStats() *data.DestinationStats {
+	return dss.stats
+}
+
+type bulkStatsStub struct {
+	statsPerCall []*data.BulkStats
+	numCalls     int
+}
+
+func (bss *bulkStatsStub) GetBulkStats() *data.BulkStats {
+	stats := bss.statsPerCall[bss.numCalls]
+	bss.numCalls++
+
+	return stats
+}
+
+func createArgsReindexerDataProcessor(reindexer Reindexer, verifier RunVerifier) ArgsReindexerDataProcessor {
+	return ArgsReindexerDataProcessor{
+		AccountsProcessor: &accountsProcessorStub{currentEpoch: 5},
+		Reindexer:         reindexer,
+		RunVerifier:       verifier,
+	}
+}
+
+func TestReindexerDataProcessor_ProcessAccountsDataVerifiesTheWrittenIndex(t *testing.T) {
+	t.Parallel()
+
+	summary := &data.ReindexSummary{NumSourceDocuments: 3, NumIndexedAccounts: 3}
+	reindexer := &reindexerStub{summary: summary}
+	verifiedIndex := ""
+	verifier := &runVerifierStub{
+		verifyRunCalled: func(sourceIndex string, index string, providedSummary *data.ReindexSummary) ([]*snapshots.VerificationResult, error) {
+			require.Equal(t, accountsIndex, sourceIndex)
+			require.Equal(t, summary, providedSummary)
+			verifiedIndex = index
+
+			return []*snapshots.VerificationResult{{Index: index, Problems: []string{}}}, nil
+		},
+	}
+
+	dp, err := NewReindexerDataProcessor(createArgsReindexerDataProcessor(reindexer, verifier))
+	require.Nil(t, err)
+	require.Nil(t, dp.ProcessAccountsData())
+	require.Equal(t, reindexer.reindexedIndex, verifiedIndex)
+
+	expectedErr := fmt.Errorf("%w: index has 2 documents", snapshots.ErrVerificationFailed)
+	verifier.verifyRunCalled = func(_ string, index string, _ *data.ReindexSummary) ([]*snapshots.VerificationResult, error) {
+		return []*snapshots.VerificationResult{{Index: index, Problems: []string{"index has 2 documents, expected 3 accounts"}}}, expectedErr
+	}
+	err = dp.ProcessAccountsData()
+	require.True(t, errors.Is(err, expectedErr))
+	require.Equal(t, ExitCodeVerification, ExitCode(err))
+	require.Len(t, dp.GetRunReport().Verification, 1)
+
+	dp, err = NewReindexerDataProcessor(createArgsReindexerDataProcessor(reindexer, nil))
+	require.Nil(t, err)
+	require.Nil(t, dp.ProcessAccountsData())
+}
+
+func TestNewReindexerDataProcessor(t *testing.T) {
+	t.Parallel()
+
+	args := createArgsReindexerDataProcessor(&reindexerStub{}, nil)
+	args.AccountsProcessor = nil
+	_, err := NewReindexerDataProcessor(args)
+	require.Equal(t, ErrNilAccountsProcessor, err)
+
+	args = createArgsReindexerDataProcessor(nil, nil)
+	_, err = NewReindexerDataProcessor(args)
+	require.Equal(t, ErrNilReindexer, err)
+}
+
+func TestReindexerDataProcessor_ProcessAccountsDataFillsTheRunReport(t *testing.T) {
+	t.Parallel()
+
+	reindexer := &reindexerStub{summary: &data.ReindexSummary{NumSourceDocuments: 10, NumIndexedAccounts: 8, NumFilteredAccounts: 2}}
+	destinationStats := &data.DestinationStats{Name: "elasticsearch http://localhost:9200", NumAccounts: 8, NumBatches: 1}
+	// the counters of the client include the requests of the previous runs
+	bulkStats := &bulkStatsStub{statsPerCall: []*data.BulkStats{
+		{NumRequests: 7, NumRetriedItems: 1},
+		{NumRequests: 10, NumRetriedItems: 3, NumDeadLetteredItems: 1},
+	}}
+
+	args := createArgsReindexerDataProcessor(reindexer, nil)
+	args.Destinations = []DestinationStatsHandler{&destinationStatsStub{stats: destinationStats}}
+	// both clients have the same address, so they are told apart by their index
+	otherBulkStats := &bulkStatsStub{statsPerCall: []*data.BulkStats{{NumRequests: 1}, {NumRequests: 2}}}
+	args.ElasticClients = []*ReportedElasticClient{
+		{Address: "http://localhost:9200", Client: bulkStats},
+		{Address: "http://localhost:9200", Client: otherBulkStats},
+	}
+	dp, _ := NewReindexerDataProcessor(args)
+	require.Nil(t, dp.ProcessAccountsData())
+
+	report := dp.GetRunReport()
+	report.Finish(nil)
+	require.Equal(t, uint32(5), report.Epoch)
+	require.Equal(t, "run-report-5", report.Key)
+	require.Equal(t, ComputeAccountsIndexName(5), report.Index)
+	require.Equal(t, uint64(10), report.NumSourceDocuments)
+	require.Equal(t, uint64(8), report.NumIndexedAccounts)
+	require.Equal(t, uint64(2), report.NumFilteredAccounts)
+	require.Equal(t, []*data.DestinationStats{destinationStats}, report.Destinations)
+	require.Equal(t, []*ElasticClientReport{
+		{
+			Index:     0,
+			Address:   "http://localhost:9200",
+			BulkStats: data.BulkStats{NumRequests: 3, NumRetriedItems: 2, NumDeadLetteredItems: 1},
+		},
+		{
+			Index:     1,
+			Address:   "http://localhost:9200",
+			BulkStats: data.BulkStats{NumRequests: 1},
+		},
+	}, report.ElasticClients)
+	require.Equal(t, RunStatusSuccess, report.Status)
+	require.Equal(t, ExitCodeSuccess, report.ExitCode)
+}
+
+func TestReindexerDataProcessor_ProcessAccountsDataCategorizesTheErrors(t *testing.T) {
+	t.Parallel()
+
+	reindexer := &reindexerStub{summary: &data.ReindexSummary{}, err: errors.New("bulk request failed")}
+	dp, _ := NewReindexerDataProcessor(createArgsReindexerDataProcessor(reindexer, nil))
+
+	err := dp.ProcessAccountsData()
+	require.True(t, errors.Is(err, ErrElasticsearch))
+	require.True(t, errors.Is(err, reindexer.err))
+
+	report := dp.GetRunReport()
+	report.Finish(err)
+	require.Equal(t, RunStatusFailed, report.Status)
+	require.Equal(t, errorCategoryElasticsearch, report.ErrorCategory)
+	require.Equal(t, ExitCodeElasticsearch, report.ExitCode)
+	require.Equal(t, err.Error(), report.Error)
+
+	for _, configErr := range []error{
+		fmt.Errorf("%w: cannot open address labels file", crossIndex.ErrInvalidAddressLabels),
+		fmt.Errorf("destination elasticsearch, PrepareSnapshot: %w: field balance", elasticDestination.ErrIncompatibleMapping),
+	} {
+		reindexer.err = configErr
+		err = dp.ProcessAccountsData()
+		require.True(t, errors.Is(err, ErrConfig))
+		require.False(t, errors.Is(err, ErrElasticsearch))
+		require.Equal(t, ExitCodeConfig, ExitCode(err))
+	}
+}
+
+func TestReindexerDataProcessor_ProcessAccountsDataGuardrails(t *testing.T) {
+	t.Parallel()
+
+	violations := []*guardrails.Violation{{Source: data.SourceDelegation, Rule: guardrails.RuleMinAccounts, Message: "returned 0 accounts"}}
+
+	t.Run("a violation blocks the run before anything is written", func(t *testing.T) {
+		t.Parallel()
+
+		reindexer := &reindexerStub{summary: &data.ReindexSummary{}}
+		args := createArgsReindexerDataProcessor(reindexer, nil)
+		args.Guardrails = &guardrailsStub{violations: violations}
+		dp, _ := NewReindexerDataProcessor(args)
+
+		err := dp.ProcessAccountsData()
+		require.True(t, errors.Is(err, ErrGuardrailsViolated))
+		require.Equal(t, ExitCodeGuardrails, ExitCode(err))
+		require.Empty(t, reindexer.reindexedIndex)
+		require.Equal(t, violations, dp.GetRunReport().GuardrailViolations)
+		require.False(t, dp.GetRunReport().GuardrailsForced)
+	})
+
+	t.Run("force publishes the snapshot anyway", func(t *testing.T) {
+		t.Parallel()
+
+		reindexer := &reindexerStub{summary: &data.ReindexSummary{}}
+		args := createArgsReindexerDataProcessor(reindexer, nil)
+		args.Guardrails = &guardrailsStub{violations: violations}
+		args.Force = true
+		dp, _ := NewReindexerDataProcessor(args)
+
+		require.Nil(t, dp.ProcessAccountsData())
+		require.Equal(t, ComputeAccountsIndexName(5), reindexer.reindexedIndex)
+		require.Equal(t, violations, dp.GetRunReport().GuardrailViolations)
+		require.True(t, dp.GetRunReport().GuardrailsForced)
+	})
+
+	t.Run("a failed check is an elasticsearch error", func(t *testing.T) {
+		t.Parallel()
+
+		reindexer := &reindexerStub{summary: &data.ReindexSummary{}}
+		args := createArgsReindexerDataProcessor(reindexer, nil)
+		args.Guardrails = &guardrailsStub{err: errors.New("cannot fetch the previous stats")}
+		dp, _ := NewReindexerDataProcessor(args)
+
+		err := dp.ProcessAccountsData()
+		require.True(t, errors.Is(err, ErrElasticsearch))
+		require.Empty(t, reindexer.reindexedIndex)
+	})
+}
+
+func TestReindexerDataProcessor_GetCurrentEpoch(t *testing.T) {
+	t.Parallel()
+
+	dp, _ := NewReindexerDataProcessor(createArgsReindexerDataProcessor(&reindexerStub{}, nil))
+
+	epoch, err := dp.GetCurrentEpoch()
+	require.Nil(t, err)
+	require.Equal(t, uint32(5), epoch)
+}
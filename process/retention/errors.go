@@ -0,0 +1,15 @@
+package retention
+
+import "errors"
+
+// ErrNilElasticClient signals that a nil elastic client has been provided
+var ErrNilElasticClient = errors.New("nil elastic client")
+
+// ErrEmptyAccountsIndex signals that an empty accounts index name has been provided
+var ErrEmptyAccountsIndex = errors.New("empty accounts index")
+
+// ErrNoRetentionRule signals that neither the number of epochs nor the number of days to keep has been configured
+var ErrNoRetentionRule = errors.New("no retention rule, set the number of epochs or the number of days to keep")
+
+// ErrInvalidRetentionRule signals that a negative number of epochs or days to keep has been configured
+var ErrInvalidRetentionRule = errors.New("invalid retention rule")
@@ -0,0 +1,16 @@
+package retention
+
+import (
+	"bytes"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+// ElasticClientHandler defines what an elastic client should be able to do
+type ElasticClientHandler interface {
+	GetIndices(index string) ([]*data.IndexInfo, error)
+	DeleteIndices(indices []string) error
+	DoBulkRequest(buff *bytes.Buffer, index string) error
+	DoScrollRequestAllDocuments(index string, body []byte, handlerFunc func(responseBytes []byte) error) error
+	IsInterfaceNil() bool
+}
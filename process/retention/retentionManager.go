@@ -0,0 +1,284 @@
+package retention
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	valuesIndex    = "values"
+	valuesDocsBody = `{"_source":false,"query":{"match_all":{}}}`
+	dayDuration    = 24 * time.Hour
+)
+
+var (
+	log = logger.GetOrCreate("process/retention")
+
+	// valuesDocPattern matches the documents written in the values index for every epoch
+	valuesDocPattern = regexp.MustCompile(`^(energy-snapshot|stake-stats|eligibility-merkle-root|run-report)-(\d+)$`)
+)
+
+// ArgsRetentionManager holds the arguments needed to create a new retention manager
+type ArgsRetentionManager struct {
+	Clients       []ElasticClientHandler
+	AccountsIndex string
+	IndexPrefixes []string
+	KeepEpochs    int
+	KeepDays      int
+	DryRun        bool
+}
+
+// PruneResult holds the indices and the documents removed by the retention
+type PruneResult struct {
+	DryRun           bool
+	DeletedIndices   []string
+	ProtectedIndices []string
+	DeletedDocuments []string
+}
+
+type epochIndex struct {
+	*data.IndexInfo
+	epoch uint32
+}
+
+type retentionManager struct {
+	clients       []ElasticClientHandler
+	accountsIndex string
+	indexPrefixes []string
+	keepEpochs    int
+	keepWindow    time.Duration
+	dryRun        bool
+	nowFunc       func() time.Time
+}
+
+// NewRetentionManager will create a new instance of retentionManager. The snapshots of the accounts index are kept
+// for the last KeepEpochs epochs and for the last KeepDays days, while the snapshots with an alias are never deleted.
+// The IndexPrefixes are the prefixes of other snapshots of the accounts index, which are pruned with the same rules
+func NewRetentionManager(args ArgsRetentionManager) (*retentionManager, error) {
+	for idx, client := range args.Clients {
+		if check.IfNil(client) {
+			return nil, fmt.Errorf("%w, index %d", ErrNilElasticClient, idx)
+		}
+	}
+	if args.AccountsIndex == "" {
+		return nil, ErrEmptyAccountsIndex
+	}
+	if args.KeepEpochs < 0 || args.KeepDays < 0 {
+		return nil, fmt.Errorf("%w: keep epochs %d, keep days %d", ErrInvalidRetentionRule, args.KeepEpochs, args.KeepDays)
+	}
+	if args.KeepEpochs == 0 && args.KeepDays == 0 {
+		return nil, ErrNoRetentionRule
+	}
+
+	return &retentionManager{
+		clients:       args.Clients,
+		accountsIndex: args.AccountsIndex,
+		indexPrefixes: args.IndexPrefixes,
+		keepEpochs:    args.KeepEpochs,
+		keepWindow:    time.Duration(args.KeepDays) * dayDuration,
+		dryRun:        args.DryRun,
+		nowFunc:       time.Now,
+	}, nil
+}
+
+// Prune will delete the epoch indices outside the retention window and the values documents of the epochs older
+// than the oldest kept snapshot. In dry-run mode, nothing is deleted and the result lists what would be deleted
+func (rm *retentionManager) Prune() (*PruneResult, error) {
+	result := &PruneResult{
+		DryRun:           rm.dryRun,
+		DeletedIndices:   make([]string, 0),
+		ProtectedIndices: make([]string, 0),
+		DeletedDocuments: make([]string, 0),
+	}
+
+	for idx, client := range rm.clients {
+		err := rm.pruneClient(client, result)
+		if err != nil {
+			return nil, fmt.Errorf("%w, client index %d", err, idx)
+		}
+	}
+
+	rm.logResult(result)
+
+	return result, nil
+}
+
+func (rm *retentionManager) pruneClient(client ElasticClientHandler, result *PruneResult) error {
+	oldestKeptEpoch, found, err := rm.pruneSnapshots(client, rm.accountsIndex, result)
+	if err != nil {
+		return err
+	}
+
+	for _, prefix := range rm.indexPrefixes {
+		_, _, err = rm.pruneSnapshots(client, prefix+rm.accountsIndex, result)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !found {
+		log.Info("no accounts snapshot kept, the values documents are not pruned")
+		return nil
+	}
+
+	return rm.pruneValuesDocuments(client, oldestKeptEpoch, result)
+}
+
+// pruneSnapshots will delete the snapshots of the provided index outside the retention window and will return the
+// oldest kept epoch
+func (rm *retentionManager) pruneSnapshots(client ElasticClientHandler, index string, result *PruneResult) (uint32, bool, error) {
+	indicesInfo, err := client.GetIndices(index + "_*")
+	if err != nil {
+		return 0, false, err
+	}
+
+	snapshots := filterEpochIndices(index, indicesInfo)
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].epoch > snapshots[j].epoch
+	})
+
+	oldestKeptEpoch := uint32(0)
+	found := false
+	toDelete := make([]string, 0)
+	now := rm.nowFunc()
+	for position, snapshot := range snapshots {
+		if len(snapshot.Aliases) > 0 {
+			result.ProtectedIndices = append(result.ProtectedIndices, snapshot.Name)
+			oldestKeptEpoch, found = snapshot.epoch, true
+			continue
+		}
+		if rm.shouldKeep(position, snapshot, now) {
+			oldestKeptEpoch, found = snapshot.epoch, true
+			continue
+		}
+
+		toDelete = append(toDelete, snapshot.Name)
+	}
+
+	result.DeletedIndices = append(result.DeletedIndices, toDelete...)
+	if rm.dryRun {
+		return oldestKeptEpoch, found, nil
+	}
+
+	return oldestKeptEpoch, found, client.DeleteIndices(toDelete)
+}
+
+func (rm *retentionManager) shouldKeep(position int, snapshot *epochIndex, now time.Time) bool {
+	if rm.keepEpochs > 0 && position < rm.keepEpochs {
+		return true
+	}
+
+	return rm.keepWindow > 0 && now.Sub(snapshot.CreationDate) <= rm.keepWindow
+}
+
+func (rm *retentionManager) pruneValuesDocuments(client ElasticClientHandler, oldestKeptEpoch uint32, result *PruneResult) error {
+	toDelete := make([]string, 0)
+	handler := func(responseBytes []byte) error {
+		for _, hit := range gjson.GetBytes(responseBytes, "hits.hits").Array() {
+			id := hit.Get("_id").String()
+			epoch, ok := parseValuesDocEpoch(id)
+			if ok && epoch < oldestKeptEpoch {
+				toDelete = append(toDelete, id)
+			}
+		}
+
+		return nil
+	}
+
+	err := client.DoScrollRequestAllDocuments(valuesIndex, []byte(valuesDocsBody), handler)
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(toDelete)
+	result.DeletedDocuments = append(result.DeletedDocuments, toDelete...)
+	if rm.dryRun || len(toDelete) == 0 {
+		return nil
+	}
+
+	buff := &bytes.Buffer{}
+	for _, id := range toDelete {
+		buff.WriteString(fmt.Sprintf(`{"delete":{"_id":"%s"}}`, id))
+		buff.WriteByte('\n')
+	}
+
+	return client.DoBulkRequest(buff, valuesIndex)
+}
+
+func (rm *retentionManager) logResult(result *PruneResult) {
+	action := "deleted"
+	if rm.dryRun {
+		action = "would delete"
+	}
+
+	for _, index := range result.DeletedIndices {
+		log.Info("retention: "+action+" index", "index", index)
+	}
+	for _, index := range result.ProtectedIndices {
+		log.Info("retention: kept aliased index", "index", index)
+	}
+	for _, id := range result.DeletedDocuments {
+		log.Info("retention: "+action+" values document", "id", id)
+	}
+
+	log.Info("retention done",
+		"dry run", rm.dryRun,
+		"num deleted indices", len(result.DeletedIndices),
+		"num protected indices", len(result.ProtectedIndices),
+		"num deleted documents", len(result.DeletedDocuments),
+	)
+}
+
+// filterEpochIndices keeps only the indices named exactly <index>_<epoch>, so that the snapshots with a prefix (for
+// example the filtered accounts) are not matched by the pattern of the accounts index
+func filterEpochIndices(index string, indicesInfo []*data.IndexInfo) []*epochIndex {
+	pattern := regexp.MustCompile(`^` + regexp.QuoteMeta(index) + `_(\d+)$`)
+
+	snapshots := make([]*epochIndex, 0, len(indicesInfo))
+	for _, info := range indicesInfo {
+		matches := pattern.FindStringSubmatch(info.Name)
+		if matches == nil {
+			continue
+		}
+
+		epoch, err := strconv.ParseUint(matches[1], 10, 32)
+		if err != nil {
+			continue
+		}
+
+		snapshots = append(snapshots, &epochIndex{
+			IndexInfo: info,
+			epoch:     uint32(epoch),
+		})
+	}
+
+	return snapshots
+}
+
+func parseValuesDocEpoch(id string) (uint32, bool) {
+	matches := valuesDocPattern.FindStringSubmatch(id)
+	if matches == nil {
+		return 0, false
+	}
+
+	epoch, err := strconv.ParseUint(matches[2], 10, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return uint32(epoch), true
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rm *retentionManager) IsInterfaceNil() bool {
+	return rm == nil
+}
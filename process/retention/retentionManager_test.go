@@ -0,0 +1,139 @@
+package retention
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func createIndexInfo(name string, age time.Duration, aliases ...string) *data.IndexInfo {
+	return &data.IndexInfo{
+		Name:         name,
+		CreationDate: now.Add(-age),
+		Aliases:      aliases,
+	}
+}
+
+func createClientStub(deletedIndices *[]string, bulkBody *string) *mocks.ElasticClientStub {
+	indices := map[string][]*data.IndexInfo{
+		"accounts-000001_*": {
+			createIndexInfo("accounts-000001_10", 1*dayDuration),
+			createIndexInfo("accounts-000001_9", 2*dayDuration),
+			createIndexInfo("accounts-000001_8", 3*dayDuration),
+			createIndexInfo("accounts-000001_7", 4*dayDuration, "accounts-pinned"),
+			createIndexInfo("accounts-000001_6", 5*dayDuration),
+			createIndexInfo("accounts-000001_5", 6*dayDuration),
+			createIndexInfo("accounts-000001_backup", 6*dayDuration),
+		},
+		"filtered-accounts-000001_*": {
+			createIndexInfo("filtered-accounts-000001_10", 1*dayDuration),
+			createIndexInfo("filtered-accounts-000001_6", 5*dayDuration),
+			createIndexInfo("filtered-accounts-000001_5", 6*dayDuration),
+		},
+	}
+
+	return &mocks.ElasticClientStub{
+		GetIndicesCalled: func(index string) ([]*data.IndexInfo, error) {
+			return indices[index], nil
+		},
+		DeleteIndicesCalled: func(names []string) error {
+			*deletedIndices = append(*deletedIndices, names...)
+			return nil
+		},
+		DoScrollRequestAllDocumentsCalled: func(index string, body []byte, handlerFunc func(responseBytes []byte) error) error {
+			return handlerFunc([]byte(`{"hits":{"hits":[
+				{"_id":"energy-snapshot-5"},{"_id":"stake-stats-5"},{"_id":"eligibility-merkle-root-6"},
+				{"_id":"run-report-5"},{"_id":"stake-stats-7"},{"_id":"energy-snapshot-10"},{"_id":"other-doc-1"}
+			]}}`))
+		},
+		DoBulkRequestCalled: func(buff *bytes.Buffer, index string) error {
+			*bulkBody = buff.String()
+			return nil
+		},
+	}
+}
+
+func TestNewRetentionManager(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewRetentionManager(ArgsRetentionManager{Clients: []ElasticClientHandler{nil}, AccountsIndex: "accounts", KeepEpochs: 1})
+	require.True(t, errors.Is(err, ErrNilElasticClient))
+
+	_, err = NewRetentionManager(ArgsRetentionManager{KeepEpochs: 1})
+	require.Equal(t, ErrEmptyAccountsIndex, err)
+
+	_, err = NewRetentionManager(ArgsRetentionManager{AccountsIndex: "accounts"})
+	require.Equal(t, ErrNoRetentionRule, err)
+
+	_, err = NewRetentionManager(ArgsRetentionManager{AccountsIndex: "accounts", KeepDays: -1})
+	require.True(t, errors.Is(err, ErrInvalidRetentionRule))
+
+	rm, err := NewRetentionManager(ArgsRetentionManager{AccountsIndex: "accounts", KeepDays: 30})
+	require.Nil(t, err)
+	require.False(t, rm.IsInterfaceNil())
+}
+
+func TestRetentionManager_PruneKeepEpochs(t *testing.T) {
+	t.Parallel()
+
+	deletedIndices := make([]string, 0)
+	bulkBody := ""
+	rm, _ := NewRetentionManager(ArgsRetentionManager{
+		Clients:       []ElasticClientHandler{createClientStub(&deletedIndices, &bulkBody)},
+		AccountsIndex: "accounts-000001",
+		IndexPrefixes: []string{"filtered-"},
+		KeepEpochs:    2,
+	})
+	rm.nowFunc = func() time.Time { return now }
+
+	result, err := rm.Prune()
+	require.Nil(t, err)
+	require.Equal(t, []string{"accounts-000001_8", "accounts-000001_6", "accounts-000001_5", "filtered-accounts-000001_5"}, deletedIndices)
+	require.Equal(t, deletedIndices, result.DeletedIndices)
+	require.Equal(t, []string{"accounts-000001_7"}, result.ProtectedIndices)
+	require.Equal(t, []string{"eligibility-merkle-root-6", "energy-snapshot-5", "run-report-5", "stake-stats-5"}, result.DeletedDocuments)
+	require.Equal(t, 4, strings.Count(bulkBody, `{"delete":`))
+}
+
+func TestRetentionManager_PruneKeepDaysDryRun(t *testing.T) {
+	t.Parallel()
+
+	deletedIndices := make([]string, 0)
+	bulkBody := ""
+	rm, _ := NewRetentionManager(ArgsRetentionManager{
+		Clients:       []ElasticClientHandler{createClientStub(&deletedIndices, &bulkBody)},
+		AccountsIndex: "accounts-000001",
+		KeepDays:      3,
+		DryRun:        true,
+	})
+	rm.nowFunc = func() time.Time { return now }
+
+	result, err := rm.Prune()
+	require.Nil(t, err)
+	require.True(t, result.DryRun)
+	require.Equal(t, []string{"accounts-000001_6", "accounts-000001_5"}, result.DeletedIndices)
+	require.Equal(t, []string{"eligibility-merkle-root-6", "energy-snapshot-5", "run-report-5", "stake-stats-5"}, result.DeletedDocuments)
+	require.Empty(t, deletedIndices)
+	require.Empty(t, bulkBody)
+}
+
+func TestFilterEpochIndices(t *testing.T) {
+	t.Parallel()
+
+	snapshots := filterEpochIndices("accounts-000001", []*data.IndexInfo{
+		{Name: "accounts-000001_3"},
+		{Name: "filtered-accounts-000001_3"},
+		{Name: "accounts-000001_3-restored"},
+		{Name: "accounts-000001_99999999999"},
+	})
+	require.Len(t, snapshots, 1)
+	require.Equal(t, uint32(3), snapshots[0].epoch)
+}
@@ -0,0 +1,115 @@
+package process
+
+import (
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/notifier"
+)
+
+// ArgsRunNotifier holds the arguments needed to create a new run notifier
+type ArgsRunNotifier struct {
+	Notifiers       []Notifier
+	AnomalyDetector AnomalyDetector
+	// StakeStatsGetter is optional, a nil value disables the anomaly checks
+	StakeStatsGetter StakeStatsGetter
+}
+
+type runNotifier struct {
+	notifiers        []Notifier
+	anomalyDetector  AnomalyDetector
+	stakeStatsGetter StakeStatsGetter
+}
+
+// NewRunNotifier will create a new instance of runNotifier
+func NewRunNotifier(args ArgsRunNotifier) (*runNotifier, error) {
+	for idx, hook := range args.Notifiers {
+		if check.IfNil(hook) {
+			return nil, fmt.Errorf("%w, index %d", ErrNilNotifier, idx)
+		}
+	}
+	if check.IfNil(args.AnomalyDetector) {
+		return nil, ErrNilAnomalyDetector
+	}
+
+	return &runNotifier{
+		notifiers:        args.Notifiers,
+		anomalyDetector:  args.AnomalyDetector,
+		stakeStatsGetter: args.StakeStatsGetter,
+	}, nil
+}
+
+// Notify will compare the stake statistics of a successful run with the previous ones, adding the anomalies to the
+// report, and will notify the hooks subscribed to the event of the run: failure, anomaly or success. All the hooks are
+// notified even if one of them fails, the first error being returned
+func (rn *runNotifier) Notify(report *RunReport) error {
+	event := notifier.EventFailure
+	if report.Status == RunStatusSuccess {
+		report.Anomalies = rn.detectAnomalies(report)
+		event = notifier.EventSuccess
+		if len(report.Anomalies) > 0 {
+			event = notifier.EventAnomaly
+		}
+	}
+
+	notification := &notifier.Notification{
+		Event:         event,
+		Epoch:         report.Epoch,
+		Status:        report.Status,
+		ErrorCategory: report.ErrorCategory,
+		Error:         report.Error,
+		ExitCode:      report.ExitCode,
+		Anomalies:     report.Anomalies,
+		Report:        report,
+	}
+
+	var firstErr error
+	for idx, hook := range rn.notifiers {
+		if !hook.IsSubscribed(event) {
+			continue
+		}
+
+		err := hook.Notify(notification)
+		if err != nil {
+			log.Warn("cannot notify the hook", "hook index", idx, "event", event, "error", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return firstErr
+}
+
+// detectAnomalies returns the anomalies of the run, an error of the checks being only logged so the hooks are notified
+func (rn *runNotifier) detectAnomalies(report *RunReport) []*notifier.Anomaly {
+	if check.IfNil(rn.stakeStatsGetter) || report.StakeStats == nil {
+		return nil
+	}
+
+	previous, err := rn.stakeStatsGetter.GetPreviousStakeStats(report.Epoch)
+	if err != nil {
+		log.Warn("cannot get the stake stats of the previous run, the anomaly checks are skipped", "error", err)
+		return nil
+	}
+	if previous == nil {
+		log.Info("no previous run with stake stats, the anomaly checks are skipped", "epoch", report.Epoch)
+		return nil
+	}
+
+	anomalies, err := rn.anomalyDetector.Detect(previous, report.StakeStats)
+	if err != nil {
+		log.Warn("cannot check the anomalies of the run", "error", err)
+		return nil
+	}
+	for _, anomaly := range anomalies {
+		log.Warn("anomaly", "description", anomaly.String())
+	}
+
+	return anomalies
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rn *runNotifier) IsInterfaceNil() bool {
+	return rn == nil
+}
@@ -0,0 +1,112 @@
+package process
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/config"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/notifier"
+	"github.com/stretchr/testify/require"
+)
+
+type notifierStub struct {
+	events        []string
+	notifications []*notifier.Notification
+	err           error
+}
+
+func (ns *notifierStub) Notify(notification *notifier.Notification) error {
+	ns.notifications = append(ns.notifications, notification)
+	return ns.err
+}
+
+func (ns *notifierStub) IsSubscribed(event string) bool {
+	for _, subscribedEvent := range ns.events {
+		if subscribedEvent == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (ns *notifierStub) IsInterfaceNil() bool {
+	return ns == nil
+}
+
+type stakeStatsGetterStub struct {
+	previous *data.StakeStats
+}
+
+func (ssgs *stakeStatsGetterStub) GetPreviousStakeStats(_ uint32) (*data.StakeStats, error) {
+	return ssgs.previous, nil
+}
+
+func (ssgs *stakeStatsGetterStub) IsInterfaceNil() bool {
+	return ssgs == nil
+}
+
+func createSuccessfulReport(numStakers uint64) *RunReport {
+	report := NewRunReport()
+	report.Epoch = 10
+	report.StakeStats = &data.StakeStats{Epoch: 10, NumStakers: numStakers}
+	report.Finish(nil)
+
+	return report
+}
+
+func TestNewRunNotifier(t *testing.T) {
+	t.Parallel()
+
+	detector, _ := notifier.NewAnomalyDetector(nil)
+
+	_, err := NewRunNotifier(ArgsRunNotifier{Notifiers: []Notifier{nil}, AnomalyDetector: detector})
+	require.True(t, errors.Is(err, ErrNilNotifier))
+
+	_, err = NewRunNotifier(ArgsRunNotifier{})
+	require.Equal(t, ErrNilAnomalyDetector, err)
+
+	rn, err := NewRunNotifier(ArgsRunNotifier{AnomalyDetector: detector})
+	require.Nil(t, err)
+	require.False(t, rn.IsInterfaceNil())
+	require.Nil(t, rn.Notify(createSuccessfulReport(100)))
+}
+
+func TestRunNotifier_Notify(t *testing.T) {
+	t.Parallel()
+
+	detector, _ := notifier.NewAnomalyDetector([]config.AnomalyThresholdConfig{{Field: "numStakers", MaxDropPercent: 10}})
+	expectedErr := errors.New("webhook unavailable")
+	failingHook := &notifierStub{events: []string{notifier.EventFailure, notifier.EventAnomaly}, err: expectedErr}
+	successHook := &notifierStub{events: []string{notifier.EventSuccess}}
+	rn, _ := NewRunNotifier(ArgsRunNotifier{
+		Notifiers:        []Notifier{failingHook, successHook},
+		AnomalyDetector:  detector,
+		StakeStatsGetter: &stakeStatsGetterStub{previous: &data.StakeStats{Epoch: 9, NumStakers: 100}},
+	})
+
+	require.Nil(t, rn.Notify(createSuccessfulReport(95)))
+	require.Len(t, successHook.notifications, 1)
+	require.Equal(t, notifier.EventSuccess, successHook.notifications[0].Event)
+	require.Empty(t, failingHook.notifications)
+
+	// all the hooks are notified even if the first one fails
+	report := createSuccessfulReport(80)
+	require.Equal(t, expectedErr, rn.Notify(report))
+	require.Len(t, failingHook.notifications, 1)
+	require.Equal(t, notifier.EventAnomaly, failingHook.notifications[0].Event)
+	require.Len(t, report.Anomalies, 1)
+	require.Equal(t, report.Anomalies, failingHook.notifications[0].Anomalies)
+	require.Equal(t, report, failingHook.notifications[0].Report)
+
+	report = NewRunReport()
+	report.Finish(NewCategorizedError(ErrElasticsearch, errors.New("bulk request failed")))
+	require.Equal(t, expectedErr, rn.Notify(report))
+	require.Len(t, failingHook.notifications, 2)
+	notification := failingHook.notifications[1]
+	require.Equal(t, notifier.EventFailure, notification.Event)
+	require.Equal(t, ExitCodeElasticsearch, notification.ExitCode)
+	require.Equal(t, errorCategoryElasticsearch, notification.ErrorCategory)
+	require.Len(t, successHook.notifications, 1)
+}
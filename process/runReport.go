@@ -0,0 +1,82 @@
+package process
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/guardrails"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/notifier"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/process/snapshots"
+)
+
+const (
+	// RunStatusSuccess is the status of a successful run
+	RunStatusSuccess = "success"
+	// RunStatusFailed is the status of a failed run
+	RunStatusFailed = "failed"
+
+	runReportKeyPrefix = "run-report-"
+)
+
+// ElasticClientReport holds the bulk counters of a destination elastic client during a run. Index is the position of
+// the client in the configured destination clients
+type ElasticClientReport struct {
+	Index   int    `json:"index"`
+	Address string `json:"address"`
+	data.BulkStats
+}
+
+// RunReport holds the outcome of a run
+type RunReport struct {
+	Key                  string                          `json:"key"`
+	Epoch                uint32                          `json:"epoch"`
+	Index                string                          `json:"index"`
+	ReferenceBlock       *data.BlockInfo                 `json:"referenceBlock,omitempty"`
+	StartTime            time.Time                       `json:"startTime"`
+	EndTime              time.Time                       `json:"endTime"`
+	DurationMs           int64                           `json:"durationMs"`
+	Sources              []*data.SourceFetchStats        `json:"sources"`
+	NumAccountsWithStake int                             `json:"numAccountsWithStake"`
+	GuardrailViolations  []*guardrails.Violation         `json:"guardrailViolations,omitempty"`
+	GuardrailsForced     bool                            `json:"guardrailsForced,omitempty"`
+	NumSourceDocuments   uint64                          `json:"numSourceDocuments"`
+	NumIndexedAccounts   uint64                          `json:"numIndexedAccounts"`
+	NumFilteredAccounts  uint64                          `json:"numFilteredAccounts"`
+	StakeStats           *data.StakeStats                `json:"stakeStats,omitempty"`
+	Anomalies            []*notifier.Anomaly             `json:"anomalies,omitempty"`
+	Destinations         []*data.DestinationStats        `json:"destinations"`
+	ElasticClients       []*ElasticClientReport          `json:"elasticClients"`
+	Verification         []*snapshots.VerificationResult `json:"verification,omitempty"`
+	Status               string                          `json:"status"`
+	ErrorCategory        string                          `json:"errorCategory,omitempty"`
+	Error                string                          `json:"error,omitempty"`
+	ExitCode             int                             `json:"exitCode"`
+}
+
+// NewRunReport returns an empty report of a run started now
+func NewRunReport() *RunReport {
+	return &RunReport{
+		StartTime:      time.Now(),
+		Sources:        make([]*data.SourceFetchStats, 0),
+		Destinations:   make([]*data.DestinationStats, 0),
+		ElasticClients: make([]*ElasticClientReport, 0),
+	}
+}
+
+// Finish will set the end time, the status and the exit code of the run, based on the provided error
+func (rr *RunReport) Finish(err error) {
+	rr.EndTime = time.Now()
+	rr.DurationMs = rr.EndTime.Sub(rr.StartTime).Milliseconds()
+	rr.ExitCode = ExitCode(err)
+	rr.ErrorCategory = ErrorCategory(err)
+	rr.Status = RunStatusSuccess
+	if err != nil {
+		rr.Status = RunStatusFailed
+		rr.Error = err.Error()
+	}
+}
+
+func runReportKey(epoch uint32) string {
+	return fmt.Sprintf("%s%d", runReportKeyPrefix, epoch)
+}
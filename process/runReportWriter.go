@@ -0,0 +1,104 @@
+package process
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+)
+
+const (
+	epochPlaceholder = "{epoch}"
+	valuesIndex      = "values"
+)
+
+// ArgsRunReportWriter holds the arguments needed to create a new run report writer
+type ArgsRunReportWriter struct {
+	// Path is the file of the report, {epoch} being replaced with the epoch of the run. The file is not written if empty
+	Path string
+	// Clients are the elastic clients whose values index receives the report. The report is not indexed if empty
+	Clients []crossIndex.ElasticClientHandler
+}
+
+type runReportWriter struct {
+	path    string
+	clients []crossIndex.ElasticClientHandler
+}
+
+// NewRunReportWriter will create a new instance of runReportWriter
+func NewRunReportWriter(args ArgsRunReportWriter) (*runReportWriter, error) {
+	for idx, client := range args.Clients {
+		if check.IfNil(client) {
+			return nil, fmt.Errorf("%w, index %d", crossIndex.ErrNilElasticClient, idx)
+		}
+	}
+
+	return &runReportWriter{
+		path:    args.Path,
+		clients: args.Clients,
+	}, nil
+}
+
+// Write will write the provided report in the configured file and in the values index of every client. All the
+// destinations are written even if one of them fails, the first error being returned
+func (rw *runReportWriter) Write(report *RunReport) error {
+	reportBytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	if rw.path != "" {
+		firstErr = rw.writeFile(report, reportBytes)
+	}
+
+	if report.Key == "" {
+		// the epoch of the run is unknown, so there is no document id
+		return firstErr
+	}
+
+	for idx, client := range rw.clients {
+		err = client.DoRequest(valuesIndex, report.Key, bytes.NewBuffer(reportBytes))
+		if err != nil {
+			log.Warn("cannot index the run report", "client index", idx, "error", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return firstErr
+}
+
+func (rw *runReportWriter) writeFile(report *RunReport, reportBytes []byte) error {
+	epoch := "unknown"
+	if report.Key != "" {
+		epoch = fmt.Sprintf("%d", report.Epoch)
+	}
+	path := strings.ReplaceAll(rw.path, epochPlaceholder, epoch)
+
+	err := os.MkdirAll(filepath.Dir(path), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(path, reportBytes, 0644)
+	if err != nil {
+		return err
+	}
+
+	log.Info("wrote the run report", "path", path, "status", report.Status)
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rw *runReportWriter) IsInterfaceNil() bool {
+	return rw == nil
+}
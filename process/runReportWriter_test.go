@@ -0,0 +1,95 @@
+package process
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRunReportWriter(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewRunReportWriter(ArgsRunReportWriter{Clients: []crossIndex.ElasticClientHandler{nil}})
+	require.True(t, errors.Is(err, crossIndex.ErrNilElasticClient))
+
+	rw, err := NewRunReportWriter(ArgsRunReportWriter{})
+	require.Nil(t, err)
+	require.False(t, rw.IsInterfaceNil())
+}
+
+func TestRunReportWriter_Write(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	expectedErr := errors.New("cannot index")
+	indexedDocuments := make(map[string][]byte)
+	clients := []crossIndex.ElasticClientHandler{
+		&mocks.ElasticClientStub{
+			DoRequestCalled: func(_, _ string, _ *bytes.Buffer) error {
+				return expectedErr
+			},
+		},
+		&mocks.ElasticClientStub{
+			DoRequestCalled: func(index, documentID string, buff *bytes.Buffer) error {
+				require.Equal(t, valuesIndex, index)
+				indexedDocuments[documentID] = buff.Bytes()
+				return nil
+			},
+		},
+	}
+	rw, _ := NewRunReportWriter(ArgsRunReportWriter{
+		Path:    filepath.Join(dir, "reports", "run-report-{epoch}.json"),
+		Clients: clients,
+	})
+
+	report := NewRunReport()
+	report.Epoch = 5
+	report.Key = runReportKey(5)
+	report.Finish(nil)
+
+	// all the clients are written even if the first one fails
+	err := rw.Write(report)
+	require.Equal(t, expectedErr, err)
+	require.Contains(t, indexedDocuments, "run-report-5")
+
+	fileBytes, err := ioutil.ReadFile(filepath.Join(dir, "reports", "run-report-5.json"))
+	require.Nil(t, err)
+	require.Equal(t, fileBytes, indexedDocuments["run-report-5"])
+
+	writtenReport := &RunReport{}
+	require.Nil(t, json.Unmarshal(fileBytes, writtenReport))
+	require.Equal(t, RunStatusSuccess, writtenReport.Status)
+	require.Equal(t, uint32(5), writtenReport.Epoch)
+}
+
+func TestRunReportWriter_WriteReportWithoutEpoch(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	rw, _ := NewRunReportWriter(ArgsRunReportWriter{
+		Path: filepath.Join(dir, "run-report-{epoch}.json"),
+		Clients: []crossIndex.ElasticClientHandler{
+			&mocks.ElasticClientStub{
+				DoRequestCalled: func(_, _ string, _ *bytes.Buffer) error {
+					require.Fail(t, "a report without epoch should not be indexed")
+					return nil
+				},
+			},
+		},
+	})
+
+	report := NewRunReport()
+	report.Finish(NewCategorizedError(ErrUpstreamAPI, errors.New("cannot get the epoch")))
+	require.Nil(t, rw.Write(report))
+
+	fileBytes, err := ioutil.ReadFile(filepath.Join(dir, "run-report-unknown.json"))
+	require.Nil(t, err)
+	require.Contains(t, string(fileBytes), `"exitCode": 3`)
+}
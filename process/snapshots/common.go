@@ -0,0 +1,43 @@
+package snapshots
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	logger "github.com/multiversx/mx-chain-logger-go"
+)
+
+const valuesIndex = "values"
+
+var log = logger.GetOrCreate("process/snapshots")
+
+func checkClients(clients []ElasticClientHandler) error {
+	if len(clients) == 0 {
+		return ErrNilElasticClient
+	}
+	for idx, client := range clients {
+		if check.IfNil(client) {
+			return fmt.Errorf("%w, index %d", ErrNilElasticClient, idx)
+		}
+	}
+
+	return nil
+}
+
+// parseSnapshotEpoch returns the epoch of an index named exactly <accountsIndex>_<epoch>
+func parseSnapshotEpoch(accountsIndex string, index string) (uint32, bool) {
+	pattern := regexp.MustCompile(`^` + regexp.QuoteMeta(accountsIndex) + `_(\d+)$`)
+	matches := pattern.FindStringSubmatch(index)
+	if matches == nil {
+		return 0, false
+	}
+
+	epoch, err := strconv.ParseUint(matches[1], 10, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return uint32(epoch), true
+}
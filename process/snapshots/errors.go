@@ -0,0 +1,24 @@
+package snapshots
+
+import "errors"
+
+// ErrNilElasticClient signals that a nil elastic client has been provided
+var ErrNilElasticClient = errors.New("nil elastic client")
+
+// ErrEmptyAccountsIndex signals that an empty accounts index name has been provided
+var ErrEmptyAccountsIndex = errors.New("empty accounts index")
+
+// ErrNoSnapshot signals that no accounts snapshot has been found
+var ErrNoSnapshot = errors.New("no accounts snapshot found")
+
+// ErrVerificationFailed signals that the verification of a snapshot found problems
+var ErrVerificationFailed = errors.New("snapshot verification failed")
+
+// ErrEmptyDestinations signals that no destination has been provided for the export
+var ErrEmptyDestinations = errors.New("empty destinations")
+
+// ErrNilDestination signals that a nil destination has been provided
+var ErrNilDestination = errors.New("nil destination")
+
+// ErrNilReindexSummary signals that a nil reindex summary has been provided
+var ErrNilReindexSummary = errors.New("nil reindex summary")
@@ -0,0 +1,16 @@
+package snapshots
+
+import (
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+)
+
+// ElasticClientHandler defines what an elastic client should be able to do
+type ElasticClientHandler interface {
+	GetIndices(index string) ([]*data.IndexInfo, error)
+	CheckIfIndexExists(index string) (bool, error)
+	RefreshIndex(index string) error
+	CountDocuments(index string) (uint64, error)
+	DoMultiGet(ids []string, index string) ([]byte, error)
+	DoScrollRequestAllDocuments(index string, body []byte, handlerFunc func(responseBytes []byte) error) error
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,116 @@
+package snapshots
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/tidwall/gjson"
+)
+
+// ArgsSnapshotExporter holds the arguments needed to create a new snapshot exporter
+type ArgsSnapshotExporter struct {
+	Client       ElasticClientHandler
+	Destinations []crossIndex.Destination
+}
+
+type snapshotExporter struct {
+	client       ElasticClientHandler
+	destinations []crossIndex.Destination
+}
+
+// NewSnapshotExporter will create a new instance of snapshotExporter
+func NewSnapshotExporter(args ArgsSnapshotExporter) (*snapshotExporter, error) {
+	if check.IfNil(args.Client) {
+		return nil, ErrNilElasticClient
+	}
+	if len(args.Destinations) == 0 {
+		return nil, ErrEmptyDestinations
+	}
+	for idx, destination := range args.Destinations {
+		if check.IfNil(destination) {
+			return nil, fmt.Errorf("%w, index %d", ErrNilDestination, idx)
+		}
+	}
+
+	return &snapshotExporter{
+		client:       args.Client,
+		destinations: args.Destinations,
+	}, nil
+}
+
+// Export will read an already indexed snapshot and will write it in the destinations. The ranks are part of the indexed
+// documents, so they are written together with the accounts
+func (se *snapshotExporter) Export(index string, epoch uint32) error {
+	exists, err := se.client.CheckIfIndexExists(index)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: index %s", ErrNoSnapshot, index)
+	}
+
+	for _, destination := range se.destinations {
+		err = destination.PrepareSnapshot(index, epoch)
+		if err != nil {
+			return err
+		}
+	}
+
+	numAccounts := 0
+	handler := func(responseBytes []byte) error {
+		accounts, errParse := parseAccountsPage(responseBytes)
+		if errParse != nil {
+			return errParse
+		}
+		numAccounts += len(accounts)
+
+		for _, destination := range se.destinations {
+			errWrite := destination.WriteBatch(accounts)
+			if errWrite != nil {
+				return errWrite
+			}
+		}
+
+		return nil
+	}
+
+	err = se.client.DoScrollRequestAllDocuments(index, crossIndex.GetAll().Bytes(), handler)
+	if err != nil {
+		return err
+	}
+
+	for _, destination := range se.destinations {
+		err = destination.Finalize()
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Info("exported accounts snapshot", "index", index, "num accounts", numAccounts, "num destinations", len(se.destinations))
+
+	return nil
+}
+
+func parseAccountsPage(responseBytes []byte) (map[string]*data.AccountInfoWithStakeValues, error) {
+	hits := gjson.GetBytes(responseBytes, "hits.hits").Array()
+	accounts := make(map[string]*data.AccountInfoWithStakeValues, len(hits))
+	for _, hit := range hits {
+		account := &data.AccountInfoWithStakeValues{}
+		err := json.Unmarshal([]byte(hit.Get("_source").Raw), account)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts[hit.Get("_id").String()] = account
+	}
+
+	return accounts, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (se *snapshotExporter) IsInterfaceNil() bool {
+	return se == nil
+}
@@ -0,0 +1,77 @@
+package snapshots
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSnapshotExporter(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSnapshotExporter(ArgsSnapshotExporter{Destinations: []crossIndex.Destination{&mocks.DestinationStub{}}})
+	require.Equal(t, ErrNilElasticClient, err)
+
+	_, err = NewSnapshotExporter(ArgsSnapshotExporter{Client: &mocks.ElasticClientStub{}})
+	require.Equal(t, ErrEmptyDestinations, err)
+
+	exporter, err := NewSnapshotExporter(ArgsSnapshotExporter{
+		Client:       &mocks.ElasticClientStub{},
+		Destinations: []crossIndex.Destination{&mocks.DestinationStub{}},
+	})
+	require.Nil(t, err)
+	require.False(t, exporter.IsInterfaceNil())
+}
+
+func TestSnapshotExporter_Export(t *testing.T) {
+	t.Parallel()
+
+	client := &mocks.ElasticClientStub{
+		CheckIfIndexExistsCalled: func(index string) (bool, error) {
+			return index == "accounts-000001_5", nil
+		},
+		DoScrollRequestAllDocumentsCalled: func(index string, body []byte, handlerFunc func(responseBytes []byte) error) error {
+			return handlerFunc([]byte(`{"hits":{"hits":[
+				{"_id":"erd1a","_source":{"balance":"10","totalStake":"5","rankStake":1}},
+				{"_id":"erd1b","_source":{"balance":"20"}}
+			]}}`))
+		},
+	}
+
+	calls := make([]string, 0)
+	written := make(map[string]*data.AccountInfoWithStakeValues)
+	destination := &mocks.DestinationStub{
+		PrepareSnapshotCalled: func(index string, epoch uint32) error {
+			calls = append(calls, "prepare")
+			return nil
+		},
+		WriteBatchCalled: func(accounts map[string]*data.AccountInfoWithStakeValues) error {
+			calls = append(calls, "write")
+			for address, account := range accounts {
+				written[address] = account
+			}
+			return nil
+		},
+		FinalizeCalled: func() error {
+			calls = append(calls, "finalize")
+			return nil
+		},
+	}
+	exporter, _ := NewSnapshotExporter(ArgsSnapshotExporter{
+		Client:       client,
+		Destinations: []crossIndex.Destination{destination},
+	})
+
+	err := exporter.Export("accounts-000001_4", 4)
+	require.ErrorIs(t, err, ErrNoSnapshot)
+
+	err = exporter.Export("accounts-000001_5", 5)
+	require.Nil(t, err)
+	require.Equal(t, []string{"prepare", "write", "finalize"}, calls)
+	require.Len(t, written, 2)
+	require.Equal(t, "5", written["erd1a"].TotalStake)
+	require.Equal(t, uint64(1), written["erd1a"].RankStake)
+}
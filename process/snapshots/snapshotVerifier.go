@@ -0,0 +1,349 @@
+package snapshots
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/tidwall/gjson"
+)
+
+const maxReportedSampleMismatches = 10
+
+// VerificationResult holds the outcome of the verification of a snapshot on a destination client
+type VerificationResult struct {
+	ClientIndex         int      `json:"clientIndex"`
+	Index               string   `json:"index"`
+	Exists              bool     `json:"exists"`
+	NumDocuments        uint64   `json:"numDocuments"`
+	ExpectedNumAccounts uint64   `json:"expectedNumAccounts"`
+	Problems            []string `json:"problems"`
+	// the fields below are set only by the verification of a run
+	SourceIndex          string   `json:"sourceIndex,omitempty"`
+	SourceNumDocuments   uint64   `json:"sourceNumDocuments,omitempty"`
+	NumDocumentsRead     uint64   `json:"numDocumentsRead,omitempty"`
+	NumFilteredAccounts  uint64   `json:"numFilteredAccounts,omitempty"`
+	NumFilteredDocuments uint64   `json:"numFilteredDocuments,omitempty"`
+	NumSampledAccounts   int      `json:"numSampledAccounts,omitempty"`
+	NumSampleMismatches  int      `json:"numSampleMismatches,omitempty"`
+	Warnings             []string `json:"warnings,omitempty"`
+}
+
+// Passed returns true if the verification found no problem
+func (vr *VerificationResult) Passed() bool {
+	return len(vr.Problems) == 0
+}
+
+// ArgsSnapshotVerifier holds the arguments needed to create a new snapshot verifier
+type ArgsSnapshotVerifier struct {
+	Clients []ElasticClientHandler
+	// SourceClient is the client of the source accounts index, needed only by VerifyRun
+	SourceClient ElasticClientHandler
+	// FilteredAccountsIndexPrefix is the prefix of the index holding the filtered accounts of a run, empty if the
+	// filtered accounts are not indexed
+	FilteredAccountsIndexPrefix string
+}
+
+type snapshotVerifier struct {
+	clients                     []ElasticClientHandler
+	sourceClient                ElasticClientHandler
+	filteredAccountsIndexPrefix string
+}
+
+// NewSnapshotVerifier will create a new instance of snapshotVerifier
+func NewSnapshotVerifier(args ArgsSnapshotVerifier) (*snapshotVerifier, error) {
+	err := checkClients(args.Clients)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshotVerifier{
+		clients:                     args.Clients,
+		sourceClient:                args.SourceClient,
+		filteredAccountsIndexPrefix: args.FilteredAccountsIndexPrefix,
+	}, nil
+}
+
+// Verify will check on every client that the snapshot index exists and holds the number of accounts recorded in the
+// stake stats document of the epoch. ErrVerificationFailed is returned together with the results if any check fails
+func (sv *snapshotVerifier) Verify(index string, epoch uint32) ([]*VerificationResult, error) {
+	results := make([]*VerificationResult, 0, len(sv.clients))
+	for idx, client := range sv.clients {
+		result, err := sv.verifyClient(idx, client, index, epoch)
+		if err != nil {
+			return nil, fmt.Errorf("%w, client index %d", err, idx)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, failedResultsError(results)
+}
+
+// VerifyRun will check on every client the index written by a run: the index has to hold the number of accounts merged
+// by the run, which has to match the number of documents read from the source index, the filtered accounts index has to
+// hold the accounts filtered by the run and the sampled accounts have to be indexed with the values computed by the run.
+// ErrVerificationFailed is returned together with the results if any check fails
+func (sv *snapshotVerifier) VerifyRun(sourceIndex string, index string, summary *data.ReindexSummary) ([]*VerificationResult, error) {
+	if check.IfNil(sv.sourceClient) {
+		return nil, fmt.Errorf("%w for the source index", ErrNilElasticClient)
+	}
+	if summary == nil {
+		return nil, ErrNilReindexSummary
+	}
+
+	sourceNumDocuments, err := sv.sourceClient.CountDocuments(sourceIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*VerificationResult, 0, len(sv.clients))
+	for idx, client := range sv.clients {
+		result, errVerify := sv.verifyRunOnClient(idx, client, index, summary)
+		if errVerify != nil {
+			return nil, fmt.Errorf("%w, client index %d", errVerify, idx)
+		}
+
+		result.SourceIndex = sourceIndex
+		result.SourceNumDocuments = sourceNumDocuments
+		if sourceNumDocuments != summary.NumSourceDocuments {
+			// the source index is updated while the run reads it, so the difference is only reported
+			result.Warnings = append(result.Warnings, fmt.Sprintf("source index has %d documents, %d were read by the run", sourceNumDocuments, summary.NumSourceDocuments))
+		}
+
+		results = append(results, result)
+	}
+
+	return results, failedResultsError(results)
+}
+
+func (sv *snapshotVerifier) verifyRunOnClient(clientIndex int, client ElasticClientHandler, index string, summary *data.ReindexSummary) (*VerificationResult, error) {
+	result := &VerificationResult{
+		ClientIndex:         clientIndex,
+		Index:               index,
+		ExpectedNumAccounts: summary.NumIndexedAccounts,
+		NumDocumentsRead:    summary.NumSourceDocuments,
+		NumFilteredAccounts: summary.NumFilteredAccounts,
+		Problems:            make([]string, 0),
+	}
+
+	numMergedAccounts := summary.NumIndexedAccounts + summary.NumFilteredAccounts
+	if numMergedAccounts != summary.NumSourceDocuments {
+		result.Problems = append(result.Problems, fmt.Sprintf("run read %d source documents, but merged %d accounts", summary.NumSourceDocuments, numMergedAccounts))
+	}
+
+	exists, err := client.CheckIfIndexExists(index)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		result.Problems = append(result.Problems, "index does not exist")
+		return result, nil
+	}
+	result.Exists = true
+
+	err = client.RefreshIndex(index)
+	if err != nil {
+		return nil, err
+	}
+
+	result.NumDocuments, err = client.CountDocuments(index)
+	if err != nil {
+		return nil, err
+	}
+	if result.NumDocuments != result.ExpectedNumAccounts {
+		result.Problems = append(result.Problems, fmt.Sprintf("index has %d documents, expected %d accounts", result.NumDocuments, result.ExpectedNumAccounts))
+	}
+
+	err = sv.verifyFilteredAccountsIndex(client, index, result)
+	if err != nil {
+		return nil, err
+	}
+
+	err = verifySampledAccounts(client, index, summary.SampledAccounts, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (sv *snapshotVerifier) verifyFilteredAccountsIndex(client ElasticClientHandler, index string, result *VerificationResult) error {
+	if sv.filteredAccountsIndexPrefix == "" {
+		return nil
+	}
+
+	filteredIndex := sv.filteredAccountsIndexPrefix + index
+	exists, err := client.CheckIfIndexExists(filteredIndex)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		result.Problems = append(result.Problems, fmt.Sprintf("index %s does not exist", filteredIndex))
+		return nil
+	}
+
+	err = client.RefreshIndex(filteredIndex)
+	if err != nil {
+		return err
+	}
+
+	result.NumFilteredDocuments, err = client.CountDocuments(filteredIndex)
+	if err != nil {
+		return err
+	}
+	if result.NumFilteredDocuments != result.NumFilteredAccounts {
+		result.Problems = append(result.Problems, fmt.Sprintf("index %s has %d documents, expected %d filtered accounts",
+			filteredIndex, result.NumFilteredDocuments, result.NumFilteredAccounts))
+	}
+
+	return nil
+}
+
+// verifySampledAccounts will fetch the sampled accounts and will compare every field written by the run with the
+// indexed one. The fields updated after the accounts were written, like the ranks, are not compared
+func verifySampledAccounts(client ElasticClientHandler, index string, sampledAccounts map[string][]byte, result *VerificationResult) error {
+	if len(sampledAccounts) == 0 {
+		return nil
+	}
+
+	addresses := make([]string, 0, len(sampledAccounts))
+	for address := range sampledAccounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	result.NumSampledAccounts = len(addresses)
+
+	response, err := client.DoMultiGet(addresses, index)
+	if err != nil {
+		return err
+	}
+
+	indexedAccounts := make(map[string]gjson.Result)
+	for _, document := range gjson.GetBytes(response, "docs").Array() {
+		if document.Get("found").Bool() {
+			indexedAccounts[document.Get("_id").String()] = document.Get("_source")
+		}
+	}
+
+	mismatches := make([]string, 0)
+	for _, address := range addresses {
+		indexedAccount, found := indexedAccounts[address]
+		if !found {
+			mismatches = append(mismatches, fmt.Sprintf("account %s not found", address))
+			continue
+		}
+
+		differentFields, errCompare := compareAccountFields(sampledAccounts[address], []byte(indexedAccount.Raw))
+		if errCompare != nil {
+			return errCompare
+		}
+		if len(differentFields) > 0 {
+			mismatches = append(mismatches, fmt.Sprintf("account %s has different %s", address, strings.Join(differentFields, ", ")))
+		}
+	}
+
+	result.NumSampleMismatches = len(mismatches)
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	if len(mismatches) > maxReportedSampleMismatches {
+		mismatches = append(mismatches[:maxReportedSampleMismatches], fmt.Sprintf("%d more", len(mismatches)-maxReportedSampleMismatches))
+	}
+	result.Problems = append(result.Problems, fmt.Sprintf("%d of %d sampled accounts do not match: %s",
+		result.NumSampleMismatches, result.NumSampledAccounts, strings.Join(mismatches, "; ")))
+
+	return nil
+}
+
+func compareAccountFields(expectedAccount []byte, indexedAccount []byte) ([]string, error) {
+	expectedFields := make(map[string]interface{})
+	err := json.Unmarshal(expectedAccount, &expectedFields)
+	if err != nil {
+		return nil, err
+	}
+
+	indexedFields := make(map[string]interface{})
+	err = json.Unmarshal(indexedAccount, &indexedFields)
+	if err != nil {
+		return nil, err
+	}
+
+	differentFields := make([]string, 0)
+	for field, expectedValue := range expectedFields {
+		if !reflect.DeepEqual(expectedValue, indexedFields[field]) {
+			differentFields = append(differentFields, field)
+		}
+	}
+	sort.Strings(differentFields)
+
+	return differentFields, nil
+}
+
+func failedResultsError(results []*VerificationResult) error {
+	failed := make([]string, 0)
+	for _, result := range results {
+		if !result.Passed() {
+			failed = append(failed, fmt.Sprintf("client %d: %s", result.ClientIndex, strings.Join(result.Problems, ", ")))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%w: %s", ErrVerificationFailed, strings.Join(failed, "; "))
+	}
+
+	return nil
+}
+
+func (sv *snapshotVerifier) verifyClient(clientIndex int, client ElasticClientHandler, index string, epoch uint32) (*VerificationResult, error) {
+	result := &VerificationResult{
+		ClientIndex: clientIndex,
+		Index:       index,
+		Problems:    make([]string, 0),
+	}
+
+	exists, err := client.CheckIfIndexExists(index)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		result.Problems = append(result.Problems, "index does not exist")
+		return result, nil
+	}
+	result.Exists = true
+
+	result.NumDocuments, err = client.CountDocuments(index)
+	if err != nil {
+		return nil, err
+	}
+
+	stakeStatsID := fmt.Sprintf(crossIndex.StakeStatsIDFormat, epoch)
+	response, err := client.DoMultiGet([]string{stakeStatsID}, valuesIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	stakeStats := gjson.GetBytes(response, "docs.0")
+	if !stakeStats.Get("found").Bool() {
+		result.Problems = append(result.Problems, fmt.Sprintf("document %s not found", stakeStatsID))
+		return result, nil
+	}
+
+	result.ExpectedNumAccounts = stakeStats.Get("_source.numAccounts").Uint()
+	if result.ExpectedNumAccounts != result.NumDocuments {
+		result.Problems = append(result.Problems, fmt.Sprintf("index has %d documents, expected %d accounts", result.NumDocuments, result.ExpectedNumAccounts))
+	}
+
+	return result, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sv *snapshotVerifier) IsInterfaceNil() bool {
+	return sv == nil
+}
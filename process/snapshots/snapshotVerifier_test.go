@@ -0,0 +1,197 @@
+package snapshots
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func createVerifierClient(exists bool, numDocuments uint64, stakeStats string) *mocks.ElasticClientStub {
+	return &mocks.ElasticClientStub{
+		CheckIfIndexExistsCalled: func(index string) (bool, error) {
+			return exists, nil
+		},
+		CountDocumentsCalled: func(index string) (uint64, error) {
+			return numDocuments, nil
+		},
+		DoMultiGetCalled: func(ids []string, index string) ([]byte, error) {
+			return []byte(stakeStats), nil
+		},
+	}
+}
+
+func TestSnapshotVerifier_Verify(t *testing.T) {
+	t.Parallel()
+
+	stakeStats := `{"docs":[{"_id":"stake-stats-5","found":true,"_source":{"numAccounts":10}}]}`
+	verifier, err := NewSnapshotVerifier(ArgsSnapshotVerifier{
+		Clients: []ElasticClientHandler{createVerifierClient(true, 10, stakeStats)},
+	})
+	require.Nil(t, err)
+	require.False(t, verifier.IsInterfaceNil())
+
+	results, err := verifier.Verify("accounts-000001_5", 5)
+	require.Nil(t, err)
+	require.True(t, results[0].Passed())
+	require.Equal(t, uint64(10), results[0].ExpectedNumAccounts)
+}
+
+func TestSnapshotVerifier_VerifyFailures(t *testing.T) {
+	t.Parallel()
+
+	verifier, _ := NewSnapshotVerifier(ArgsSnapshotVerifier{
+		Clients: []ElasticClientHandler{
+			createVerifierClient(true, 9, `{"docs":[{"_id":"stake-stats-5","found":true,"_source":{"numAccounts":10}}]}`),
+			createVerifierClient(false, 0, ""),
+			createVerifierClient(true, 10, `{"docs":[{"_id":"stake-stats-5","found":false}]}`),
+		},
+	})
+
+	results, err := verifier.Verify("accounts-000001_5", 5)
+	require.True(t, errors.Is(err, ErrVerificationFailed))
+	require.Len(t, results, 3)
+	require.Equal(t, []string{"index has 9 documents, expected 10 accounts"}, results[0].Problems)
+	require.Equal(t, []string{"index does not exist"}, results[1].Problems)
+	require.Equal(t, []string{"document stake-stats-5 not found"}, results[2].Problems)
+}
+
+func createRunVerifierClient(numDocuments uint64, indexedDocuments string, refreshedIndices *[]string) *mocks.ElasticClientStub {
+	return &mocks.ElasticClientStub{
+		CheckIfIndexExistsCalled: func(index string) (bool, error) {
+			return true, nil
+		},
+		RefreshIndexCalled: func(index string) error {
+			*refreshedIndices = append(*refreshedIndices, index)
+			return nil
+		},
+		CountDocumentsCalled: func(index string) (uint64, error) {
+			return numDocuments, nil
+		},
+		DoMultiGetCalled: func(ids []string, index string) ([]byte, error) {
+			return []byte(indexedDocuments), nil
+		},
+	}
+}
+
+func TestSnapshotVerifier_VerifyRun(t *testing.T) {
+	t.Parallel()
+
+	summary := &data.ReindexSummary{
+		NumSourceDocuments:  12,
+		NumIndexedAccounts:  10,
+		NumFilteredAccounts: 2,
+		SampledAccounts: map[string][]byte{
+			"addr1": []byte(`{"address":"addr1","balance":"10","balanceNum":0.00001,"totalStake":"5"}`),
+			"addr2": []byte(`{"address":"addr2","balance":"20"}`),
+		},
+	}
+	matchingDocuments := `{"docs":[
+		{"_id":"addr1","found":true,"_source":{"address":"addr1","balance":"10","balanceNum":0.00001,"totalStake":"5","rankStake":3}},
+		{"_id":"addr2","found":true,"_source":{"address":"addr2","balance":"20","rankStake":1}}
+	]}`
+	differentDocuments := `{"docs":[
+		{"_id":"addr1","found":true,"_source":{"address":"addr1","balance":"10","balanceNum":0.00001,"totalStake":"6"}},
+		{"_id":"addr2","found":false}
+	]}`
+
+	refreshedIndices := make([]string, 0)
+	sourceClient := &mocks.ElasticClientStub{
+		CountDocumentsCalled: func(index string) (uint64, error) {
+			require.Equal(t, "accounts", index)
+			return 13, nil
+		},
+	}
+
+	verifier, _ := NewSnapshotVerifier(ArgsSnapshotVerifier{
+		Clients: []ElasticClientHandler{createRunVerifierClient(10, matchingDocuments, &refreshedIndices)},
+	})
+	_, err := verifier.VerifyRun("accounts", "accounts-000001_5", summary)
+	require.True(t, errors.Is(err, ErrNilElasticClient))
+
+	verifier, _ = NewSnapshotVerifier(ArgsSnapshotVerifier{
+		Clients: []ElasticClientHandler{
+			createRunVerifierClient(10, matchingDocuments, &refreshedIndices),
+			createRunVerifierClient(9, differentDocuments, &refreshedIndices),
+		},
+		SourceClient: sourceClient,
+	})
+
+	_, err = verifier.VerifyRun("accounts", "accounts-000001_5", nil)
+	require.Equal(t, ErrNilReindexSummary, err)
+
+	results, err := verifier.VerifyRun("accounts", "accounts-000001_5", summary)
+	require.True(t, errors.Is(err, ErrVerificationFailed))
+	require.Len(t, results, 2)
+	require.Equal(t, []string{"accounts-000001_5", "accounts-000001_5"}, refreshedIndices)
+
+	require.True(t, results[0].Passed())
+	require.Equal(t, 2, results[0].NumSampledAccounts)
+	require.Equal(t, uint64(13), results[0].SourceNumDocuments)
+	require.Equal(t, []string{"source index has 13 documents, 12 were read by the run"}, results[0].Warnings)
+
+	require.Equal(t, 2, results[1].NumSampleMismatches)
+	require.Equal(t, []string{
+		"index has 9 documents, expected 10 accounts",
+		"2 of 2 sampled accounts do not match: account addr1 has different totalStake; account addr2 not found",
+	}, results[1].Problems)
+
+	summary.NumSourceDocuments = 11
+	verifier, _ = NewSnapshotVerifier(ArgsSnapshotVerifier{
+		Clients:      []ElasticClientHandler{createRunVerifierClient(10, matchingDocuments, &refreshedIndices)},
+		SourceClient: sourceClient,
+	})
+	results, err = verifier.VerifyRun("accounts", "accounts-000001_5", summary)
+	require.True(t, errors.Is(err, ErrVerificationFailed))
+	require.Equal(t, []string{"run read 11 source documents, but merged 12 accounts"}, results[0].Problems)
+}
+
+func TestSnapshotVerifier_VerifyRunChecksTheFilteredAccountsIndex(t *testing.T) {
+	t.Parallel()
+
+	summary := &data.ReindexSummary{
+		NumSourceDocuments:  12,
+		NumIndexedAccounts:  10,
+		NumFilteredAccounts: 2,
+	}
+	sourceClient := &mocks.ElasticClientStub{
+		CountDocumentsCalled: func(index string) (uint64, error) {
+			return 12, nil
+		},
+	}
+	createClient := func(filteredIndexExists bool, numFilteredDocuments uint64) *mocks.ElasticClientStub {
+		return &mocks.ElasticClientStub{
+			CheckIfIndexExistsCalled: func(index string) (bool, error) {
+				if index == "filtered-accounts-000001_5" {
+					return filteredIndexExists, nil
+				}
+				return true, nil
+			},
+			CountDocumentsCalled: func(index string) (uint64, error) {
+				if index == "filtered-accounts-000001_5" {
+					return numFilteredDocuments, nil
+				}
+				return 10, nil
+			},
+		}
+	}
+
+	verifier, _ := NewSnapshotVerifier(ArgsSnapshotVerifier{
+		Clients: []ElasticClientHandler{
+			createClient(true, 2),
+			createClient(true, 1),
+			createClient(false, 0),
+		},
+		SourceClient:                sourceClient,
+		FilteredAccountsIndexPrefix: "filtered-",
+	})
+
+	results, err := verifier.VerifyRun("accounts", "accounts-000001_5", summary)
+	require.True(t, errors.Is(err, ErrVerificationFailed))
+	require.True(t, results[0].Passed())
+	require.Equal(t, uint64(2), results[0].NumFilteredDocuments)
+	require.Equal(t, []string{"index filtered-accounts-000001_5 has 1 documents, expected 2 filtered accounts"}, results[1].Problems)
+	require.Equal(t, []string{"index filtered-accounts-000001_5 does not exist"}, results[2].Problems)
+}
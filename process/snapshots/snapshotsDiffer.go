@@ -0,0 +1,151 @@
+package snapshots
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	diffBody          = `{"_source":["totalBalanceWithStakeNum"],"query":{"match_all":{}},"sort":["_doc"]}`
+	numLargestChanges = 10
+)
+
+// AccountChange holds the change of the total balance with stake of an account between two snapshots
+type AccountChange struct {
+	Address string  `json:"address"`
+	Before  float64 `json:"before"`
+	After   float64 `json:"after"`
+}
+
+// Delta returns the difference between the new and the old total balance with stake
+func (ac *AccountChange) Delta() float64 {
+	return ac.After - ac.Before
+}
+
+// DiffResult holds the differences between two snapshots
+type DiffResult struct {
+	IndexBefore                string           `json:"indexBefore"`
+	IndexAfter                 string           `json:"indexAfter"`
+	NumAccountsBefore          int              `json:"numAccountsBefore"`
+	NumAccountsAfter           int              `json:"numAccountsAfter"`
+	NumAdded                   int              `json:"numAdded"`
+	NumRemoved                 int              `json:"numRemoved"`
+	NumChanged                 int              `json:"numChanged"`
+	TotalBalanceWithStakeDelta float64          `json:"totalBalanceWithStakeDelta"`
+	LargestChanges             []*AccountChange `json:"largestChanges"`
+}
+
+// ArgsSnapshotsDiffer holds the arguments needed to create a new snapshots differ
+type ArgsSnapshotsDiffer struct {
+	Client ElasticClientHandler
+}
+
+type snapshotsDiffer struct {
+	client ElasticClientHandler
+}
+
+// NewSnapshotsDiffer will create a new instance of snapshotsDiffer
+func NewSnapshotsDiffer(args ArgsSnapshotsDiffer) (*snapshotsDiffer, error) {
+	if check.IfNil(args.Client) {
+		return nil, ErrNilElasticClient
+	}
+
+	return &snapshotsDiffer{
+		client: args.Client,
+	}, nil
+}
+
+// Diff will compare the total balances with stake of the accounts from two snapshots
+func (sd *snapshotsDiffer) Diff(indexBefore string, indexAfter string) (*DiffResult, error) {
+	before, err := sd.readBalances(indexBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := sd.readBalances(indexAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DiffResult{
+		IndexBefore:       indexBefore,
+		IndexAfter:        indexAfter,
+		NumAccountsBefore: len(before),
+		NumAccountsAfter:  len(after),
+	}
+
+	changes := make([]*AccountChange, 0)
+	for address, balanceAfter := range after {
+		balanceBefore, found := before[address]
+		if !found {
+			result.NumAdded++
+		}
+		if balanceBefore == balanceAfter {
+			continue
+		}
+		if found {
+			result.NumChanged++
+		}
+
+		changes = append(changes, &AccountChange{Address: address, Before: balanceBefore, After: balanceAfter})
+		result.TotalBalanceWithStakeDelta += balanceAfter - balanceBefore
+	}
+	for address, balanceBefore := range before {
+		if _, found := after[address]; found {
+			continue
+		}
+
+		result.NumRemoved++
+		changes = append(changes, &AccountChange{Address: address, Before: balanceBefore})
+		result.TotalBalanceWithStakeDelta -= balanceBefore
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		deltaI, deltaJ := math.Abs(changes[i].Delta()), math.Abs(changes[j].Delta())
+		if deltaI == deltaJ {
+			return changes[i].Address < changes[j].Address
+		}
+		return deltaI > deltaJ
+	})
+	if len(changes) > numLargestChanges {
+		changes = changes[:numLargestChanges]
+	}
+	result.LargestChanges = changes
+
+	return result, nil
+}
+
+func (sd *snapshotsDiffer) readBalances(index string) (map[string]float64, error) {
+	exists, err := sd.client.CheckIfIndexExists(index)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: index %s", ErrNoSnapshot, index)
+	}
+
+	balances := make(map[string]float64)
+	handler := func(responseBytes []byte) error {
+		for _, hit := range gjson.GetBytes(responseBytes, "hits.hits").Array() {
+			balances[hit.Get("_id").String()] = hit.Get("_source.totalBalanceWithStakeNum").Float()
+		}
+
+		return nil
+	}
+
+	err = sd.client.DoScrollRequestAllDocuments(index, []byte(diffBody), handler)
+	if err != nil {
+		return nil, err
+	}
+
+	return balances, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sd *snapshotsDiffer) IsInterfaceNil() bool {
+	return sd == nil
+}
@@ -0,0 +1,54 @@
+package snapshots
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotsDiffer_Diff(t *testing.T) {
+	t.Parallel()
+
+	pages := map[string]string{
+		"accounts-000001_4": `{"hits":{"hits":[
+			{"_id":"erd1a","_source":{"totalBalanceWithStakeNum":10}},
+			{"_id":"erd1b","_source":{"totalBalanceWithStakeNum":20}},
+			{"_id":"erd1c","_source":{"totalBalanceWithStakeNum":5}}
+		]}}`,
+		"accounts-000001_5": `{"hits":{"hits":[
+			{"_id":"erd1a","_source":{"totalBalanceWithStakeNum":10}},
+			{"_id":"erd1b","_source":{"totalBalanceWithStakeNum":50}},
+			{"_id":"erd1d","_source":{"totalBalanceWithStakeNum":1}}
+		]}}`,
+	}
+	client := &mocks.ElasticClientStub{
+		CheckIfIndexExistsCalled: func(index string) (bool, error) {
+			_, ok := pages[index]
+			return ok, nil
+		},
+		DoScrollRequestAllDocumentsCalled: func(index string, body []byte, handlerFunc func(responseBytes []byte) error) error {
+			return handlerFunc([]byte(pages[index]))
+		},
+	}
+
+	differ, err := NewSnapshotsDiffer(ArgsSnapshotsDiffer{Client: client})
+	require.Nil(t, err)
+	require.False(t, differ.IsInterfaceNil())
+
+	_, err = differ.Diff("accounts-000001_3", "accounts-000001_5")
+	require.ErrorIs(t, err, ErrNoSnapshot)
+
+	result, err := differ.Diff("accounts-000001_4", "accounts-000001_5")
+	require.Nil(t, err)
+	require.Equal(t, 3, result.NumAccountsBefore)
+	require.Equal(t, 3, result.NumAccountsAfter)
+	require.Equal(t, 1, result.NumAdded)
+	require.Equal(t, 1, result.NumRemoved)
+	require.Equal(t, 1, result.NumChanged)
+	require.Equal(t, float64(26), result.TotalBalanceWithStakeDelta)
+	require.Len(t, result.LargestChanges, 3)
+	require.Equal(t, "erd1b", result.LargestChanges[0].Address)
+	require.Equal(t, "erd1c", result.LargestChanges[1].Address)
+	require.Equal(t, float64(-5), result.LargestChanges[1].Delta())
+}
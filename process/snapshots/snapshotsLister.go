@@ -0,0 +1,162 @@
+package snapshots
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/tidwall/gjson"
+)
+
+// SnapshotInfo holds the details of an accounts snapshot of a destination client
+type SnapshotInfo struct {
+	ClientIndex  int       `json:"clientIndex"`
+	Index        string    `json:"index"`
+	Epoch        uint32    `json:"epoch"`
+	CreationDate time.Time `json:"creationDate"`
+	Aliases      []string  `json:"aliases"`
+	NumDocuments uint64    `json:"numDocuments"`
+}
+
+// ArgsSnapshotsLister holds the arguments needed to create a new snapshots lister
+type ArgsSnapshotsLister struct {
+	Clients       []ElasticClientHandler
+	AccountsIndex string
+}
+
+type snapshotsLister struct {
+	clients       []ElasticClientHandler
+	accountsIndex string
+}
+
+// NewSnapshotsLister will create a new instance of snapshotsLister
+func NewSnapshotsLister(args ArgsSnapshotsLister) (*snapshotsLister, error) {
+	err := checkClients(args.Clients)
+	if err != nil {
+		return nil, err
+	}
+	if args.AccountsIndex == "" {
+		return nil, ErrEmptyAccountsIndex
+	}
+
+	return &snapshotsLister{
+		clients:       args.Clients,
+		accountsIndex: args.AccountsIndex,
+	}, nil
+}
+
+// ListSnapshots returns the epoch snapshots of all the clients, sorted by client and by epoch
+func (sl *snapshotsLister) ListSnapshots() ([]*SnapshotInfo, error) {
+	snapshots := make([]*SnapshotInfo, 0)
+	for idx, client := range sl.clients {
+		clientSnapshots, err := sl.listClientSnapshots(idx, client)
+		if err != nil {
+			return nil, fmt.Errorf("%w, client index %d", err, idx)
+		}
+
+		snapshots = append(snapshots, clientSnapshots...)
+	}
+
+	return snapshots, nil
+}
+
+// GetLatestSnapshot returns the snapshot with the highest epoch of the first client
+func (sl *snapshotsLister) GetLatestSnapshot() (*SnapshotInfo, error) {
+	snapshots, err := sl.listClientSnapshots(0, sl.clients[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, ErrNoSnapshot
+	}
+
+	return snapshots[len(snapshots)-1], nil
+}
+
+// GetPreviousStakeStats returns the stake statistics of the latest snapshot of the first client older than the provided
+// epoch, nil if there is no such snapshot with stake statistics
+func (sl *snapshotsLister) GetPreviousStakeStats(epoch uint32) (*data.StakeStats, error) {
+	snapshots, err := sl.listClientSnapshots(0, sl.clients[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := len(snapshots) - 1; idx >= 0; idx-- {
+		if snapshots[idx].Epoch >= epoch {
+			continue
+		}
+
+		stakeStats, errGet := getStakeStats(sl.clients[0], snapshots[idx].Epoch)
+		if errGet != nil {
+			return nil, errGet
+		}
+		if stakeStats != nil {
+			return stakeStats, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func getStakeStats(client ElasticClientHandler, epoch uint32) (*data.StakeStats, error) {
+	response, err := client.DoMultiGet([]string{fmt.Sprintf(crossIndex.StakeStatsIDFormat, epoch)}, valuesIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	document := gjson.GetBytes(response, "docs.0")
+	if !document.Get("found").Bool() {
+		return nil, nil
+	}
+
+	stakeStats := &data.StakeStats{}
+	err = json.Unmarshal([]byte(document.Get("_source").Raw), stakeStats)
+	if err != nil {
+		return nil, err
+	}
+
+	return stakeStats, nil
+}
+
+func (sl *snapshotsLister) listClientSnapshots(clientIndex int, client ElasticClientHandler) ([]*SnapshotInfo, error) {
+	indices, err := client.GetIndices(sl.accountsIndex + "_*")
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*SnapshotInfo, 0, len(indices))
+	for _, indexInfo := range indices {
+		epoch, ok := parseSnapshotEpoch(sl.accountsIndex, indexInfo.Name)
+		if !ok {
+			continue
+		}
+
+		numDocuments, errCount := client.CountDocuments(indexInfo.Name)
+		if errCount != nil {
+			return nil, errCount
+		}
+
+		snapshots = append(snapshots, &SnapshotInfo{
+			ClientIndex:  clientIndex,
+			Index:        indexInfo.Name,
+			Epoch:        epoch,
+			CreationDate: indexInfo.CreationDate,
+			Aliases:      indexInfo.Aliases,
+			NumDocuments: numDocuments,
+		})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Epoch < snapshots[j].Epoch
+	})
+
+	return snapshots, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sl *snapshotsLister) IsInterfaceNil() bool {
+	return sl == nil
+}
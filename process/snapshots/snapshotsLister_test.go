@@ -0,0 +1,113 @@
+package snapshots
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSnapshotsLister(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSnapshotsLister(ArgsSnapshotsLister{AccountsIndex: "accounts"})
+	require.Equal(t, ErrNilElasticClient, err)
+
+	_, err = NewSnapshotsLister(ArgsSnapshotsLister{Clients: []ElasticClientHandler{nil}, AccountsIndex: "accounts"})
+	require.True(t, errors.Is(err, ErrNilElasticClient))
+
+	_, err = NewSnapshotsLister(ArgsSnapshotsLister{Clients: []ElasticClientHandler{&mocks.ElasticClientStub{}}})
+	require.Equal(t, ErrEmptyAccountsIndex, err)
+
+	lister, err := NewSnapshotsLister(ArgsSnapshotsLister{Clients: []ElasticClientHandler{&mocks.ElasticClientStub{}}, AccountsIndex: "accounts"})
+	require.Nil(t, err)
+	require.False(t, lister.IsInterfaceNil())
+}
+
+func TestSnapshotsLister_ListSnapshots(t *testing.T) {
+	t.Parallel()
+
+	client := &mocks.ElasticClientStub{
+		GetIndicesCalled: func(index string) ([]*data.IndexInfo, error) {
+			require.Equal(t, "accounts-000001_*", index)
+			return []*data.IndexInfo{
+				{Name: "accounts-000001_12", Aliases: []string{"latest"}},
+				{Name: "accounts-000001_9"},
+				{Name: "accounts-000001_restored"},
+			}, nil
+		},
+		CountDocumentsCalled: func(index string) (uint64, error) {
+			return uint64(len(index)), nil
+		},
+	}
+	lister, _ := NewSnapshotsLister(ArgsSnapshotsLister{
+		Clients:       []ElasticClientHandler{client, client},
+		AccountsIndex: "accounts-000001",
+	})
+
+	snapshots, err := lister.ListSnapshots()
+	require.Nil(t, err)
+	require.Len(t, snapshots, 4)
+	require.Equal(t, uint32(9), snapshots[0].Epoch)
+	require.Equal(t, uint32(12), snapshots[1].Epoch)
+	require.Equal(t, []string{"latest"}, snapshots[1].Aliases)
+	require.Equal(t, uint64(len("accounts-000001_12")), snapshots[1].NumDocuments)
+	require.Equal(t, 1, snapshots[3].ClientIndex)
+
+	latest, err := lister.GetLatestSnapshot()
+	require.Nil(t, err)
+	require.Equal(t, "accounts-000001_12", latest.Index)
+}
+
+func TestSnapshotsLister_GetLatestSnapshotNoSnapshot(t *testing.T) {
+	t.Parallel()
+
+	lister, _ := NewSnapshotsLister(ArgsSnapshotsLister{
+		Clients:       []ElasticClientHandler{&mocks.ElasticClientStub{}},
+		AccountsIndex: "accounts-000001",
+	})
+
+	_, err := lister.GetLatestSnapshot()
+	require.Equal(t, ErrNoSnapshot, err)
+}
+
+func TestSnapshotsLister_GetPreviousStakeStats(t *testing.T) {
+	t.Parallel()
+
+	requestedIDs := make([]string, 0)
+	client := &mocks.ElasticClientStub{
+		GetIndicesCalled: func(_ string) ([]*data.IndexInfo, error) {
+			return []*data.IndexInfo{
+				{Name: "accounts-000001_7"},
+				{Name: "accounts-000001_8"},
+				{Name: "accounts-000001_9"},
+				{Name: "accounts-000001_10"},
+			}, nil
+		},
+		DoMultiGetCalled: func(ids []string, index string) ([]byte, error) {
+			require.Equal(t, valuesIndex, index)
+			requestedIDs = append(requestedIDs, ids...)
+			if ids[0] == "stake-stats-7" {
+				return []byte(`{"docs":[{"found":true,"_source":{"epoch":7,"numStakers":100}}]}`), nil
+			}
+
+			return []byte(`{"docs":[{"found":false}]}`), nil
+		},
+	}
+	lister, _ := NewSnapshotsLister(ArgsSnapshotsLister{
+		Clients:       []ElasticClientHandler{client},
+		AccountsIndex: "accounts-000001",
+	})
+
+	// the snapshots of the current and of the later epochs are skipped, as well as the snapshots without stake stats
+	stakeStats, err := lister.GetPreviousStakeStats(9)
+	require.Nil(t, err)
+	require.Equal(t, &data.StakeStats{Epoch: 7, NumStakers: 100}, stakeStats)
+	require.Equal(t, []string{"stake-stats-8", "stake-stats-7"}, requestedIDs)
+
+	stakeStats, err = lister.GetPreviousStakeStats(7)
+	require.Nil(t, err)
+	require.Nil(t, stakeStats)
+}
@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/multiversx/mx-chain-core-go/core/check"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/core"
 	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
@@ -15,18 +18,27 @@ import (
 
 var log = logger.GetOrCreate("restClient")
 
+// path segments longer than this are addresses or storage keys, replaced in the endpoint label of the metrics
+const maxEndpointSegmentLength = 20
+
 type restClient struct {
-	httpClient *http.Client
-	url        string
+	httpClient     *http.Client
+	url            string
+	metricsHandler MetricsHandler
 }
 
 // NewRestClient will create a new instance of restClient
-func NewRestClient(url string) (*restClient, error) {
+func NewRestClient(url string, metricsHandler MetricsHandler) (*restClient, error) {
+	if check.IfNil(metricsHandler) {
+		return nil, ErrNilMetricsHandler
+	}
+
 	c := http.DefaultClient
 
 	return &restClient{
-		httpClient: c,
-		url:        url,
+		httpClient:     c,
+		url:            url,
+		metricsHandler: metricsHandler,
 	}, nil
 }
 
@@ -35,6 +47,18 @@ func (rc *restClient) CallGetRestEndPoint(
 	path string,
 	value interface{},
 	authenticationData data.RestApiAuthenticationData,
+) error {
+	start := time.Now()
+	err := rc.callGetRestEndPoint(path, value, authenticationData)
+	rc.metricsHandler.ObserveRESTRequest(endpointLabel(path), time.Since(start), err)
+
+	return err
+}
+
+func (rc *restClient) callGetRestEndPoint(
+	path string,
+	value interface{},
+	authenticationData data.RestApiAuthenticationData,
 ) error {
 	req, err := http.NewRequest("GET", rc.url+path, nil)
 	if err != nil {
@@ -74,6 +98,19 @@ func (rc *restClient) CallPostRestEndPoint(
 	dataR interface{},
 	response interface{},
 	authenticationData data.RestApiAuthenticationData,
+) error {
+	start := time.Now()
+	err := rc.callPostRestEndPoint(path, dataR, response, authenticationData)
+	rc.metricsHandler.ObserveRESTRequest(endpointLabel(path), time.Since(start), err)
+
+	return err
+}
+
+func (rc *restClient) callPostRestEndPoint(
+	path string,
+	dataR interface{},
+	response interface{},
+	authenticationData data.RestApiAuthenticationData,
 ) error {
 	buff, err := json.Marshal(dataR)
 	if err != nil {
@@ -122,3 +159,17 @@ func (rc *restClient) CallPostRestEndPoint(
 
 	return errors.New(genericApiResponse.Error)
 }
+
+// endpointLabel returns the path without its query and with the addresses and the storage keys replaced, so the
+// metrics have a label per endpoint instead of one per account
+func endpointLabel(path string) string {
+	path = strings.SplitN(path, "?", 2)[0]
+	segments := strings.Split(path, "/")
+	for idx, segment := range segments {
+		if len(segment) > maxEndpointSegmentLength {
+			segments[idx] = "{param}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
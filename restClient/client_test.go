@@ -0,0 +1,53 @@
+package restClient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRestClient(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewRestClient("http://localhost", nil)
+	require.Equal(t, ErrNilMetricsHandler, err)
+
+	_, err = NewRestClient("http://localhost", &mocks.MetricsHandlerStub{})
+	require.Nil(t, err)
+}
+
+func TestRestClient_CallGetRestEndPointObservesTheRequest(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/network/status/4294967295" {
+			_, _ = w.Write([]byte(`{"data":{"status":{}},"code":"successful"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`not json`))
+	}))
+	defer server.Close()
+
+	observedEndpoints := make([]string, 0)
+	numErrors := 0
+	rc, _ := NewRestClient(server.URL, &mocks.MetricsHandlerStub{
+		ObserveRESTRequestCalled: func(endpoint string, _ time.Duration, err error) {
+			observedEndpoints = append(observedEndpoints, endpoint)
+			if err != nil {
+				numErrors++
+			}
+		},
+	})
+
+	response := &data.GenericAPIResponse{}
+	require.Nil(t, rc.CallGetRestEndPoint("/network/status/4294967295", response, data.RestApiAuthenticationData{}))
+	require.NotNil(t, rc.CallGetRestEndPoint("/address/erd1qqqqqqqqqqqqqpgqfzydqmdw7m2vazsp6u5p95yxz76t2p9rd8ss0zp9ts/keys?onFinalBlock=true", response, data.RestApiAuthenticationData{}))
+
+	require.Equal(t, []string{"/network/status/4294967295", "/address/{param}/keys"}, observedEndpoints)
+	require.Equal(t, 1, numErrors)
+}
@@ -0,0 +1,6 @@
+package restClient
+
+import "errors"
+
+// ErrNilMetricsHandler signals that a nil metrics handler has been provided
+var ErrNilMetricsHandler = errors.New("nil metrics handler")
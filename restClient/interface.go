@@ -0,0 +1,9 @@
+package restClient
+
+import "time"
+
+// MetricsHandler defines what the collector of the latency and the errors of the requests should be able to do
+type MetricsHandler interface {
+	ObserveRESTRequest(endpoint string, duration time.Duration, err error)
+	IsInterfaceNil() bool
+}
@@ -1,3 +1,5 @@
+#!/usr/bin/env bash
+
 COUNT=0
 MAX_RETRIES=20
 CURRENT_DATE=$(date +'%Y_%m_%d')
@@ -27,31 +29,39 @@ if [ ! -d "${PATH_TO_INDICES_CONFIG}" ]; then
 fi
 
 
+# exit codes of the manager, the run report written after every run holding the details of the failure
+EXIT_CODE_SUCCESS=0
+EXIT_CODE_CONFIG=2
+
+EXIT_CODE=1
 while [ ${COUNT} -lt ${MAX_RETRIES} ]
 do
   CURRENT_LOGS_FILE="logs_${CURRENT_DATE}_$(( COUNT+1 )).txt"
 
   ${PATH_TO_MANAGER} -config "${PATH_TO_CONFIG}" -indices-path "${PATH_TO_INDICES_CONFIG}" | tee -a "${CURRENT_LOGS_FILE}"
+  EXIT_CODE=${PIPESTATUS[0]}
 
-  ERROR_OUTPUT=$(grep ERROR "${CURRENT_LOGS_FILE}" )
-
-  if [ -z "${ERROR_OUTPUT}" ]
+  if [ ${EXIT_CODE} -eq ${EXIT_CODE_SUCCESS} ]
   then
     break
-  else
-    echo "Something went wrong error: ${ERROR_OUTPUT}"
-    echo "Will retry "$(( COUNT+1 ))""
   fi
 
+  if [ ${EXIT_CODE} -eq ${EXIT_CODE_CONFIG} ]
+  then
+    echo "Invalid configuration, will not retry, check logs file ${CURRENT_LOGS_FILE}"
+    exit ${EXIT_CODE}
+  fi
+
+  echo "Something went wrong, exit code: ${EXIT_CODE}"
+  echo "Will retry "$(( COUNT+1 ))""
+
   COUNT=$(( COUNT+1 ))
 done
 
-if [ ${COUNT} -eq $(( MAX_RETRIES )) ]
+if [ ${EXIT_CODE} -ne ${EXIT_CODE_SUCCESS} ]
 then
-  echo "Reindex process failed, check logs file ${LOGS_FILE}"
-  exit 1
+  echo "Reindex process failed, check logs file ${CURRENT_LOGS_FILE}"
+  exit ${EXIT_CODE}
 else
   echo "Reindex accounts with stake success"
 fi
-
-
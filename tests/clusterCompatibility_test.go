@@ -0,0 +1,139 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-es-indexer-go/templates/noKibana"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/crossIndex/elasticDestination"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/data"
+	"github.com/multiversx/mx-chain-tools-accounts-manager-go/elasticClient"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	pathToIndicesConfig = "../cmd/manager/config/indices"
+	snapshotIndex       = "accounts-000001_5"
+)
+
+func prepareSnapshot(t *testing.T, address string) {
+	client, err := elasticClient.NewElasticClient(data.EsClientConfig{Address: address})
+	require.Nil(t, err)
+
+	destination, err := elasticDestination.NewElasticDestination(elasticDestination.ArgsElasticDestination{
+		Client:              client,
+		PathToIndicesConfig: pathToIndicesConfig,
+	})
+	require.Nil(t, err)
+
+	require.Nil(t, destination.PrepareSnapshot(snapshotIndex, 5))
+	require.Nil(t, destination.WriteMetadata("stake-stats-5", []byte(`{"key":"stakeStats"}`)))
+}
+
+func TestPrepareSnapshot_OpenSearch(t *testing.T) {
+	t.Parallel()
+
+	standIn, server := newClusterStandIn(t, "opensearch", "2.11.0")
+
+	prepareSnapshot(t, server.URL)
+
+	policy := standIn.ismPolicies[crossIndex.AccountsPolicyName]
+	require.NotNil(t, policy)
+	require.Equal(t, "hot", gjson.GetBytes(policy, "policy.default_state").String())
+	require.Equal(t, "delete", gjson.GetBytes(policy, "policy.states.0.transitions.0.state_name").String())
+	require.Equal(t, "90d", gjson.GetBytes(policy, "policy.states.0.transitions.0.conditions.min_index_age").String())
+	require.True(t, gjson.GetBytes(policy, "policy.states.1.actions.0.delete").Exists())
+
+	require.False(t, gjson.GetBytes(standIn.indices[snapshotIndex], "settings.index\\.lifecycle\\.name").Exists())
+	accountsTemplate := standIn.templates["_index_template/accounts-manager-accounts"]
+	require.False(t, gjson.GetBytes(accountsTemplate, "template.settings.index\\.lifecycle\\.name").Exists())
+	require.Equal(t, int64(1), gjson.GetBytes(accountsTemplate, "template.settings.number_of_shards").Int())
+	require.Equal(t, crossIndex.AccountsPolicyName, standIn.ismAttached[snapshotIndex])
+	require.Contains(t, standIn.indices, "values")
+	require.Contains(t, standIn.documents, "values/stake-stats-5")
+
+	// a second run updates the existing policy and skips the unchanged templates
+	numTemplatePuts := standIn.numTemplatePuts
+	prepareSnapshot(t, server.URL)
+	require.Equal(t, 2, standIn.ismSeqNo[crossIndex.AccountsPolicyName])
+	require.Equal(t, numTemplatePuts, standIn.numTemplatePuts)
+}
+
+func TestPrepareSnapshot_Elasticsearch(t *testing.T) {
+	t.Parallel()
+
+	for _, version := range []string{"7.17.9", "8.11.1"} {
+		standIn, server := newClusterStandIn(t, "elasticsearch", version)
+
+		prepareSnapshot(t, server.URL)
+
+		require.Contains(t, standIn.ilmPolicies, crossIndex.AccountsPolicyName)
+		require.Empty(t, standIn.ismPolicies)
+		require.Equal(t, crossIndex.AccountsPolicyName, gjson.GetBytes(standIn.indices[snapshotIndex], "settings.index\\.lifecycle\\.name").String())
+		require.Contains(t, standIn.indices, "values")
+
+		accountsTemplate := standIn.templates["_index_template/accounts-manager-accounts"]
+		require.Equal(t, crossIndex.AccountsPolicyName, gjson.GetBytes(accountsTemplate, "template.settings.index\\.lifecycle\\.name").String())
+		require.Equal(t, "accounts-manager", gjson.GetBytes(accountsTemplate, "_meta.managedBy").String())
+		require.Len(t, gjson.GetBytes(accountsTemplate, "composed_of").Array(), 3)
+		for _, component := range gjson.GetBytes(accountsTemplate, "composed_of").Array() {
+			require.Contains(t, standIn.templates, "_component_template/"+component.String())
+		}
+		require.Contains(t, standIn.templates, "_index_template/accounts-manager-values")
+	}
+}
+
+func TestPrepareSnapshot_SharedClusterWithIndexerTemplate(t *testing.T) {
+	t.Parallel()
+
+	standIn, server := newClusterStandIn(t, "elasticsearch", "7.17.9")
+	indexerTemplate, err := json.Marshal(noKibana.Accounts)
+	require.Nil(t, err)
+	standIn.templates["_template/accounts"] = indexerTemplate
+
+	prepareSnapshot(t, server.URL)
+
+	// the composable template of the manager wins over the legacy template of the indexer, so it has to hold the
+	// mappings of the indexed account fields as well
+	properties := gjson.GetBytes(standIn.indices[snapshotIndex], "mappings.properties")
+	require.Equal(t, "keyword", properties.Get("address.type").String())
+	require.Equal(t, "keyword", properties.Get("balance.type").String())
+	require.Equal(t, "keyword", properties.Get("totalBalanceWithStake.type").String())
+	require.Equal(t, "long", properties.Get("nonce.type").String())
+	require.Equal(t, "long", properties.Get("shardID.type").String())
+	require.Equal(t, "date", properties.Get("timestamp.type").String())
+	require.Equal(t, "epoch_second", properties.Get("timestamp.format").String())
+	require.Equal(t, "double", properties.Get("totalStakeNum.type").String())
+}
+
+func TestPrepareSnapshot_MappingDrift(t *testing.T) {
+	t.Parallel()
+
+	standIn, server := newClusterStandIn(t, "elasticsearch", "8.11.1")
+	standIn.indices["accounts-000001_4"] = []byte(`{"mappings":{"properties":{"totalStakeNum":{"type":"keyword"}}}}`)
+
+	client, err := elasticClient.NewElasticClient(data.EsClientConfig{Address: server.URL})
+	require.Nil(t, err)
+
+	destination, err := elasticDestination.NewElasticDestination(elasticDestination.ArgsElasticDestination{
+		Client:              client,
+		PathToIndicesConfig: pathToIndicesConfig,
+	})
+	require.Nil(t, err)
+
+	err = destination.PrepareSnapshot(snapshotIndex, 5)
+	require.True(t, errors.Is(err, elasticDestination.ErrIncompatibleMapping))
+	require.NotContains(t, standIn.indices, snapshotIndex)
+
+	destination, err = elasticDestination.NewElasticDestination(elasticDestination.ArgsElasticDestination{
+		Client:              client,
+		PathToIndicesConfig: pathToIndicesConfig,
+		ForceMappingChanges: true,
+	})
+	require.Nil(t, err)
+	require.Nil(t, destination.PrepareSnapshot(snapshotIndex, 5))
+	require.Contains(t, standIn.indices, snapshotIndex)
+}
@@ -0,0 +1,262 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/tidwall/gjson"
+)
+
+// clusterStandIn is an in-memory stand-in of an Elasticsearch or OpenSearch node, which answers the requests used by
+// the accounts manager when creating indices and policies
+type clusterStandIn struct {
+	mut             sync.Mutex
+	flavour         string
+	version         string
+	indices         map[string][]byte
+	ilmPolicies     map[string][]byte
+	ismPolicies     map[string][]byte
+	ismSeqNo        map[string]int
+	ismAttached     map[string]string
+	documents       map[string][]byte
+	templates       map[string][]byte
+	numTemplatePuts int
+}
+
+func newClusterStandIn(t *testing.T, flavour string, version string) (*clusterStandIn, *httptest.Server) {
+	standIn := &clusterStandIn{
+		flavour:     flavour,
+		version:     version,
+		indices:     make(map[string][]byte),
+		ilmPolicies: make(map[string][]byte),
+		ismPolicies: make(map[string][]byte),
+		ismSeqNo:    make(map[string]int),
+		ismAttached: make(map[string]string),
+		documents:   make(map[string][]byte),
+		templates:   make(map[string][]byte),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(standIn.handle))
+	t.Cleanup(server.Close)
+
+	return standIn, server
+}
+
+func (cs *clusterStandIn) isOpenSearch() bool {
+	return cs.flavour == "opensearch"
+}
+
+func (cs *clusterStandIn) handle(w http.ResponseWriter, r *http.Request) {
+	cs.mut.Lock()
+	defer cs.mut.Unlock()
+
+	body, _ := ioutil.ReadAll(r.Body)
+	path := strings.Trim(r.URL.Path, "/")
+	parts := strings.Split(path, "/")
+
+	switch {
+	case path == "" && r.Method == http.MethodGet:
+		cs.writeInfo(w)
+	case strings.HasPrefix(path, "_ilm/policy/") && r.Method == http.MethodPut:
+		if cs.isOpenSearch() {
+			writeJSON(w, http.StatusBadRequest, `{"error":"no handler found for uri [/_ilm/policy]"}`)
+			return
+		}
+		cs.ilmPolicies[parts[2]] = body
+		writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+	case len(parts) == 2 && parts[1] == "_mapping" && r.Method == http.MethodGet:
+		cs.writeMappings(w, parts[0])
+	case len(parts) == 2 && (parts[0] == "_index_template" || parts[0] == "_component_template" || parts[0] == "_template"):
+		cs.handleTemplate(w, r, parts[0], parts[1], body)
+	case strings.HasPrefix(path, "_plugins/_ism/policies/"):
+		cs.handleISMPolicy(w, r, parts[3], body)
+	case strings.HasPrefix(path, "_plugins/_ism/add/") && r.Method == http.MethodPost:
+		index := parts[3]
+		policy := struct {
+			PolicyID string `json:"policy_id"`
+		}{}
+		_ = json.Unmarshal(body, &policy)
+		_, indexExists := cs.indices[index]
+		_, policyExists := cs.ismPolicies[policy.PolicyID]
+		if !indexExists || !policyExists {
+			writeJSON(w, http.StatusOK, `{"updated_indices":0,"failures":true,"failed_indices":[{"index_name":"`+index+`"}]}`)
+			return
+		}
+		cs.ismAttached[index] = policy.PolicyID
+		writeJSON(w, http.StatusOK, `{"updated_indices":1,"failures":false,"failed_indices":[]}`)
+	case len(parts) == 1 && r.Method == http.MethodHead:
+		if _, ok := cs.indices[parts[0]]; ok {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	case len(parts) == 1 && r.Method == http.MethodPut:
+		if cs.isOpenSearch() && strings.Contains(string(body), "index.lifecycle.name") {
+			writeJSON(w, http.StatusBadRequest, `{"error":{"type":"illegal_argument_exception","reason":"unknown setting [index.lifecycle.name]"}}`)
+			return
+		}
+		cs.indices[parts[0]] = cs.applyTemplates(parts[0], body)
+		writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+	case len(parts) == 3 && parts[1] == "_doc":
+		cs.documents[parts[0]+"/"+parts[2]] = body
+		writeJSON(w, http.StatusCreated, `{"result":"created"}`)
+	default:
+		writeJSON(w, http.StatusNotFound, fmt.Sprintf(`{"error":"unknown request %s %s"}`, r.Method, path))
+	}
+}
+
+func (cs *clusterStandIn) writeInfo(w http.ResponseWriter) {
+	distribution := ""
+	if cs.isOpenSearch() {
+		distribution = `"distribution":"opensearch",`
+	}
+
+	writeJSON(w, http.StatusOK, fmt.Sprintf(`{"name":"node-1","version":{%s"number":"%s"},"tagline":"stand-in"}`, distribution, cs.version))
+}
+
+func (cs *clusterStandIn) handleISMPolicy(w http.ResponseWriter, r *http.Request, policyName string, body []byte) {
+	if !cs.isOpenSearch() {
+		writeJSON(w, http.StatusBadRequest, `{"error":"no handler found"}`)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, fmt.Sprintf(`{"_id":"%s","_seq_no":%d,"_primary_term":1,"policy":{}}`, policyName, cs.ismSeqNo[policyName]))
+	case http.MethodPut:
+		_, exists := cs.ismPolicies[policyName]
+		if exists && r.URL.Query().Get("if_seq_no") != fmt.Sprintf("%d", cs.ismSeqNo[policyName]) {
+			writeJSON(w, http.StatusConflict, `{"error":{"type":"version_conflict_engine_exception"}}`)
+			return
+		}
+		cs.ismPolicies[policyName] = body
+		cs.ismSeqNo[policyName]++
+		writeJSON(w, http.StatusCreated, fmt.Sprintf(`{"_id":"%s"}`, policyName))
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, `{}`)
+	}
+}
+
+func (cs *clusterStandIn) handleTemplate(w http.ResponseWriter, r *http.Request, kind string, name string, body []byte) {
+	key := kind + "/" + name
+
+	switch r.Method {
+	case http.MethodGet:
+		template, ok := cs.templates[key]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, `{"error":"template missing"}`)
+			return
+		}
+		field := strings.TrimPrefix(kind, "_")
+		writeJSON(w, http.StatusOK, fmt.Sprintf(`{"%ss":[{"name":"%s","%s":%s}]}`, field, name, field, template))
+	case http.MethodPut:
+		if cs.isOpenSearch() && strings.Contains(string(body), "index.lifecycle.name") {
+			writeJSON(w, http.StatusBadRequest, `{"error":{"type":"illegal_argument_exception","reason":"unknown setting [index.lifecycle.name]"}}`)
+			return
+		}
+		cs.templates[key] = body
+		cs.numTemplatePuts++
+		writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, `{}`)
+	}
+}
+
+// applyTemplates returns the body of a created index holding the mappings of the matching templates. As in
+// Elasticsearch 7.8+, the composable template with the highest priority wins and the legacy templates are ignored if
+// a composable template matches
+func (cs *clusterStandIn) applyTemplates(index string, body []byte) []byte {
+	properties := make(map[string]interface{})
+	indexTemplate, found := cs.findIndexTemplate(index)
+	if found {
+		for _, component := range gjson.GetBytes(indexTemplate, "composed_of").Array() {
+			mergeProperties(properties, cs.templates["_component_template/"+component.String()], "template.mappings.properties")
+		}
+		mergeProperties(properties, indexTemplate, "template.mappings.properties")
+	} else {
+		for key, legacyTemplate := range cs.templates {
+			if strings.HasPrefix(key, "_template/") && matchesIndexPatterns(index, legacyTemplate) {
+				mergeProperties(properties, legacyTemplate, "mappings.properties")
+			}
+		}
+	}
+	mergeProperties(properties, body, "mappings.properties")
+	if len(properties) == 0 {
+		return body
+	}
+
+	indexBody := make(map[string]interface{})
+	_ = json.Unmarshal(body, &indexBody)
+	indexBody["mappings"] = map[string]interface{}{"properties": properties}
+	indexBodyBytes, _ := json.Marshal(indexBody)
+
+	return indexBodyBytes
+}
+
+func (cs *clusterStandIn) findIndexTemplate(index string) ([]byte, bool) {
+	var bestTemplate []byte
+	bestPriority := int64(-1)
+	for key, template := range cs.templates {
+		if !strings.HasPrefix(key, "_index_template/") || !matchesIndexPatterns(index, template) {
+			continue
+		}
+
+		priority := gjson.GetBytes(template, "priority").Int()
+		if priority > bestPriority {
+			bestTemplate = template
+			bestPriority = priority
+		}
+	}
+
+	return bestTemplate, bestTemplate != nil
+}
+
+func matchesIndexPatterns(index string, template []byte) bool {
+	for _, pattern := range gjson.GetBytes(template, "index_patterns").Array() {
+		matches, _ := filepath.Match(pattern.String(), index)
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mergeProperties(properties map[string]interface{}, body []byte, path string) {
+	newProperties := make(map[string]interface{})
+	_ = json.Unmarshal([]byte(gjson.GetBytes(body, path).Raw), &newProperties)
+	for field, mapping := range newProperties {
+		properties[field] = mapping
+	}
+}
+
+func (cs *clusterStandIn) writeMappings(w http.ResponseWriter, pattern string) {
+	mappings := make([]string, 0)
+	for index, body := range cs.indices {
+		matches, _ := filepath.Match(pattern, index)
+		if !matches {
+			continue
+		}
+
+		mapping := gjson.GetBytes(body, "mappings").Raw
+		if mapping == "" {
+			mapping = "{}"
+		}
+		mappings = append(mappings, fmt.Sprintf(`"%s":{"mappings":%s}`, index, mapping))
+	}
+
+	writeJSON(w, http.StatusOK, "{"+strings.Join(mappings, ",")+"}")
+}
+
+func writeJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(body))
+}
@@ -32,7 +32,7 @@ func TestIndex(t *testing.T) {
 }
 
 func generateAccountsAndIndex(t *testing.T, numberOfAccounts int, handler process.ElasticClientHandler) {
-	ap, _ := accountsIndexer.NewAccountsIndexer(handler)
+	ap, _ := accountsIndexer.NewAccountsIndexer(accountsIndexer.ArgsAccountsIndexer{ElasticClient: handler})
 	err := ap.IndexAccounts(generateAccounts(numberOfAccounts), "accounts-000001")
 	require.Nil(t, err)
 